@@ -0,0 +1,148 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"application/core/interfaces"
+	"application/driven/memory"
+	"application/driven/storage"
+	"application/driver/backup"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rokwire/core-auth-library-go/v3/envloader"
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+var (
+	// Version : version of this executable
+	Version string
+	// Build : build date of this executable
+	Build string
+)
+
+const usage = `Usage:
+  backup export [-collections <names>] [-file <path>]
+  backup restore [-collections <names>] [-file <path>] [-remap-ids] [-anonymize] [-replace]
+
+Exports the service collections (%s) to a JSONL backup archive, or restores them from one.
+The storage is selected with the same environment variables as the service.
+
+`
+
+func main() {
+	if len(Version) == 0 {
+		Version = "dev"
+	}
+
+	if len(os.Args) < 2 || (os.Args[1] != "export" && os.Args[1] != "restore") {
+		fmt.Fprintf(os.Stderr, usage, strings.Join(backup.Collections, ", "))
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	collections := flags.String("collections", "", "comma separated list of the collections to "+command+", all collections if empty")
+	file := flags.String("file", "-", "path of the backup archive, - for standard input or output")
+	remapIDs := flags.Bool("remap-ids", false, "assign new IDs to the restored configs and survey responses")
+	anonymize := flags.Bool("anonymize", false, "replace the user IDs of the restored survey responses and match results with random IDs")
+	replace := flags.Bool("replace", false, "replace the stored documents with the same IDs as the restored documents")
+	flags.Parse(os.Args[2:])
+
+	serviceID := "skills-to-jobs"
+
+	logger := logs.NewLogger(serviceID+"-backup", nil)
+	envLoader := envloader.NewEnvLoader(Version, logger)
+	envPrefix := strings.ReplaceAll(strings.ToUpper(serviceID), "-", "_") + "_"
+
+	storageAdapter := newStorageAdapter(envLoader, envPrefix, logger)
+
+	var collectionList []string
+	if len(*collections) > 0 {
+		collectionList = strings.Split(*collections, ",")
+	}
+
+	var counts map[string]int
+	var err error
+	switch command {
+	case "export":
+		var w io.Writer = os.Stdout
+		if *file != "-" {
+			f, createErr := os.Create(*file)
+			if createErr != nil {
+				logger.Fatalf("Cannot create the backup archive: %v", createErr)
+			}
+			defer f.Close()
+			w = f
+		}
+		counts, err = backup.Export(storageAdapter, w, collectionList, Version)
+	case "restore":
+		var r io.Reader = os.Stdin
+		if *file != "-" {
+			f, openErr := os.Open(*file)
+			if openErr != nil {
+				logger.Fatalf("Cannot open the backup archive: %v", openErr)
+			}
+			defer f.Close()
+			r = f
+		}
+		options := backup.RestoreOptions{Collections: collectionList, RemapIDs: *remapIDs, Anonymize: *anonymize, Replace: *replace}
+		counts, err = backup.Restore(storageAdapter, r, options)
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		logger.Infof("%s %s: %d documents", command, name, counts[name])
+	}
+	if err != nil {
+		logger.Fatalf("Error running %s: %v", command, err)
+	}
+}
+
+// newStorageAdapter creates and starts the storage adapter configured for the service
+func newStorageAdapter(envLoader envloader.EnvLoader, envPrefix string, logger *logs.Logger) interfaces.Storage {
+	storageType := envLoader.GetAndLogEnvVar(envPrefix+"STORAGE", false, false)
+	switch storageType {
+	case "", "mongodb":
+		mongoDBAuth := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_AUTH", true, true)
+		mongoDBName := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_DATABASE", true, false)
+		mongoTimeout := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_TIMEOUT", false, false)
+		encryptionKeys := envLoader.GetAndLogEnvVar(envPrefix+"ENCRYPTION_KEYS", false, true)
+		mongoAdapter := storage.NewStorageAdapter(mongoDBAuth, mongoDBName, mongoTimeout, encryptionKeys, nil, logger)
+		err := mongoAdapter.Start()
+		if err != nil {
+			logger.Fatalf("Cannot start the mongoDB adapter: %v", err)
+		}
+		return mongoAdapter
+	case "memory":
+		fixturesDir := envLoader.GetAndLogEnvVar(envPrefix+"STORAGE_FIXTURES_DIR", false, false)
+		memoryAdapter := memory.NewStorageAdapter(fixturesDir, logger)
+		err := memoryAdapter.Start()
+		if err != nil {
+			logger.Fatalf("Cannot start the memory storage adapter: %v", err)
+		}
+		return memoryAdapter
+	}
+	logger.Fatalf("Invalid storage type: %s", storageType)
+	return nil
+}
@@ -15,7 +15,9 @@
 package core
 
 import (
+	"application/core/interfaces"
 	"application/core/model"
+	"application/utils"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,7 +38,7 @@ func (a appAdmin) GetConfig(id string, claims *tokenauth.Claims) (*model.Config,
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
 	}
 	if config == nil {
-		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"id": id})
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"id": id}).SetStatus(utils.ErrorStatusNotFound)
 	}
 
 	err = claims.CanAccess(config.AppID, config.OrgID, config.System)
@@ -73,8 +75,14 @@ func (a appAdmin) CreateConfig(config model.Config, claims *tokenauth.Claims) (*
 		return nil, errors.WrapErrorAction(logutils.ActionValidate, "config access", nil, err)
 	}
 
+	err = validateConfigData(config)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeConfigData, nil, err)
+	}
+
 	config.ID = uuid.NewString()
 	config.DateCreated = time.Now().UTC()
+	config.Revision = 1
 	err = a.app.storage.InsertConfig(config)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeConfig, nil, err)
@@ -93,7 +101,7 @@ func (a appAdmin) UpdateConfig(config model.Config, claims *tokenauth.Claims) er
 		return errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
 	}
 	if oldConfig == nil {
-		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"type": config.Type, "app_id": config.AppID, "org_id": config.OrgID})
+		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"type": config.Type, "app_id": config.AppID, "org_id": config.OrgID}).SetStatus(utils.ErrorStatusNotFound)
 	}
 
 	// cannot update a system config if not a system admin
@@ -105,6 +113,11 @@ func (a appAdmin) UpdateConfig(config model.Config, claims *tokenauth.Claims) er
 		return errors.WrapErrorAction(logutils.ActionValidate, "config access", nil, err)
 	}
 
+	err = validateConfigData(config)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, model.TypeConfigData, nil, err)
+	}
+
 	now := time.Now().UTC()
 	config.ID = oldConfig.ID
 	config.DateUpdated = &now
@@ -122,7 +135,7 @@ func (a appAdmin) DeleteConfig(id string, claims *tokenauth.Claims) error {
 		return errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
 	}
 	if config == nil {
-		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"id": id})
+		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, &logutils.FieldArgs{"id": id}).SetStatus(utils.ErrorStatusNotFound)
 	}
 
 	err = claims.CanAccess(config.AppID, config.OrgID, config.System)
@@ -137,6 +150,294 @@ func (a appAdmin) DeleteConfig(id string, claims *tokenauth.Claims) error {
 	return nil
 }
 
+func (a appAdmin) GetOccupationData(code string) (*model.OccupationData, error) {
+	occupationData, err := a.app.storage.GetOccupationData(code)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+	return occupationData, nil
+}
+
+func (a appAdmin) GetOccupationDatas() ([]model.OccupationData, error) {
+	occupationDatas, err := a.app.storage.GetAllOccupationDatas()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+	return occupationDatas, nil
+}
+
+func (a appAdmin) CreateOccupationData(occupationData model.OccupationData) (*model.OccupationData, error) {
+	err := validateOccupationData(occupationData)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeOccupationData, nil, err)
+	}
+
+	now := time.Now().UTC()
+	occupationData.DateUpdated = &now
+	err = a.app.storage.InsertOccupationData(occupationData)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeOccupationData, nil, err)
+	}
+
+	a.app.resetOccupationGraph()
+	return &occupationData, nil
+}
+
+func (a appAdmin) UpdateOccupationData(occupationData model.OccupationData) error {
+	err := validateOccupationData(occupationData)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, model.TypeOccupationData, nil, err)
+	}
+
+	now := time.Now().UTC()
+	occupationData.DateUpdated = &now
+	err = a.app.storage.UpdateOccupationData(occupationData)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeOccupationData, nil, err)
+	}
+
+	a.app.resetOccupationGraph()
+	return nil
+}
+
+func (a appAdmin) DeleteOccupationData(code string) error {
+	err := a.app.storage.DeleteOccupationData(code)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeOccupationData, nil, err)
+	}
+
+	a.app.resetOccupationGraph()
+	return nil
+}
+
+func (a appAdmin) GetOccupationDataReport() (*model.OccupationDataReport, error) {
+	occupationDatas, err := a.app.storage.GetAllOccupationDatas()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+
+	report := newOccupationDataReport(occupationDatas)
+	return &report, nil
+}
+
+func (a appAdmin) GetRetentionReport() (*model.RetentionReport, error) {
+	return a.app.newRetentionReport()
+}
+
+// SearchSurveyDatas returns the survey data matching the search, auditing the access
+func (a appAdmin) SearchSurveyDatas(search model.UserDataSearch, claims *tokenauth.Claims) ([]model.SurveyData, error) {
+	search = userDataSearchWithLimit(search)
+	surveyDatas, err := a.app.storage.SearchSurveyDatas(search)
+	a.audit(claims, "search survey data", userDataSearchFields(search, len(surveyDatas)), err)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, nil, err)
+	}
+	return surveyDatas, nil
+}
+
+// GetSurveyData returns the survey data with the given ID, auditing the access
+func (a appAdmin) GetSurveyData(id string, claims *tokenauth.Claims) (*model.SurveyData, error) {
+	surveyData, err := a.app.storage.GetSurveyData(id)
+	fields := logutils.Fields{"survey_id": id}
+	if surveyData != nil {
+		fields["account_id"] = surveyData.AccountID
+	}
+	a.audit(claims, "get survey data", fields, err)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, nil, err)
+	}
+	return surveyData, nil
+}
+
+// SearchUserMatchingResults returns the match results matching the search, auditing the access
+func (a appAdmin) SearchUserMatchingResults(search model.UserDataSearch, claims *tokenauth.Claims) ([]model.UserMatchingResult, error) {
+	search = userDataSearchWithLimit(search)
+	userMatchingResults, err := a.app.storage.SearchUserMatchingResults(search)
+	a.audit(claims, "search match results", userDataSearchFields(search, len(userMatchingResults)), err)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, nil, err)
+	}
+	return userMatchingResults, nil
+}
+
+// GetUserMatchingResult returns the match result of an account, auditing the access
+func (a appAdmin) GetUserMatchingResult(accountID string, claims *tokenauth.Claims) (*model.UserMatchingResult, error) {
+	userMatchingResult, err := a.app.storage.GetUserMatchingResult(accountID)
+	a.audit(claims, "get match result", logutils.Fields{"account_id": accountID}, err)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, nil, err)
+	}
+	return userMatchingResult, nil
+}
+
+// MatchUserOccupations starts matching the latest survey data of an account to the occupations again, returning that survey data
+func (a appAdmin) MatchUserOccupations(accountID string, claims *tokenauth.Claims) (*model.SurveyData, error) {
+	surveyData, err := a.latestSurveyData(accountID)
+	fields := logutils.Fields{"account_id": accountID}
+	if surveyData != nil {
+		fields["survey_id"] = surveyData.ID
+	}
+	a.audit(claims, "match occupations", fields, err)
+	if err != nil {
+		return nil, err
+	}
+
+	a.app.Client.MatchOccupations(*surveyData, accountID)
+	return surveyData, nil
+}
+
+// latestSurveyData returns the most recently created survey data of an account
+func (a appAdmin) latestSurveyData(accountID string) (*model.SurveyData, error) {
+	surveyDatas, err := a.app.storage.SearchSurveyDatas(model.UserDataSearch{AccountID: &accountID, Limit: 1})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	if len(surveyDatas) == 0 {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, &logutils.FieldArgs{"account_id": accountID}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return &surveyDatas[0], nil
+}
+
+// DeleteUserData deletes the survey data, match result and its history, idempotency keys and data exports of an account, auditing the deletion
+func (a appAdmin) DeleteUserData(accountID string, claims *tokenauth.Claims) (*model.UserDataDeletion, error) {
+	deletion := model.UserDataDeletion{AccountID: accountID}
+	err := a.app.storage.PerformTransaction(func(storage interfaces.Storage) error {
+		var err error
+		deletion.SurveyDatas, err = storage.DeleteSurveyDatasByAccount(accountID)
+		if err != nil {
+			return err
+		}
+
+		err = storage.DeleteUserMatchingResult(accountID)
+		if err == nil {
+			deletion.MatchResults = 1
+		} else if errors.Status(err) != utils.ErrorStatusNotFound {
+			return err
+		}
+
+		deletion.MatchResultsHistory, err = storage.DeleteUserMatchingResultHistoriesByAccount(accountID)
+		if err != nil {
+			return err
+		}
+
+		deletion.IdempotencyKeys, err = storage.DeleteIdempotencyKeysByAccount(accountID)
+		if err != nil {
+			return err
+		}
+
+		deletion.UserDataExports, err = storage.DeleteUserDataExportsByAccount(accountID)
+		return err
+	})
+	a.audit(claims, "delete user data", logutils.Fields{"account_id": accountID, "survey_data": deletion.SurveyDatas, "match_results": deletion.MatchResults,
+		"match_results_history": deletion.MatchResultsHistory, "idempotency_keys": deletion.IdempotencyKeys, "user_data_exports": deletion.UserDataExports}, err)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserDataDeletion, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	return &deletion, nil
+}
+
+// audit logs an access of an admin to the data of the users, with the error that made it fail if any
+//
+//	The audit logs have the "admin audit" message, so that they can be collected apart from the other logs.
+func (a appAdmin) audit(claims *tokenauth.Claims, action string, fields logutils.Fields, err error) {
+	auditFields := logutils.Fields{"audit_action": action}
+	for key, value := range fields {
+		auditFields[key] = value
+	}
+	if claims != nil {
+		auditFields["admin_account_id"] = claims.Subject
+		auditFields["admin_app_id"] = claims.AppID
+		auditFields["admin_org_id"] = claims.OrgID
+	}
+
+	if err != nil {
+		auditFields["error"] = err.Error()
+		a.app.logger.WarnWithFields("admin audit", auditFields)
+		return
+	}
+	a.app.logger.InfoWithFields("admin audit", auditFields)
+}
+
+// userDataSearchWithLimit sets the default limit of a search without one, and caps the limit of the others
+func userDataSearchWithLimit(search model.UserDataSearch) model.UserDataSearch {
+	if search.Limit <= 0 {
+		search.Limit = model.DefaultUserDataSearchLimit
+	}
+	if search.Limit > model.MaxUserDataSearchLimit {
+		search.Limit = model.MaxUserDataSearchLimit
+	}
+	return search
+}
+
+// userDataSearchFields returns the audit log fields of a user data search which found count results
+func userDataSearchFields(search model.UserDataSearch, count int) logutils.Fields {
+	fields := logutils.Fields{"limit": search.Limit, "offset": search.Offset, "count": count}
+	if search.AccountID != nil {
+		fields["account_id"] = *search.AccountID
+	}
+	if search.Version != nil {
+		fields["version"] = *search.Version
+	}
+	if search.From != nil {
+		fields["from"] = search.From.Format(time.RFC3339)
+	}
+	if search.To != nil {
+		fields["to"] = search.To.Format(time.RFC3339)
+	}
+	return fields
+}
+
+// validateConfigData checks the data of the config types with a known structure
+func validateConfigData(config model.Config) error {
+	switch config.Type {
+	case model.ConfigTypeRetention:
+		return validateRetentionConfigData(config.Data)
+	case model.ConfigTypeRateLimit:
+		return validateRateLimitConfigData(config.Data)
+	}
+	return nil
+}
+
+// validateOccupationData checks that an occupation has a valid ONET-SOC code, job zone and known work styles within their scales
+func validateOccupationData(occupationData model.OccupationData) error {
+	if !model.OccupationCodeRegex.MatchString(occupationData.Code) {
+		return errors.ErrorData(logutils.StatusInvalid, "occupation code", &logutils.FieldArgs{"code": occupationData.Code}).SetStatus(utils.ErrorStatusInvalid)
+	}
+	if len(occupationData.Name) == 0 {
+		return errors.ErrorData(logutils.StatusMissing, "occupation name", &logutils.FieldArgs{"code": occupationData.Code}).SetStatus(utils.ErrorStatusInvalid)
+	}
+	if occupationData.JobZone < 0 || occupationData.JobZone > model.MaxJobZone {
+		return errors.ErrorData(logutils.StatusInvalid, "job zone", &logutils.FieldArgs{"job_zone": occupationData.JobZone}).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	workstyles := map[string]bool{}
+	for _, workstyle := range occupationData.Workstyles {
+		if !logutils.ContainsString(model.OnetWorkstyles, workstyle.Name) {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeWorkstyle, &logutils.FieldArgs{"name": workstyle.Name}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if workstyles[workstyle.Name] {
+			return errors.ErrorData(logutils.StatusFound, "duplicate workstyle", &logutils.FieldArgs{"name": workstyle.Name}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		workstyles[workstyle.Name] = true
+
+		scale, ok := model.WorkstyleScales[workstyle.Scale]
+		if !ok {
+			return errors.ErrorData(logutils.StatusInvalid, "workstyle scale", &logutils.FieldArgs{"name": workstyle.Name, "scale": workstyle.Scale}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if workstyle.Value < scale[0] || workstyle.Value > scale[1] {
+			return errors.ErrorData(logutils.StatusInvalid, "workstyle value", &logutils.FieldArgs{"name": workstyle.Name, "scale": workstyle.Scale, "value": workstyle.Value}).SetStatus(utils.ErrorStatusInvalid)
+		}
+	}
+
+	for _, related := range occupationData.RelatedOccupations {
+		if !model.OccupationCodeRegex.MatchString(related.Code) {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeRelatedOccupation, &logutils.FieldArgs{"code": related.Code}).SetStatus(utils.ErrorStatusInvalid)
+		}
+	}
+
+	return nil
+}
+
 // newAppAdmin creates new appAdmin
 func newAppAdmin(app *Application) appAdmin {
 	return appAdmin{app: app}
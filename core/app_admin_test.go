@@ -0,0 +1,268 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"application/core/interfaces/mocks"
+	"application/core/model"
+	"application/driven/memory"
+	"application/utils"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAppAdmin_CreateOccupationData(t *testing.T) {
+	valid := model.OccupationData{Code: "15-1252.00", Name: "Software Developers", JobZone: 4,
+		Workstyles: []model.Workstyle{{Name: "Analytical Thinking", Scale: "IM", Value: 4.5}, {Name: "Innovation", Scale: "Importance", Value: 80}}}
+
+	invalidCode := valid
+	invalidCode.Code = "15-1252"
+	unknownWorkstyle := valid
+	unknownWorkstyle.Workstyles = []model.Workstyle{{Name: "Telepathy", Scale: "IM", Value: 3}}
+	outOfScale := valid
+	outOfScale.Workstyles = []model.Workstyle{{Name: "Innovation", Scale: "IM", Value: 80}}
+	duplicateWorkstyle := valid
+	duplicateWorkstyle.Workstyles = []model.Workstyle{{Name: "Innovation", Scale: "IM", Value: 3}, {Name: "Innovation", Scale: "IM", Value: 4}}
+
+	storage := mocks.NewStorage(t)
+	storage.On("InsertOccupationData", mock.AnythingOfType("model.OccupationData")).Return(nil).Maybe()
+	app := buildTestApplication(storage)
+
+	tests := []struct {
+		name        string
+		occupation  model.OccupationData
+		wantInvalid bool
+	}{
+		{"valid", valid, false},
+		{"invalid code", invalidCode, true},
+		{"unknown workstyle", unknownWorkstyle, true},
+		{"value out of scale", outOfScale, true},
+		{"duplicate workstyle", duplicateWorkstyle, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := app.Admin.CreateOccupationData(tt.occupation)
+			if tt.wantInvalid {
+				if errors.Status(err) != utils.ErrorStatusInvalid {
+					t.Errorf("appAdmin.CreateOccupationData() error = %v, want invalid status", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("appAdmin.CreateOccupationData() error = %v", err)
+				return
+			}
+			if got.DateUpdated == nil {
+				t.Errorf("appAdmin.CreateOccupationData() date_updated not set")
+			}
+		})
+	}
+}
+
+func TestAppAdmin_CreateOccupationDataExisting(t *testing.T) {
+	occupation := model.OccupationData{Code: "15-1252.00", Name: "Software Developers", JobZone: 4}
+
+	storage := mocks.NewStorage(t)
+	storage.On("InsertOccupationData", mock.AnythingOfType("model.OccupationData")).Return(errors.ErrorData(logutils.StatusFound, model.TypeOccupationData, nil).SetStatus(utils.ErrorStatusExists))
+	app := buildTestApplication(storage)
+
+	_, err := app.Admin.CreateOccupationData(occupation)
+	if errors.Status(err) != utils.ErrorStatusExists {
+		t.Errorf("appAdmin.CreateOccupationData() error = %v, want exists status", err)
+	}
+}
+
+func TestAppAdmin_GetOccupationDataReport(t *testing.T) {
+	occupations := []model.OccupationData{
+		{Code: "11-1011.00", Name: "Chief Executives", JobZone: 5, Workstyles: []model.Workstyle{{Name: "Leadership", Scale: "IM", Value: 5}}},
+		{Code: "11-1011.00", Name: "Chief Executives", JobZone: 5},
+		{Code: "11-1011", Name: "Malformed"},
+	}
+
+	storage := mocks.NewStorage(t)
+	storage.On("GetAllOccupationDatas").Return(occupations, nil)
+	app := buildTestApplication(storage)
+
+	report, err := app.Admin.GetOccupationDataReport()
+	if err != nil {
+		t.Fatalf("appAdmin.GetOccupationDataReport() error = %v", err)
+	}
+	if report.Statistics.TotalOccupations != 3 || report.Statistics.MatchableOccupations != 1 {
+		t.Errorf("appAdmin.GetOccupationDataReport() statistics = %+v", report.Statistics)
+	}
+	if len(report.ExcludedOccupations) != 2 {
+		t.Errorf("appAdmin.GetOccupationDataReport() excluded = %v, want 2 occupations", report.ExcludedOccupations)
+	}
+	if len(report.MissingWorkstyles) != 1 || len(report.MissingWorkstyles[0].Workstyles) != 15 {
+		t.Errorf("appAdmin.GetOccupationDataReport() missing workstyles = %v, want 15 for 1 occupation", report.MissingWorkstyles)
+	}
+	if !reflect.DeepEqual(report.DuplicateCodes, []string{"11-1011.00"}) {
+		t.Errorf("appAdmin.GetOccupationDataReport() duplicate codes = %v", report.DuplicateCodes)
+	}
+	if !reflect.DeepEqual(report.MalformedCodes, []string{"11-1011"}) {
+		t.Errorf("appAdmin.GetOccupationDataReport() malformed codes = %v", report.MalformedCodes)
+	}
+}
+
+func TestAppAdmin_CreateConfig(t *testing.T) {
+	claims := &tokenauth.Claims{AppID: authutils.AllApps, OrgID: authutils.AllOrgs, System: true}
+	retention := func(policies ...model.RetentionPolicy) model.Config {
+		return model.Config{Type: model.ConfigTypeRetention, AppID: authutils.AllApps, OrgID: authutils.AllOrgs, System: true,
+			Data: map[string]interface{}{"policies": policies}}
+	}
+	rateLimit := func(limits ...model.RateLimit) model.Config {
+		return model.Config{Type: model.ConfigTypeRateLimit, AppID: authutils.AllApps, OrgID: authutils.AllOrgs, System: true,
+			Data: map[string]interface{}{"limits": limits}}
+	}
+
+	storage := mocks.NewStorage(t)
+	storage.On("InsertConfig", mock.AnythingOfType("model.Config")).Return(nil)
+	app := buildTestApplication(storage)
+
+	tests := []struct {
+		name        string
+		config      model.Config
+		wantInvalid bool
+	}{
+		{"valid retention", retention(model.RetentionPolicy{Collection: model.RetentionCollectionSurveyResponses, RetentionDays: 365}), false},
+		{"unknown collection", retention(model.RetentionPolicy{Collection: "configs", RetentionDays: 365}), true},
+		{"non-positive retention", retention(model.RetentionPolicy{Collection: model.RetentionCollectionMatchResults, RetentionDays: 0}), true},
+		{"duplicate collection", retention(model.RetentionPolicy{Collection: model.RetentionCollectionMatchResults, RetentionDays: 30},
+			model.RetentionPolicy{Collection: model.RetentionCollectionMatchResults, RetentionDays: 60}), true},
+		{"valid rate limit", rateLimit(model.RateLimit{Route: "POST /api/survey-data", Requests: 5, PeriodSeconds: 60}), false},
+		{"malformed rate limit route", rateLimit(model.RateLimit{Route: "/api/survey-data", Requests: 5, PeriodSeconds: 60}), true},
+		{"non-positive rate limit", rateLimit(model.RateLimit{Route: "POST /api/survey-data", Requests: 0, PeriodSeconds: 60}), true},
+		{"duplicate rate limit route", rateLimit(model.RateLimit{Route: "POST /api/survey-data", Requests: 5, PeriodSeconds: 60},
+			model.RateLimit{Route: "POST /api/survey-data", Requests: 10, PeriodSeconds: 60}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := app.Admin.CreateConfig(tt.config, claims)
+			if (err != nil) != tt.wantInvalid {
+				t.Errorf("appAdmin.CreateConfig() error = %v, wantInvalid %v", err, tt.wantInvalid)
+				return
+			}
+			if tt.wantInvalid && errors.Status(err) != utils.ErrorStatusInvalid {
+				t.Errorf("appAdmin.CreateConfig() error status = %s, want %s", errors.Status(err), utils.ErrorStatusInvalid)
+			}
+		})
+	}
+	storage.AssertNumberOfCalls(t, "InsertConfig", 2)
+}
+
+func TestAppAdmin_GetRetentionReport(t *testing.T) {
+	data := model.RetentionConfigData{Policies: []model.RetentionPolicy{{Collection: model.RetentionCollectionSurveyResponses, RetentionDays: 30}}}
+	config := model.Config{Type: model.ConfigTypeRetention, AppID: authutils.AllApps, OrgID: authutils.AllOrgs, System: true, Data: data}
+	lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	storage := mocks.NewStorage(t)
+	storage.On("FindConfig", model.ConfigTypeRetention, authutils.AllApps, authutils.AllOrgs).Return(&config, nil)
+	storage.On("GetDataRetentionStatus", model.RetentionCollectionSurveyResponses, mock.AnythingOfType("time.Time")).Return(int64(3), &lastUpdated, nil)
+	app := buildTestApplication(storage)
+
+	report, err := app.Admin.GetRetentionReport()
+	if err != nil {
+		t.Fatalf("appAdmin.GetRetentionReport() error = %v", err)
+	}
+	if report.PurgeIntervalHours != model.DefaultRetentionPurgeIntervalHours {
+		t.Errorf("appAdmin.GetRetentionReport() purge interval = %d, want %d", report.PurgeIntervalHours, model.DefaultRetentionPurgeIntervalHours)
+	}
+	if len(report.Policies) != 1 {
+		t.Fatalf("appAdmin.GetRetentionReport() policies = %v, want 1 policy", report.Policies)
+	}
+	policy := report.Policies[0]
+	wantExpiry := lastUpdated.AddDate(0, 0, 30)
+	if policy.ExpiredCount != 3 || policy.NextExpiry == nil || !policy.NextExpiry.Equal(wantExpiry) {
+		t.Errorf("appAdmin.GetRetentionReport() policy = %+v, want 3 expired and next expiry %s", policy, wantExpiry)
+	}
+}
+
+func TestAppAdmin_MatchUserOccupations(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+
+	_, err := app.Admin.MatchUserOccupations("account", nil)
+	if errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("appAdmin.MatchUserOccupations() without survey data error = %v, want not found", err)
+	}
+
+	now := time.Now().UTC()
+	for _, surveyData := range []model.SurveyData{
+		{ID: "old", AccountID: "account", Scores: testSurveyScores(), DateCreated: now.Add(-time.Hour)},
+		{ID: "latest", AccountID: "account", Scores: testSurveyScores(), DateCreated: now},
+	} {
+		if err = storage.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("error creating survey data: %v", err)
+		}
+	}
+
+	surveyData, err := app.Admin.MatchUserOccupations("account", nil)
+	if err != nil || surveyData.ID != "latest" {
+		t.Errorf("appAdmin.MatchUserOccupations() = %v, %v, want survey data latest", surveyData, err)
+	}
+	if err = app.Stop(context.Background()); err != nil {
+		t.Errorf("error waiting for the occupation matching: %v", err)
+	}
+}
+
+func TestAppAdmin_DeleteUserData(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+
+	for _, surveyData := range []model.SurveyData{{ID: "first", AccountID: "account"}, {ID: "second", AccountID: "account"}, {ID: "other", AccountID: "other"}} {
+		if err := storage.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("error creating survey data: %v", err)
+		}
+	}
+	if err := storage.InsertUserMatchingResult(model.UserMatchingResult{ID: "account"}); err != nil {
+		t.Fatalf("error inserting match result: %v", err)
+	}
+	for _, history := range []model.UserMatchingResultHistory{{ID: "first", AccountID: "account"}, {ID: "second", AccountID: "account"}, {ID: "other", AccountID: "other"}} {
+		if err := storage.InsertUserMatchingResultHistory(history); err != nil {
+			t.Fatalf("error inserting match result history: %v", err)
+		}
+	}
+	if _, err := storage.InsertIdempotencyKey(model.IdempotencyKey{AccountID: "account", Key: "key"}); err != nil {
+		t.Fatalf("error inserting idempotency key: %v", err)
+	}
+	if err := storage.InsertUserDataExport(model.UserDataExport{ID: "export", AccountID: "account"}); err != nil {
+		t.Fatalf("error inserting user data export: %v", err)
+	}
+
+	deletion, err := app.Admin.DeleteUserData("account", nil)
+	want := model.UserDataDeletion{AccountID: "account", SurveyDatas: 2, MatchResults: 1, MatchResultsHistory: 2, IdempotencyKeys: 1, UserDataExports: 1}
+	if err != nil || !reflect.DeepEqual(*deletion, want) {
+		t.Fatalf("appAdmin.DeleteUserData() = %+v, %v, want %+v", deletion, err, want)
+	}
+	if _, err = storage.GetSurveyData("other"); err != nil {
+		t.Errorf("appAdmin.DeleteUserData() deleted the survey data of another account: %v", err)
+	}
+
+	// deleting the data again finds nothing to delete
+	deletion, err = app.Admin.DeleteUserData("account", nil)
+	want = model.UserDataDeletion{AccountID: "account"}
+	if err != nil || !reflect.DeepEqual(*deletion, want) {
+		t.Errorf("appAdmin.DeleteUserData() again = %+v, %v, want %+v", deletion, err, want)
+	}
+}
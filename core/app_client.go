@@ -15,82 +15,476 @@
 package core
 
 import (
+	"application/core/interfaces"
 	"application/core/model"
+	"application/utils"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-gota/gota/dataframe"
 	"github.com/google/uuid"
 	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// bessiToWorkstyles maps the BESSI skills to the ONET work styles they are matched against
+var bessiToWorkstyles = map[string]string{
+	"stress_regulation":         "Stress Tolerance",
+	"adaptability":              "Adaptability/Flexibility",
+	"capacity_social_warmth":    "Concern for Others",
+	"abstract_thinking":         "Analytical Thinking",
+	"teamwork":                  "Cooperation",
+	"responsibility_management": "Dependability",
+	"detail_management":         "Attention to Detail",
+	"initiative":                "Initiative",
+	"anger_management":          "Self-Control",
+	"capacity_consistency":      "Persistence",
+	"capacity_independence":     "Independence",
+	"perspective_taking":        "Social Orientation",
+	"goal_regulation":           "Achievement/Effort",
+	"creativity":                "Innovation",
+	"ethical_competence":        "Integrity",
+	"leadership":                "Leadership",
+}
+
 // appClient contains client implementations
 type appClient struct {
 	app *Application
+
+	// ctx is the context of the request handled by the client, such as its trace, if any
+	ctx context.Context
+}
+
+// WithContext returns a copy of the client handling the request with the given context
+func (a appClient) WithContext(ctx context.Context) interfaces.Client {
+	return appClient{app: a.app, ctx: ctx}
+}
+
+// storage returns the storage performing its operations with the context of the client
+func (a appClient) storage() interfaces.Storage {
+	if a.ctx == nil {
+		return a.app.storage
+	}
+	return a.app.storage.WithContext(a.ctx)
 }
 
 // GetOccupationData gets an OccupationData by Code
 func (a appClient) GetOccupationData(code string) (*model.OccupationData, error) {
-	return a.app.storage.GetOccupationData(code)
+	occupationData, err := a.storage().GetOccupationData(code)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, &logutils.FieldArgs{"code": code}, err)
+	}
+	return occupationData, nil
 }
 
 // GetAllOccupationDatas gets all the OccupationDatas
 func (a appClient) GetAllOccupationDatas() ([]model.OccupationData, error) {
-	return a.app.storage.GetAllOccupationDatas()
+	return a.storage().GetAllOccupationDatas()
+}
+
+// GetRelatedOccupations gets the occupations adjacent to the occupation with the given code in the related occupations graph
+func (a appClient) GetRelatedOccupations(code string, limit int) ([]model.OccupationNeighbor, error) {
+	graph, err := a.app.getOccupationGraph()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionLoad, model.TypeOccupationNeighbor, nil, err)
+	}
+
+	neighbors := graph.neighbors(code, limit)
+	if neighbors == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"code": code}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return neighbors, nil
+}
+
+// GetOccupationPathways gets the lowest cost pathways from one occupation to another, weighted by job zone progression, up to MaxOccupationPathways
+func (a appClient) GetOccupationPathways(fromCode string, toCode string, limit int) ([]model.OccupationPathway, error) {
+	graph, err := a.app.getOccupationGraph()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionLoad, model.TypeOccupationPathway, nil, err)
+	}
+
+	if limit > model.MaxOccupationPathways {
+		limit = model.MaxOccupationPathways
+	}
+	pathways := graph.pathways(fromCode, toCode, limit)
+	if pathways == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"from": fromCode, "to": toCode}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return pathways, nil
+}
+
+// GetCrosswalkSystems gets the classification systems with a loaded crosswalk table
+func (a appClient) GetCrosswalkSystems() []string {
+	return a.app.crosswalks.GetCrosswalkSystems()
+}
+
+// GetOccupationCrosswalks gets the codes in the given classification systems equivalent to an occupation code
+func (a appClient) GetOccupationCrosswalks(code string, systems []string) ([]model.CrosswalkCode, error) {
+	_, err := a.storage().GetOccupationData(code)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, &logutils.FieldArgs{"code": code}, err)
+	}
+
+	if len(systems) == 0 {
+		systems = a.app.crosswalks.GetCrosswalkSystems()
+	}
+	codes := make([]model.CrosswalkCode, 0)
+	for _, system := range systems {
+		codes = append(codes, a.app.crosswalks.FindCrosswalkCodes(code, system)...)
+	}
+	return codes, nil
+}
+
+// GetCrosswalkOccupations gets the occupations equivalent to a code in the given classification system
+func (a appClient) GetCrosswalkOccupations(system string, code string) ([]model.OccupationMatch, error) {
+	occupationCodes := a.app.crosswalks.FindOccupationCodes(system, code)
+	if len(occupationCodes) == 0 {
+		return []model.OccupationMatch{}, nil
+	}
+
+	occupations, err := a.storage().GetAllOccupationDatas()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+
+	// crosswalk tables may list SOC codes, which include every ONET-SOC occupation sharing the SOC code
+	matches := make([]model.OccupationMatch, 0)
+	for _, occupation := range occupations {
+		for _, occupationCode := range occupationCodes {
+			if occupation.Code == occupationCode || strings.HasPrefix(occupation.Code, occupationCode+".") {
+				matches = append(matches, model.OccupationMatch{Code: occupation.Code, Name: occupation.Name})
+				break
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Code < matches[j].Code
+	})
+	return matches, nil
 }
 
-// GetUserMatchingResult gets an UserMatchingResult by ID
-func (a appClient) GetUserMatchingResult(id string) (*model.UserMatchingResult, error) {
-	return a.app.storage.GetUserMatchingResult(id)
+// GetUserMatchingResult gets an UserMatchingResult by ID, including the equivalent codes in the given classification systems for each match
+func (a appClient) GetUserMatchingResult(id string, crosswalkSystems []string) (*model.UserMatchingResult, error) {
+	userMatchingResult, err := a.storage().GetUserMatchingResult(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if userMatchingResult == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	if len(crosswalkSystems) == 0 {
+		return userMatchingResult, nil
+	}
+
+	for i, match := range userMatchingResult.Matches {
+		crosswalks := make([]model.CrosswalkCode, 0)
+		for _, system := range crosswalkSystems {
+			crosswalks = append(crosswalks, a.app.crosswalks.FindCrosswalkCodes(match.Occupation.Code, system)...)
+		}
+		userMatchingResult.Matches[i].Occupation.Crosswalks = crosswalks
+	}
+	return userMatchingResult, nil
 }
 
 // DeleteUserMatchingResult deletes an UserMatchingResult by ID
 func (a appClient) DeleteUserMatchingResult(id string) error {
-	return a.app.storage.DeleteUserMatchingResult(id)
+	return a.storage().DeleteUserMatchingResult(id)
 }
 
 // GetSurveyData gets a SurveyData by ID
 func (a appClient) GetSurveyData(id string) (*model.SurveyData, error) {
-	return a.app.storage.GetSurveyData(id)
+	surveyData, err := a.storage().GetSurveyData(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if surveyData == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return surveyData, nil
 }
 
 // CreateSurveyData creates a new SurveyData
 func (a appClient) CreateSurveyData(surveyData model.SurveyData) (*model.SurveyData, error) {
+	if len(surveyData.Version) == 0 {
+		surveyData.Version = model.CurrentSurveyVersion
+	}
+	err := validateSurveyData(surveyData)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeSurveyData, nil, err).SetStatus(utils.ErrorStatusUnprocessable)
+	}
+
 	surveyData.ID = uuid.NewString()
 	surveyData.DateCreated = time.Now()
-	surveyData.Version = "v3.0"
-	err := a.app.storage.CreateSurveyData(surveyData)
+	surveyData.Revision = 1
+	err = a.storage().CreateSurveyData(surveyData)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeSurveyData, nil, err)
 	}
 	return &surveyData, nil
 }
 
+// CreateSurveyDataIdempotent creates a new SurveyData once for each idempotency key of an account
+//
+//	A retry with the same key returns the SurveyData created by the first request, and true to report that it was not created again.
+func (a appClient) CreateSurveyDataIdempotent(surveyData model.SurveyData, idempotencyKey string) (*model.SurveyData, bool, error) {
+	if len(surveyData.Version) == 0 {
+		surveyData.Version = model.CurrentSurveyVersion
+	}
+	requestHash, err := surveyDataRequestHash(surveyData)
+	if err != nil {
+		return nil, false, errors.WrapErrorAction(logutils.ActionCompute, model.TypeIdempotencyKey, nil, err)
+	}
+
+	now := time.Now().UTC()
+	record := model.IdempotencyKey{AccountID: surveyData.AccountID, Key: idempotencyKey, RequestHash: requestHash, DateCreated: now, DateExpires: now.Add(a.app.idempotencyWindow)}
+	inserted, err := a.storage().InsertIdempotencyKey(record)
+	if err != nil {
+		return nil, false, errors.WrapErrorAction(logutils.ActionInsert, model.TypeIdempotencyKey, nil, err)
+	}
+	if !inserted {
+		existing, err := a.replayedSurveyData(record)
+		return existing, err == nil, err
+	}
+
+	created, err := a.CreateSurveyData(surveyData)
+	if err != nil {
+		// the key is released so that the request can be retried
+		deleteErr := a.storage().DeleteIdempotencyKey(record.AccountID, record.Key)
+		if deleteErr != nil {
+			a.app.logger.Errorf("error deleting the idempotency key of a failed survey data creation: %s", deleteErr)
+		}
+		return nil, false, err
+	}
+
+	record.ResourceID = created.ID
+	err = a.storage().UpdateIdempotencyKey(record)
+	if err != nil {
+		return nil, false, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeIdempotencyKey, nil, err)
+	}
+	return created, false, nil
+}
+
+// replayedSurveyData returns the SurveyData created by the first request sent with the key of a retried request
+func (a appClient) replayedSurveyData(record model.IdempotencyKey) (*model.SurveyData, error) {
+	existing, err := a.storage().FindIdempotencyKey(record.AccountID, record.Key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeIdempotencyKey, nil, err)
+	}
+	if existing != nil && existing.RequestHash != record.RequestHash {
+		validation := model.ValidationError{DataType: model.TypeIdempotencyKey}
+		validation.Add("Idempotency-Key", "already used for a different survey submission")
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, model.TypeIdempotencyKey, nil, validation.Err()).SetStatus(utils.ErrorStatusUnprocessable)
+	}
+	// the key may also have been released by a failed first request after it was found to exist
+	if existing == nil || len(existing.ResourceID) == 0 {
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeIdempotencyKey, &logutils.FieldArgs{"key": record.Key, "resource_id": ""}).SetStatus(utils.ErrorStatusInProgress)
+	}
+
+	return a.GetSurveyData(existing.ResourceID)
+}
+
 // UpdateSurveyData updates a SurveyData
 func (a appClient) UpdateSurveyData(surveyData model.SurveyData) error {
-	return a.app.storage.UpdateSurveyData(surveyData)
+	existing, err := a.GetSurveyData(surveyData.ID)
+	if err != nil {
+		return err
+	}
+
+	// the scores are validated against the survey version of the stored submission
+	surveyData.Version = existing.Version
+	err = validateSurveyData(surveyData)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionValidate, model.TypeSurveyData, nil, err).SetStatus(utils.ErrorStatusUnprocessable)
+	}
+	return a.storage().UpdateSurveyData(surveyData)
 }
 
 // DeleteSurveyData deletes a SurveyData by ID
 func (a appClient) DeleteSurveyData(id string) error {
-	return a.app.storage.DeleteSurveyData(id)
+	return a.storage().DeleteSurveyData(id)
 }
 
+// ExportUserData exports all the data of an account in the given format
+//
+//	The export is returned with its data when the account has a small history. Larger histories are exported in the background,
+//	the export being returned while pending and then downloaded with its token once ready.
+func (a appClient) ExportUserData(accountID string, format string) (*model.UserDataExport, error) {
+	if !logutils.ContainsString(model.UserDataExportFormats, format) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, "user data export format", &logutils.FieldArgs{"format": format}).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	token, err := newUserDataExportToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	userDataExport := model.UserDataExport{ID: uuid.NewString(), AccountID: accountID, Format: format, Status: model.UserDataExportStatusPending, Token: token,
+		DateCreated: now, DateExpires: now.Add(model.UserDataExportExpiry)}
+
+	surveyDatas, err := a.storage().SearchSurveyDatas(model.UserDataSearch{AccountID: &accountID, Limit: model.UserDataExportSyncLimit + 1})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	if len(surveyDatas) <= model.UserDataExportSyncLimit {
+		userDataExport.Data, err = userDataArchive(a.storage(), accountID, surveyDatas, format)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserDataExport, &logutils.FieldArgs{"account_id": accountID}, err)
+		}
+		userDataExport.Status = model.UserDataExportStatusReady
+		return &userDataExport, nil
+	}
+
+	err = a.storage().InsertUserDataExport(userDataExport)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserDataExport, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	started := a.app.goWorker(func() {
+		a.app.generateUserDataExport(userDataExport)
+	})
+	if !started {
+		userDataExport.Status = model.UserDataExportStatusFailed
+		err = a.storage().UpdateUserDataExport(userDataExport)
+		if err != nil {
+			a.app.logger.Errorf("error failing the data export %s of account %s: %v", userDataExport.ID, accountID, err)
+		}
+		return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeUserDataExport, &logutils.FieldArgs{"account_id": accountID}).SetStatus(utils.ErrorStatusUnavailable)
+	}
+	return &userDataExport, nil
+}
+
+// GetUserDataExport returns the status of an unexpired export of the data of an account, without its data
+func (a appClient) GetUserDataExport(accountID string, id string) (*model.UserDataExport, error) {
+	userDataExport, err := a.storage().GetUserDataExport(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if userDataExport.AccountID != accountID || !userDataExport.DateExpires.After(time.Now()) {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	userDataExport.Data = nil
+	return userDataExport, nil
+}
+
+// DownloadUserDataExport returns a ready and unexpired export with its data, given the token of its download link
+func (a appClient) DownloadUserDataExport(id string, token string) (*model.UserDataExport, error) {
+	userDataExport, err := a.storage().GetUserDataExport(id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}, err)
+	}
+	// an export is not found with a wrong token, so that the existence of the exports is not disclosed
+	if subtle.ConstantTimeCompare([]byte(userDataExport.Token), []byte(token)) != 1 || userDataExport.Status != model.UserDataExportStatusReady ||
+		!userDataExport.DateExpires.After(time.Now()) {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return userDataExport, nil
+}
+
+// MatchOccupations starts matching the survey data of a user to the occupations in the background, saving the result once completed
+//
+//	The matching is traced apart from the request starting it, as it outlives the request, and linked to the trace of the request.
 func (a appClient) MatchOccupations(surveyData model.SurveyData, userID string) {
+	link := trace.LinkFromContext(a.ctx)
+	a.addPendingMatchings(1)
+	started := a.app.goWorker(func() {
+		defer a.addPendingMatchings(-1)
+		a.matchOccupations(surveyData, userID, link)
+	})
+	if !started {
+		a.addPendingMatchings(-1)
+		a.app.logger.Warnf("occupation matching of survey data %s not started, the application is stopping", surveyData.ID)
+	}
+}
+
+// SubscribeMatchingEvents subscribes to the matching events of an account until the returned unsubscribe function is called or the application is stopped
+//
+//	The events channel is closed once unsubscribed. The completed events are only sent for the match results saved after subscribing.
+func (a appClient) SubscribeMatchingEvents(accountID string) (<-chan model.MatchingEvent, func(), error) {
+	lastRevision := int64(0)
+	result, err := a.storage().GetUserMatchingResult(accountID)
+	if err != nil && errors.Status(err) != utils.ErrorStatusNotFound {
+		return nil, nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": accountID}, err)
+	}
+	if result != nil {
+		lastRevision = result.Revision
+	}
+
+	subscription, subscribed := a.app.subscribeMatchingEvents(accountID, lastRevision)
+	if !subscribed {
+		return nil, nil, errors.ErrorData(logutils.StatusInvalid, model.TypeMatchingEvent, &logutils.FieldArgs{"account_id": accountID}).SetStatus(utils.ErrorStatusUnavailable)
+	}
+	return subscription.events, func() { a.app.unsubscribeMatchingEvents(subscription) }, nil
+}
+
+// addPendingMatchings updates the number of occupation matchings started and not yet completed
+func (a appClient) addPendingMatchings(delta int64) {
+	pending := a.app.pendingMatchings.Add(delta)
+	if a.app.metrics != nil {
+		a.app.metrics.SetMatchingQueueDepth(pending)
+	}
+}
+
+func (a appClient) matchOccupations(surveyData model.SurveyData, userID string, link trace.Link) {
+	ctx, span := tracer.Start(context.Background(), "MatchOccupations", trace.WithNewRoot(), trace.WithLinks(link),
+		trace.WithAttributes(attribute.String("survey.id", surveyData.ID), attribute.String("survey.version", surveyData.Version)))
+	defer span.End()
+
+	a.app.sendMatchingEvent(a.app.newMatchingEvent(model.MatchingEventStarted, userID, surveyData.ID))
+
+	start := time.Now()
+	client := appClient{app: a.app, ctx: ctx}
+	occupationsScored, err := client.saveOccupationMatches(surveyData, userID)
+	span.SetAttributes(attribute.Int("matching.occupations_scored", occupationsScored))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		a.app.logger.Errorf("error matching occupations to survey data %s: %v", surveyData.ID, err)
+		a.app.sendMatchingEvent(a.app.newMatchingEvent(model.MatchingEventFailed, userID, surveyData.ID))
+	}
+	if a.app.metrics != nil {
+		a.app.metrics.ObserveMatching(time.Since(start), occupationsScored, err)
+	}
+}
+
+// saveOccupationMatches matches the survey data to the occupations and saves the result, returning the number of occupations scored
+func (a appClient) saveOccupationMatches(surveyData model.SurveyData, userID string) (int, error) {
 	occupations, err := a.GetAllOccupationDatas()
 	if err != nil {
-		return
+		return 0, err
 	}
 
+	_, span := tracer.Start(a.ctx, "RunMatchingAlgorithm", trace.WithAttributes(attribute.Int("matching.occupations", len(occupations))))
 	matches := a.runMatchingAlgo(surveyData.Scores, occupations)
+	span.End()
+
 	userMatchingResult := model.UserMatchingResult{
 		ID:      userID,
 		Matches: matches,
 		Version: surveyData.Version,
 	}
+	history := model.UserMatchingResultHistory{ID: uuid.NewString(), AccountID: userID, Version: surveyData.Version, Matches: matches, DateCreated: time.Now().UTC()}
 
-	a.app.storage.SaveUserMatchingResult(userMatchingResult)
+	err = a.storage().PerformTransaction(func(storage interfaces.Storage) error {
+		err := storage.SaveUserMatchingResult(userMatchingResult)
+		if err != nil {
+			return err
+		}
+		return storage.InsertUserMatchingResultHistory(history)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(matches), nil
 }
 
 func (a appClient) runMatchingAlgo(userScores []model.WorkstyleScore, occupations []model.OccupationData) []model.Match {
@@ -116,25 +510,6 @@ func (a appClient) runMatchingAlgorithmPerOccupation(occupation model.Occupation
 	dfImportanceUnsorted := dataframe.LoadStructs(occupation.Workstyles)
 	dfImportance := dfImportanceUnsorted.Arrange(dataframe.Sort("Value"))
 
-	bessiToWorkstyles := map[string]string{
-		"stress_regulation":         "Stress Tolerance",
-		"adaptability":              "Adaptability/Flexibility",
-		"capacity_social_warmth":    "Concern for Others",
-		"abstract_thinking":         "Analytical Thinking",
-		"teamwork":                  "Cooperation",
-		"responsibility_management": "Dependability",
-		"detail_management":         "Attention to Detail",
-		"initiative":                "Initiative",
-		"anger_management":          "Self-Control",
-		"capacity_consistency":      "Persistence",
-		"capacity_independence":     "Independence",
-		"perspective_taking":        "Social Orientation",
-		"goal_regulation":           "Achievement/Effort",
-		"creativity":                "Innovation",
-		"ethical_competence":        "Integrity",
-		"leadership":                "Leadership",
-	}
-
 	sumSquared := 0.0
 	n := float64(dfUserScores.Nrow())
 	for i := 0; i < dfUserScores.Nrow(); i++ {
@@ -162,6 +537,48 @@ func index(df dataframe.DataFrame, workstyle string) (int, error) {
 	return -1, errors.New("did not find matching workstyle")
 }
 
+// surveyDataRequestHash returns the hash of the submitted fields of a SurveyData, identifying the request of an idempotency key
+func surveyDataRequestHash(surveyData model.SurveyData) (string, error) {
+	data, err := json.Marshal(model.SurveyData{Version: surveyData.Version, Scores: surveyData.Scores})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// validateSurveyData checks that the scores of a survey submission cover each skill of its survey version once, within the allowed range
+func validateSurveyData(surveyData model.SurveyData) error {
+	validation := model.ValidationError{DataType: model.TypeSurveyData}
+	instrument, ok := model.SurveyInstruments[surveyData.Version]
+	if !ok {
+		validation.Add("version", "unsupported survey version %q", surveyData.Version)
+		return validation.Err()
+	}
+
+	scored := map[string]bool{}
+	for i, score := range surveyData.Scores {
+		field := fmt.Sprintf("scores[%d]", i)
+		if !logutils.ContainsString(instrument.Skills, score.Workstyle) {
+			validation.Add(field+".workstyle", "unknown skill %q", score.Workstyle)
+		} else if scored[score.Workstyle] {
+			validation.Add(field+".workstyle", "duplicate skill %q", score.Workstyle)
+		}
+		scored[score.Workstyle] = true
+
+		if score.Score < instrument.MinScore || score.Score > instrument.MaxScore {
+			validation.Add(field+".score", "must be between %d and %d", instrument.MinScore, instrument.MaxScore)
+		}
+	}
+
+	for _, skill := range instrument.Skills {
+		if !scored[skill] {
+			validation.Add("scores", "missing skill %q", skill)
+		}
+	}
+	return validation.Err()
+}
+
 // newAppClient creates new appClient
 func newAppClient(app *Application) appClient {
 	return appClient{app: app}
@@ -0,0 +1,560 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"application/core"
+	"application/core/interfaces/mocks"
+	"application/core/model"
+	"application/driven/memory"
+	"application/utils"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"github.com/stretchr/testify/mock"
+)
+
+func testOccupations() []model.OccupationData {
+	workstyles := func(values ...float64) []model.Workstyle {
+		names := []string{"Stress Tolerance", "Cooperation", "Initiative", "Leadership"}
+		list := make([]model.Workstyle, len(values))
+		for i, value := range values {
+			list[i] = model.Workstyle{Name: names[i], Scale: "IM", Value: value}
+		}
+		return list
+	}
+
+	return []model.OccupationData{
+		{Code: "11-0000.00", Name: "Assistant", JobZone: 1, Workstyles: workstyles(3, 4, 2, 1),
+			RelatedOccupations: []model.RelatedOccupation{{Code: "22-0000.00", Name: "Coordinator", Index: 1}}},
+		{Code: "22-0000.00", Name: "Coordinator", JobZone: 2, Workstyles: workstyles(3, 4, 3, 2),
+			RelatedOccupations: []model.RelatedOccupation{{Code: "33-0000.00", Name: "Manager", Index: 1}}},
+		{Code: "33-0000.00", Name: "Manager", JobZone: 3, Workstyles: workstyles(4, 3, 4, 5)},
+		{Code: "44-0000.00", Name: "Hermit", JobZone: 3, Workstyles: workstyles(1, 0, 0, 0)},
+	}
+}
+
+func TestAppClient_GetOccupationData(t *testing.T) {
+	occupations := testOccupations()
+	storage := mocks.NewStorage(t)
+	storage.On("GetOccupationData", "11-0000.00").Return(&occupations[0], nil)
+	storage.On("GetOccupationData", "99-0000.00").Return(nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, nil).SetStatus(utils.ErrorStatusNotFound))
+	app := buildTestApplication(storage)
+
+	got, err := app.Client.GetOccupationData("11-0000.00")
+	if err != nil || got == nil || got.Name != "Assistant" {
+		t.Errorf("appClient.GetOccupationData() = %v, %v", got, err)
+	}
+
+	got, err = app.Client.GetOccupationData("99-0000.00")
+	if got != nil || errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("appClient.GetOccupationData() missing = %v, %v, want not found", got, err)
+	}
+}
+
+func TestAppClient_GetRelatedOccupations(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	storage.On("GetAllOccupationDatas").Return(testOccupations(), nil)
+	app := buildTestApplication(storage)
+
+	tests := []struct {
+		name      string
+		code      string
+		limit     int
+		wantCodes []string
+		wantErr   bool
+	}{
+		{"related first", "11-0000.00", 1, []string{"22-0000.00"}, false},
+		{"unknown", "99-0000.00", 0, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := app.Client.GetRelatedOccupations(tt.code, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("appClient.GetRelatedOccupations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			codes := make([]string, len(got))
+			for i, neighbor := range got {
+				codes[i] = neighbor.Occupation.Code
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("appClient.GetRelatedOccupations() = %v, want %v", codes, tt.wantCodes)
+			}
+			if !got[0].Related {
+				t.Errorf("appClient.GetRelatedOccupations() related = false, want true")
+			}
+		})
+	}
+}
+
+func TestAppClient_GetOccupationPathways(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	storage.On("GetAllOccupationDatas").Return(testOccupations(), nil)
+	app := buildTestApplication(storage)
+
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		wantSteps []string
+		wantErr   bool
+	}{
+		{"job zone progression", "11-0000.00", "33-0000.00", []string{"11-0000.00", "22-0000.00", "33-0000.00"}, false},
+		{"unknown", "11-0000.00", "99-0000.00", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := app.Client.GetOccupationPathways(tt.from, tt.to, 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("appClient.GetOccupationPathways() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) == 0 {
+				t.Fatalf("appClient.GetOccupationPathways() returned no pathways")
+			}
+			steps := make([]string, len(got[0].Steps))
+			for i, step := range got[0].Steps {
+				steps[i] = step.Occupation.Code
+			}
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("appClient.GetOccupationPathways() = %v, want %v", steps, tt.wantSteps)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].Cost < got[i-1].Cost {
+					t.Errorf("appClient.GetOccupationPathways() pathways not ordered by cost")
+				}
+			}
+		})
+	}
+}
+
+func TestAppClient_GetOccupationPathwaysLimit(t *testing.T) {
+	// identical occupations are all linked together, so that there are many pathways between any two of them
+	occupations := make([]model.OccupationData, 7)
+	for i := range occupations {
+		occupations[i] = model.OccupationData{Code: fmt.Sprintf("1%d-0000.00", i), Name: fmt.Sprintf("Occupation %d", i), JobZone: 2,
+			Workstyles: []model.Workstyle{{Name: "Cooperation", Scale: "IM", Value: 4}, {Name: "Initiative", Scale: "IM", Value: 3}}}
+	}
+	storage := mocks.NewStorage(t)
+	storage.On("GetAllOccupationDatas").Return(occupations, nil)
+	app := buildTestApplication(storage)
+
+	got, err := app.Client.GetOccupationPathways(occupations[0].Code, occupations[1].Code, 100)
+	if err != nil {
+		t.Fatalf("appClient.GetOccupationPathways() error = %v", err)
+	}
+	if len(got) != model.MaxOccupationPathways {
+		t.Errorf("appClient.GetOccupationPathways() returned %d pathways, want %d", len(got), model.MaxOccupationPathways)
+	}
+}
+
+func TestAppClient_GetUserMatchingResult(t *testing.T) {
+	result := model.UserMatchingResult{ID: "user", Matches: []model.Match{{Occupation: model.OccupationMatch{Code: "15-1252.00", Name: "Software Developers"}, MatchPercent: 90}}}
+	isco := model.CrosswalkCode{System: model.CrosswalkSystemISCO08, Code: "2512", Title: "Software developers"}
+
+	storage := mocks.NewStorage(t)
+	storage.On("GetUserMatchingResult", "user").Return(func(string) *model.UserMatchingResult {
+		copied := result
+		copied.Matches = append([]model.Match{}, result.Matches...)
+		return &copied
+	}, nil)
+	crosswalks := mocks.NewCrosswalks(t)
+	crosswalks.On("FindCrosswalkCodes", "15-1252.00", model.CrosswalkSystemISCO08).Return([]model.CrosswalkCode{isco}).Maybe()
+	app := buildTestApplicationWithCrosswalks(storage, crosswalks)
+
+	tests := []struct {
+		name    string
+		systems []string
+		want    []model.CrosswalkCode
+	}{
+		{"without crosswalks", nil, nil},
+		{"with crosswalks", []string{model.CrosswalkSystemISCO08}, []model.CrosswalkCode{isco}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := app.Client.GetUserMatchingResult("user", tt.systems)
+			if err != nil {
+				t.Errorf("appClient.GetUserMatchingResult() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got.Matches[0].Occupation.Crosswalks, tt.want) {
+				t.Errorf("appClient.GetUserMatchingResult() crosswalks = %v, want %v", got.Matches[0].Occupation.Crosswalks, tt.want)
+			}
+		})
+	}
+}
+
+func testSurveyScores() []model.WorkstyleScore {
+	skills := model.SurveyInstruments[model.CurrentSurveyVersion].Skills
+	scores := make([]model.WorkstyleScore, len(skills))
+	for i, skill := range skills {
+		scores[i] = model.WorkstyleScore{Workstyle: skill, Score: 50}
+	}
+	return scores
+}
+
+func TestAppClient_CreateSurveyData(t *testing.T) {
+	storage := mocks.NewStorage(t)
+	storage.On("CreateSurveyData", mock.Anything).Return(nil).Once()
+	app := buildTestApplication(storage)
+
+	tests := []struct {
+		name       string
+		version    string
+		scores     func([]model.WorkstyleScore) []model.WorkstyleScore
+		wantFields []string
+	}{
+		{"valid", "", func(scores []model.WorkstyleScore) []model.WorkstyleScore { return scores }, nil},
+		{"unsupported version", "v1.0", func(scores []model.WorkstyleScore) []model.WorkstyleScore { return scores }, []string{"version"}},
+		{"unknown skill", "", func(scores []model.WorkstyleScore) []model.WorkstyleScore {
+			return append(scores, model.WorkstyleScore{Workstyle: "juggling", Score: 50})
+		}, []string{"scores[16].workstyle"}},
+		{"duplicate skill", "", func(scores []model.WorkstyleScore) []model.WorkstyleScore {
+			return append(scores, scores[0])
+		}, []string{"scores[16].workstyle"}},
+		{"out of range", "", func(scores []model.WorkstyleScore) []model.WorkstyleScore {
+			scores[1].Score = 101
+			return scores
+		}, []string{"scores[1].score"}},
+		{"missing skill", "", func(scores []model.WorkstyleScore) []model.WorkstyleScore { return scores[1:] }, []string{"scores"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surveyData, err := app.Client.CreateSurveyData(model.SurveyData{Version: tt.version, Scores: tt.scores(testSurveyScores())})
+			if tt.wantFields == nil {
+				if err != nil || surveyData.Version != model.CurrentSurveyVersion {
+					t.Errorf("appClient.CreateSurveyData() = %v, %v", surveyData, err)
+				}
+				return
+			}
+
+			if errors.Status(err) != utils.ErrorStatusUnprocessable {
+				t.Fatalf("appClient.CreateSurveyData() error = %v, want unprocessable", err)
+			}
+			validation, ok := errors.AsError(err).Internal().(*model.ValidationError)
+			if !ok {
+				t.Fatalf("appClient.CreateSurveyData() error = %v, want validation error", err)
+			}
+			fields := make([]string, len(validation.Fields))
+			for i, field := range validation.Fields {
+				fields[i] = field.Field
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("appClient.CreateSurveyData() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestAppClient_CreateSurveyDataIdempotent(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+	surveyData := model.SurveyData{AccountID: "account", Scores: testSurveyScores()}
+
+	created, replayed, err := app.Client.CreateSurveyDataIdempotent(surveyData, "key")
+	if err != nil || replayed {
+		t.Fatalf("first appClient.CreateSurveyDataIdempotent() = %v, %v, %v", created, replayed, err)
+	}
+
+	retried, replayed, err := app.Client.CreateSurveyDataIdempotent(surveyData, "key")
+	if err != nil || !replayed || retried.ID != created.ID {
+		t.Errorf("retried appClient.CreateSurveyDataIdempotent() = %v, %v, %v, want survey data %s", retried, replayed, err, created.ID)
+	}
+
+	other, replayed, err := app.Client.CreateSurveyDataIdempotent(model.SurveyData{AccountID: "other", Scores: testSurveyScores()}, "key")
+	if err != nil || replayed || other.ID == created.ID {
+		t.Errorf("other account appClient.CreateSurveyDataIdempotent() = %v, %v, %v, want new survey data", other, replayed, err)
+	}
+
+	changed := model.SurveyData{AccountID: "account", Scores: testSurveyScores()}
+	changed.Scores[0].Score = 60
+	_, _, err = app.Client.CreateSurveyDataIdempotent(changed, "key")
+	if errors.Status(err) != utils.ErrorStatusUnprocessable {
+		t.Errorf("changed request appClient.CreateSurveyDataIdempotent() error = %v, want unprocessable", err)
+	}
+
+	// a key without the created survey data is held by a request in progress
+	pending, _ := storage.FindIdempotencyKey("account", "key")
+	pending.ResourceID = ""
+	err = storage.UpdateIdempotencyKey(*pending)
+	if err != nil {
+		t.Fatalf("error updating idempotency key: %v", err)
+	}
+	_, _, err = app.Client.CreateSurveyDataIdempotent(surveyData, "key")
+	if errors.Status(err) != utils.ErrorStatusInProgress {
+		t.Errorf("in progress appClient.CreateSurveyDataIdempotent() error = %v, want in progress", err)
+	}
+
+	invalid := model.SurveyData{AccountID: "account", Scores: testSurveyScores()[1:]}
+	_, _, err = app.Client.CreateSurveyDataIdempotent(invalid, "invalid")
+	if errors.Status(err) != utils.ErrorStatusUnprocessable {
+		t.Errorf("invalid appClient.CreateSurveyDataIdempotent() error = %v, want unprocessable", err)
+	}
+	if idempotencyKey, _ := storage.FindIdempotencyKey("account", "invalid"); idempotencyKey != nil {
+		t.Errorf("invalid request idempotency key = %v, want released", idempotencyKey)
+	}
+}
+
+func TestAppClient_SubscribeMatchingEvents(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+	app.Start()
+	matches := []model.Match{{MatchPercent: 90}, {MatchPercent: 80}, {MatchPercent: 70}, {MatchPercent: 60}}
+	if err := storage.SaveUserMatchingResult(model.UserMatchingResult{ID: "account", Matches: matches}); err != nil {
+		t.Fatalf("error saving match result: %v", err)
+	}
+
+	events, unsubscribe, err := app.Client.SubscribeMatchingEvents("account")
+	if err != nil {
+		t.Fatalf("appClient.SubscribeMatchingEvents() error = %v", err)
+	}
+	receive := func() model.MatchingEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("matching event not received")
+			return model.MatchingEvent{}
+		}
+	}
+
+	// the results of other accounts are not sent
+	if err := storage.SaveUserMatchingResult(model.UserMatchingResult{ID: "other", Matches: matches}); err != nil {
+		t.Fatalf("error saving match result: %v", err)
+	}
+	app.Client.MatchOccupations(model.SurveyData{ID: "survey", Version: "1"}, "account")
+	if event := receive(); event.Type != model.MatchingEventStarted || event.AccountID != "account" || event.SurveyID != "survey" {
+		t.Errorf("first event = %+v, want started", event)
+	}
+	if event := receive(); event.Type != model.MatchingEventCompleted || event.Summary == nil || event.Summary.MatchesCount != 0 {
+		t.Errorf("second event = %+v, want completed without matches", event)
+	}
+
+	if err := storage.SaveUserMatchingResult(model.UserMatchingResult{ID: "account", Version: "2", Matches: matches}); err != nil {
+		t.Fatalf("error saving match result: %v", err)
+	}
+	event := receive()
+	if event.Type != model.MatchingEventCompleted || event.Summary == nil || event.Summary.MatchesCount != 4 || !reflect.DeepEqual(event.Summary.TopMatches, matches[:3]) {
+		t.Errorf("third event = %+v, want completed with the top 3 matches", event)
+	}
+
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("events channel not closed when unsubscribed")
+	}
+
+	events, _, err = app.Client.SubscribeMatchingEvents("account")
+	if err != nil {
+		t.Fatalf("appClient.SubscribeMatchingEvents() error = %v", err)
+	}
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Error("events channel not closed when stopped")
+	}
+	_, _, err = app.Client.SubscribeMatchingEvents("account")
+	if errors.Status(err) != utils.ErrorStatusUnavailable {
+		t.Errorf("stopped appClient.SubscribeMatchingEvents() error = %v, want unavailable", err)
+	}
+}
+
+func TestAppClient_MatchingEventsAcrossInstances(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	matching := buildTestApplication(storage)
+	matching.Start()
+	other := buildTestApplication(storage)
+	other.Start()
+
+	subscribe := func(app *core.Application) <-chan model.MatchingEvent {
+		events, _, err := app.Client.SubscribeMatchingEvents("account")
+		if err != nil {
+			t.Fatalf("appClient.SubscribeMatchingEvents() error = %v", err)
+		}
+		return events
+	}
+	matchingEvents := subscribe(matching)
+	otherEvents := subscribe(other)
+
+	matching.Client.MatchOccupations(model.SurveyData{ID: "survey", Version: "1"}, "account")
+
+	// each instance sends the started and completed events once, whichever instance matched the occupations
+	for name, events := range map[string]<-chan model.MatchingEvent{"matching": matchingEvents, "other": otherEvents} {
+		received := map[string]int{}
+		timeout := time.After(time.Second)
+		for len(received) < 2 {
+			select {
+			case event := <-events:
+				received[event.Type]++
+			case <-timeout:
+				t.Fatalf("%s instance events = %v, want started and completed events", name, received)
+			}
+		}
+		select {
+		case event := <-events:
+			received[event.Type]++
+		case <-time.After(100 * time.Millisecond):
+		}
+		if received[model.MatchingEventStarted] != 1 || received[model.MatchingEventCompleted] != 1 {
+			t.Errorf("%s instance events = %v, want one started and one completed event", name, received)
+		}
+	}
+
+	for _, app := range []*core.Application{matching, other} {
+		if err := app.Stop(context.Background()); err != nil {
+			t.Fatalf("Stop() error = %v", err)
+		}
+	}
+}
+
+func TestAppClient_MatchOccupationsHistory(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+
+	app.Client.MatchOccupations(model.SurveyData{ID: "survey-1", Version: "1"}, "account")
+	app.Client.MatchOccupations(model.SurveyData{ID: "survey-2", Version: "2"}, "account")
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	// the match result is replaced, while each result is kept in the history
+	result, err := storage.GetUserMatchingResult("account")
+	if err != nil || result.Revision != 2 {
+		t.Errorf("match result = %+v, %v, want the second revision", result, err)
+	}
+	histories, err := storage.FindUserMatchingResultHistories("account")
+	if err != nil || len(histories) != 2 {
+		t.Fatalf("match results history = %+v, %v, want both results", histories, err)
+	}
+	versions := map[string]bool{histories[0].Version: true, histories[1].Version: true}
+	if !versions["1"] || !versions["2"] || histories[0].AccountID != "account" {
+		t.Errorf("match results history = %+v, want the results of both surveys of the account", histories)
+	}
+}
+
+func TestAppClient_ExportUserData(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+	now := time.Now().UTC()
+	if err := storage.CreateSurveyData(model.SurveyData{ID: "survey", AccountID: "account", Scores: testSurveyScores(), DateCreated: now}); err != nil {
+		t.Fatalf("error creating survey data: %v", err)
+	}
+	for i, percent := range []float64{87.5, 90} {
+		history := model.UserMatchingResultHistory{ID: fmt.Sprintf("history-%d", i), AccountID: "account", DateCreated: now.Add(time.Duration(i) * time.Minute),
+			Matches: []model.Match{{Occupation: model.OccupationMatch{Code: "11-1011.00", Name: "Chief Executives"}, MatchPercent: percent}}}
+		if err := storage.InsertUserMatchingResultHistory(history); err != nil {
+			t.Fatalf("error inserting match result history: %v", err)
+		}
+	}
+
+	if _, err := app.Client.ExportUserData("account", "xml"); errors.Status(err) != utils.ErrorStatusInvalid {
+		t.Errorf("appClient.ExportUserData() unknown format error = %v, want invalid", err)
+	}
+
+	userDataExport, err := app.Client.ExportUserData("account", model.UserDataExportFormatJSON)
+	if err != nil || userDataExport.Status != model.UserDataExportStatusReady {
+		t.Fatalf("appClient.ExportUserData() = %+v, %v, want ready export", userDataExport, err)
+	}
+	var archive model.UserDataArchive
+	if err = json.Unmarshal(userDataExport.Data, &archive); err != nil {
+		t.Fatalf("appClient.ExportUserData() archive error = %v", err)
+	}
+	if archive.AccountID != "account" || len(archive.SurveyDatas) != 1 || len(archive.MatchResults) != 2 || archive.MatchResults[0].ID != "history-0" {
+		t.Errorf("appClient.ExportUserData() archive = %+v, want the survey data and match results history of the account, from the oldest", archive)
+	}
+
+	userDataExport, err = app.Client.ExportUserData("account", model.UserDataExportFormatCSV)
+	if err != nil {
+		t.Fatalf("appClient.ExportUserData() csv error = %v", err)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(userDataExport.Data), int64(len(userDataExport.Data)))
+	if err != nil {
+		t.Fatalf("appClient.ExportUserData() csv archive error = %v", err)
+	}
+	rows := map[string]int{}
+	for _, file := range zipReader.File {
+		reader, _ := file.Open()
+		records, err := csv.NewReader(reader).ReadAll()
+		if err != nil {
+			t.Fatalf("appClient.ExportUserData() csv file %s error = %v", file.Name, err)
+		}
+		rows[file.Name] = len(records)
+	}
+	wantRows := map[string]int{"survey_data.csv": len(testSurveyScores()) + 1, "match_results.csv": 3}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("appClient.ExportUserData() csv rows = %v, want %v", rows, wantRows)
+	}
+}
+
+func TestAppClient_ExportUserDataInBackground(t *testing.T) {
+	storage := memory.NewStorageAdapter("", logs.NewLogger(serviceID, nil))
+	app := buildTestApplication(storage)
+	for i := 0; i <= model.UserDataExportSyncLimit; i++ {
+		surveyData := model.SurveyData{ID: fmt.Sprintf("survey-%d", i), AccountID: "account", Scores: testSurveyScores(), DateCreated: time.Now().UTC()}
+		if err := storage.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("error creating survey data: %v", err)
+		}
+	}
+
+	userDataExport, err := app.Client.ExportUserData("account", model.UserDataExportFormatJSON)
+	if err != nil || userDataExport.Status != model.UserDataExportStatusPending || userDataExport.Data != nil {
+		t.Fatalf("appClient.ExportUserData() = %+v, %v, want pending export", userDataExport, err)
+	}
+	if err = app.Stop(context.Background()); err != nil {
+		t.Fatalf("error waiting for the export: %v", err)
+	}
+
+	status, err := app.Client.GetUserDataExport("account", userDataExport.ID)
+	if err != nil || status.Status != model.UserDataExportStatusReady || status.Data != nil {
+		t.Errorf("appClient.GetUserDataExport() = %+v, %v, want ready export without data", status, err)
+	}
+	if _, err = app.Client.GetUserDataExport("other", userDataExport.ID); errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("appClient.GetUserDataExport() of another account error = %v, want not found", err)
+	}
+
+	if _, err = app.Client.DownloadUserDataExport(userDataExport.ID, "wrong"); errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("appClient.DownloadUserDataExport() with a wrong token error = %v, want not found", err)
+	}
+	downloaded, err := app.Client.DownloadUserDataExport(userDataExport.ID, userDataExport.Token)
+	if err != nil {
+		t.Fatalf("appClient.DownloadUserDataExport() error = %v", err)
+	}
+	var archive model.UserDataArchive
+	if err = json.Unmarshal(downloaded.Data, &archive); err != nil || len(archive.SurveyDatas) != model.UserDataExportSyncLimit+1 {
+		t.Errorf("appClient.DownloadUserDataExport() archive has %d survey data, %v, want %d", len(archive.SurveyDatas), err, model.UserDataExportSyncLimit+1)
+	}
+
+	// the application is stopped, so no other export is started in the background
+	if _, err = app.Client.ExportUserData("account", model.UserDataExportFormatJSON); errors.Status(err) != utils.ErrorStatusUnavailable {
+		t.Errorf("appClient.ExportUserData() while stopping error = %v, want unavailable", err)
+	}
+}
@@ -14,6 +14,11 @@
 
 package core
 
+import (
+	"application/core/model"
+	"time"
+)
+
 // appDefault contains default implementations
 type appDefault struct {
 	app *Application
@@ -24,6 +29,41 @@ func (a appDefault) GetVersion() string {
 	return a.app.version
 }
 
+// GetHealth gets the health of this service, including the checks of its dependencies when ready is set
+func (a appDefault) GetHealth(ready bool) model.Health {
+	health := model.Health{Status: model.HealthStatusPass, Version: a.app.version}
+	if !ready {
+		return health
+	}
+
+	for name, checks := range a.app.storage.CheckHealth() {
+		health.AddChecks(name, checks...)
+	}
+
+	now := time.Now().UTC()
+	occupationData := model.HealthCheck{Status: model.HealthStatusPass, Time: &now}
+	occupationDatas, err := a.app.storage.GetAllOccupationDatas()
+	if err != nil {
+		occupationData.Status = model.HealthStatusFail
+		occupationData.Output = err.Error()
+	} else if len(occupationDatas) == 0 {
+		occupationData.Status = model.HealthStatusFail
+		occupationData.Output = "no occupation data loaded"
+	}
+	occupationData.ObservedValue = len(occupationDatas)
+	health.AddChecks("occupation_data:size", occupationData)
+
+	matchingQueue := model.HealthCheck{Status: model.HealthStatusPass, ObservedValue: a.app.pendingMatchings.Load(), Time: &now}
+	health.AddChecks("matching:queue_depth", matchingQueue)
+
+	return health
+}
+
+// TakeRateLimit takes a request of an account from the rate limit of a route, returning nil if the route is not limited
+func (a appDefault) TakeRateLimit(route string, accountID string, appID string, orgID string) (*model.RateLimitStatus, error) {
+	return a.app.takeRateLimit(route, accountID, appID, orgID)
+}
+
 // newAppDefault creates new appDefault
 func newAppDefault(app *Application) appDefault {
 	return appDefault{app: app}
@@ -15,8 +15,16 @@
 package core_test
 
 import (
+	"application/core"
 	"application/core/interfaces"
+	"application/core/interfaces/mocks"
+	"application/core/model"
+	"application/driven/ratelimit"
 	"testing"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/logging-library-go/v2/logs"
 )
 
 func Test_appDefault_GetVersion(t *testing.T) {
@@ -35,3 +43,66 @@ func Test_appDefault_GetVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_appDefault_GetHealth(t *testing.T) {
+	ping := model.HealthCheck{ComponentID: "mongodb", Status: model.HealthStatusPass}
+	tests := []struct {
+		name            string
+		ready           bool
+		storageChecks   map[string][]model.HealthCheck
+		occupationDatas []model.OccupationData
+		wantStatus      string
+		wantChecks      int
+	}{
+		{"live", false, nil, nil, model.HealthStatusPass, 0},
+		{"ready", true, map[string][]model.HealthCheck{"mongodb:response_time": {ping}}, []model.OccupationData{{Code: "15-1252.00"}}, model.HealthStatusPass, 3},
+		{"no occupation data", true, map[string][]model.HealthCheck{}, []model.OccupationData{}, model.HealthStatusFail, 2},
+		{"storage failing", true, map[string][]model.HealthCheck{"mongodb:response_time": {{Status: model.HealthStatusFail}}}, []model.OccupationData{{Code: "15-1252.00"}}, model.HealthStatusFail, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := mocks.NewStorage(t)
+			if tt.ready {
+				storage.On("CheckHealth").Return(tt.storageChecks)
+				storage.On("GetAllOccupationDatas").Return(tt.occupationDatas, nil)
+			}
+			app := buildTestApplication(storage)
+
+			got := app.Default.GetHealth(tt.ready)
+			if got.Status != tt.wantStatus || len(got.Checks) != tt.wantChecks || got.Version != "1.1.1" {
+				t.Errorf("appDefault.GetHealth() = %+v, want status %s with %d checks", got, tt.wantStatus, tt.wantChecks)
+			}
+		})
+	}
+}
+
+func Test_appDefault_TakeRateLimit(t *testing.T) {
+	data := model.RateLimitConfigData{Limits: []model.RateLimit{{Route: "POST /api/survey-data", Requests: 2, PeriodSeconds: 60}}}
+	storage := mocks.NewStorage(t)
+	storage.On("FindConfig", model.ConfigTypeRateLimit, "app", "org").Return(nil, nil)
+	storage.On("FindConfig", model.ConfigTypeRateLimit, authutils.AllApps, authutils.AllOrgs).Return(&model.Config{Type: model.ConfigTypeRateLimit, Data: data}, nil)
+	app := core.NewApplication("1.1.1", "build", storage, nil, ratelimit.NewRateLimitAdapter(), nil, 24*time.Hour, logs.NewLogger(serviceID, nil))
+
+	wantRemaining := []int{1, 0, 0}
+	for i, wantAllowed := range []bool{true, true, false} {
+		status, err := app.Default.TakeRateLimit("POST /api/survey-data", "user", "app", "org")
+		if err != nil || status == nil {
+			t.Fatalf("appDefault.TakeRateLimit() = %v, %v", status, err)
+		}
+		if status.Allowed != wantAllowed || status.Remaining != wantRemaining[i] {
+			t.Errorf("appDefault.TakeRateLimit() request %d = %+v, want allowed %v and %d remaining", i, status, wantAllowed, wantRemaining[i])
+		}
+		if !wantAllowed && status.RetryAfter <= 0 {
+			t.Errorf("appDefault.TakeRateLimit() retry after = %v, want positive", status.RetryAfter)
+		}
+	}
+
+	status, err := app.Default.TakeRateLimit("POST /api/survey-data", "other", "app", "org")
+	if err != nil || status == nil || !status.Allowed {
+		t.Errorf("appDefault.TakeRateLimit() other account = %v, %v, want allowed", status, err)
+	}
+	status, err = app.Default.TakeRateLimit("GET /api/crosswalks", "user", "app", "org")
+	if err != nil || status != nil {
+		t.Errorf("appDefault.TakeRateLimit() unlimited route = %v, %v, want nil", status, err)
+	}
+}
@@ -17,11 +17,17 @@ package core
 import (
 	"application/core/interfaces"
 	"application/core/model"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rokwire/core-auth-library-go/v3/authutils"
 	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logs"
 	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.opentelemetry.io/otel"
 )
 
 type storageListener struct {
@@ -29,18 +35,63 @@ type storageListener struct {
 	model.DefaultStorageListener
 }
 
+func (s *storageListener) OnOccupationDataUpdated() {
+	s.app.resetOccupationGraph()
+}
+
+func (s *storageListener) OnDataChanged(change model.DataChange) {
+	if change.Collection == model.CollectionMatchResults {
+		s.app.onMatchResultChanged(change)
+	}
+	if change.Collection == model.CollectionMatchingEvents {
+		s.app.onMatchingEventChanged(change)
+	}
+}
+
+// tracer creates the spans of the core operations with the tracer provider set by the tracing adapter
+var tracer = otel.Tracer("application/core")
+
 // Application represents the core application code based on hexagonal architecture
 type Application struct {
 	version string
 	build   string
 
+	// instanceID identifies this instance of the service in the matching events it stores
+	instanceID string
+
 	Default interfaces.Default // expose to the drivers adapters
 	Client  interfaces.Client  // expose to the drivers adapters
 	Admin   interfaces.Admin   // expose to the drivers adapters
 
 	logger *logs.Logger
 
-	storage interfaces.Storage
+	storage    interfaces.Storage
+	crosswalks interfaces.Crosswalks
+	rateLimits interfaces.RateLimits
+	metrics    interfaces.Metrics
+
+	// idempotencyWindow is the time during which a request is not repeated when retried with the same idempotency key
+	idempotencyWindow time.Duration
+
+	occupationGraph     *occupationGraph
+	occupationGraphLock *sync.RWMutex
+
+	// pendingMatchings is the number of occupation matchings started and not yet completed
+	pendingMatchings atomic.Int64
+
+	// workers tracks the background work of the application, which is not started once stopping is closed
+	workers     *sync.WaitGroup
+	workersLock *sync.Mutex
+	stopping    chan struct{}
+
+	// matchingSubscriptions are the subscriptions to the matching events by account ID
+	matchingSubscriptions     map[string][]*matchingSubscription
+	matchingSubscriptionsLock *sync.Mutex
+
+	lastRetentionPurge *time.Time
+	nextRetentionPurge time.Time
+	lastPurgedCounts   map[string]int64
+	retentionLock      *sync.RWMutex
 }
 
 // Start starts the core part of the application
@@ -48,6 +99,70 @@ func (a *Application) Start() {
 	//set storage listener
 	storageListener := storageListener{app: a}
 	a.storage.RegisterStorageListener(&storageListener)
+
+	a.goWorker(a.runRetentionPurges)
+}
+
+// Stop stops the background work of the application, waiting for the running occupation matchings to complete until the context is done
+//
+//	The subscriptions to the matching events are closed.
+func (a *Application) Stop(ctx context.Context) error {
+	a.workersLock.Lock()
+	select {
+	case <-a.stopping:
+	default:
+		close(a.stopping)
+	}
+	a.workersLock.Unlock()
+
+	a.closeMatchingSubscriptions()
+
+	done := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Newf("stopped with %d occupation matchings in progress: %v", a.pendingMatchings.Load(), ctx.Err())
+	}
+}
+
+// goWorker runs fn in the background until it returns or the application is stopped, returning false if the application is stopping
+//
+//	Long running workers must return once the stopping channel is closed.
+func (a *Application) goWorker(fn func()) bool {
+	a.workersLock.Lock()
+	defer a.workersLock.Unlock()
+
+	select {
+	case <-a.stopping:
+		return false
+	default:
+	}
+
+	a.workers.Add(1)
+	go func() {
+		defer a.workers.Done()
+		fn()
+	}()
+	return true
+}
+
+// sleep waits for the given duration, returning false if the application is stopped before
+func (a *Application) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-a.stopping:
+		return false
+	}
 }
 
 // GetEnvConfigs retrieves the cached database env configs
@@ -63,9 +178,43 @@ func (a *Application) GetEnvConfigs() (*model.EnvConfigData, error) {
 	return model.GetConfigData[model.EnvConfigData](*config)
 }
 
+// getOccupationGraph returns the cached related occupations graph, building it on first use
+func (a *Application) getOccupationGraph() (*occupationGraph, error) {
+	a.occupationGraphLock.RLock()
+	graph := a.occupationGraph
+	a.occupationGraphLock.RUnlock()
+	if graph != nil {
+		return graph, nil
+	}
+
+	a.occupationGraphLock.Lock()
+	defer a.occupationGraphLock.Unlock()
+	if a.occupationGraph != nil {
+		return a.occupationGraph, nil
+	}
+
+	occupations, err := a.storage.GetAllOccupationDatas()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+	a.occupationGraph = newOccupationGraph(occupations)
+	return a.occupationGraph, nil
+}
+
+// resetOccupationGraph clears the cached related occupations graph so that it is rebuilt on next use
+func (a *Application) resetOccupationGraph() {
+	a.occupationGraphLock.Lock()
+	defer a.occupationGraphLock.Unlock()
+
+	a.occupationGraph = nil
+}
+
 // NewApplication creates new Application
-func NewApplication(version string, build string, storage interfaces.Storage, logger *logs.Logger) *Application {
-	application := Application{version: version, build: build, storage: storage, logger: logger}
+func NewApplication(version string, build string, storage interfaces.Storage, crosswalks interfaces.Crosswalks, rateLimits interfaces.RateLimits,
+	metrics interfaces.Metrics, idempotencyWindow time.Duration, logger *logs.Logger) *Application {
+	application := Application{version: version, build: build, instanceID: uuid.NewString(), storage: storage, crosswalks: crosswalks, rateLimits: rateLimits, metrics: metrics, idempotencyWindow: idempotencyWindow, logger: logger, occupationGraphLock: &sync.RWMutex{},
+		lastPurgedCounts: map[string]int64{}, retentionLock: &sync.RWMutex{}, workers: &sync.WaitGroup{}, workersLock: &sync.Mutex{}, stopping: make(chan struct{}),
+		matchingSubscriptions: map[string][]*matchingSubscription{}, matchingSubscriptionsLock: &sync.Mutex{}}
 
 	//add the drivers ports/interfaces
 	application.Default = newAppDefault(&application)
@@ -19,6 +19,7 @@ import (
 	"application/core/interfaces"
 	"application/core/interfaces/mocks"
 	"application/core/model"
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -35,9 +36,13 @@ const (
 )
 
 func buildTestApplication(storage interfaces.Storage) *core.Application {
+	return buildTestApplicationWithCrosswalks(storage, nil)
+}
+
+func buildTestApplicationWithCrosswalks(storage interfaces.Storage, crosswalks interfaces.Crosswalks) *core.Application {
 	loggerOpts := logs.LoggerOpts{SuppressRequests: logs.NewStandardHealthCheckHTTPRequestProperties(serviceID + "/version")}
 	logger := logs.NewLogger(serviceID, &loggerOpts)
-	return core.NewApplication("1.1.1", "build", storage, logger)
+	return core.NewApplication("1.1.1", "build", storage, crosswalks, nil, nil, 24*time.Hour, logger)
 }
 
 func TestApplication_Start(t *testing.T) {
@@ -84,3 +89,38 @@ func TestApplication_GetEnvConfigs(t *testing.T) {
 		})
 	}
 }
+
+func TestApplication_Stop(t *testing.T) {
+	release := make(chan time.Time)
+	storage := mocks.NewStorage(t)
+	storage.On("WithContext", mock.Anything).Return(storage)
+	storage.On("GetAllOccupationDatas").WaitUntil(release).Return([]model.OccupationData{}, nil).Once()
+	storage.On("PerformTransaction", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(func(interfaces.Storage) error)(storage)
+	}).Return(nil).Once()
+	storage.On("SaveUserMatchingResult", mock.AnythingOfType("model.UserMatchingResult")).Return(nil).Once()
+	storage.On("InsertUserMatchingResultHistory", mock.AnythingOfType("model.UserMatchingResultHistory")).Return(nil).Once()
+	storage.On("InsertMatchingEvent", mock.AnythingOfType("model.MatchingEvent")).Return(nil).Once()
+	app := buildTestApplication(storage)
+
+	app.Client.MatchOccupations(model.SurveyData{ID: "survey"}, "user")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := app.Stop(ctx); err == nil {
+		t.Fatal("Stop() with a matching in progress error = nil, want timeout")
+	}
+
+	close(release)
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	storage.AssertCalled(t, "SaveUserMatchingResult", mock.AnythingOfType("model.UserMatchingResult"))
+
+	// matchings are not started once stopped
+	app.Client.MatchOccupations(model.SurveyData{ID: "survey"}, "user")
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatalf("second Stop() error = %v", err)
+	}
+	storage.AssertNumberOfCalls(t, "GetAllOccupationDatas", 1)
+}
@@ -16,6 +16,7 @@ package interfaces
 
 import (
 	"application/core/model"
+	"context"
 
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
 )
@@ -23,26 +24,45 @@ import (
 // Default exposes client APIs for the driver adapters
 type Default interface {
 	GetVersion() string
+	GetHealth(ready bool) model.Health
+	TakeRateLimit(route string, accountID string, appID string, orgID string) (*model.RateLimitStatus, error)
 }
 
 // Client exposes client APIs for the driver adapters
 type Client interface {
+	// WithContext returns a copy of the client handling the request with the given context, such as its trace
+	WithContext(ctx context.Context) Client
+
 	// OccupationData APIs
 	GetOccupationData(code string) (*model.OccupationData, error)
 	GetAllOccupationDatas() ([]model.OccupationData, error)
+	GetRelatedOccupations(code string, limit int) ([]model.OccupationNeighbor, error)
+	GetOccupationPathways(fromCode string, toCode string, limit int) ([]model.OccupationPathway, error)
+
+	// Crosswalk APIs
+	GetCrosswalkSystems() []string
+	GetOccupationCrosswalks(code string, systems []string) ([]model.CrosswalkCode, error)
+	GetCrosswalkOccupations(system string, code string) ([]model.OccupationMatch, error)
 
 	// UserMatchingResult APIs
-	GetUserMatchingResult(id string) (*model.UserMatchingResult, error)
+	GetUserMatchingResult(id string, crosswalkSystems []string) (*model.UserMatchingResult, error)
 	DeleteUserMatchingResult(id string) error
 
 	// Survey Data APIs
 	GetSurveyData(id string) (*model.SurveyData, error)
 	CreateSurveyData(surveyData model.SurveyData) (*model.SurveyData, error)
+	CreateSurveyDataIdempotent(surveyData model.SurveyData, idempotencyKey string) (*model.SurveyData, bool, error)
 	UpdateSurveyData(surveyData model.SurveyData) error
 	DeleteSurveyData(id string) error
 
-	// Occupation Matching
+	// User Data Export APIs
+	ExportUserData(accountID string, format string) (*model.UserDataExport, error)
+	GetUserDataExport(accountID string, id string) (*model.UserDataExport, error)
+	DownloadUserDataExport(id string, token string) (*model.UserDataExport, error)
+
+	// Occupation Matching, run in the background
 	MatchOccupations(surveyData model.SurveyData, userID string)
+	SubscribeMatchingEvents(accountID string) (<-chan model.MatchingEvent, func(), error)
 }
 
 // Admin exposes administrative APIs for the driver adapters
@@ -52,4 +72,20 @@ type Admin interface {
 	CreateConfig(config model.Config, claims *tokenauth.Claims) (*model.Config, error)
 	UpdateConfig(config model.Config, claims *tokenauth.Claims) error
 	DeleteConfig(id string, claims *tokenauth.Claims) error
+
+	GetOccupationData(code string) (*model.OccupationData, error)
+	GetOccupationDatas() ([]model.OccupationData, error)
+	CreateOccupationData(occupationData model.OccupationData) (*model.OccupationData, error)
+	UpdateOccupationData(occupationData model.OccupationData) error
+	DeleteOccupationData(code string) error
+	GetOccupationDataReport() (*model.OccupationDataReport, error)
+	GetRetentionReport() (*model.RetentionReport, error)
+
+	// User data APIs, audited
+	SearchSurveyDatas(search model.UserDataSearch, claims *tokenauth.Claims) ([]model.SurveyData, error)
+	GetSurveyData(id string, claims *tokenauth.Claims) (*model.SurveyData, error)
+	SearchUserMatchingResults(search model.UserDataSearch, claims *tokenauth.Claims) ([]model.UserMatchingResult, error)
+	GetUserMatchingResult(accountID string, claims *tokenauth.Claims) (*model.UserMatchingResult, error)
+	MatchUserOccupations(accountID string, claims *tokenauth.Claims) (*model.SurveyData, error)
+	DeleteUserData(accountID string, claims *tokenauth.Claims) (*model.UserDataDeletion, error)
 }
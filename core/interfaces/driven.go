@@ -16,11 +16,16 @@ package interfaces
 
 import (
 	"application/core/model"
+	"context"
+	"time"
 )
 
 // Storage is used by core to storage data - DB storage adapter, file storage adapter etc
+//
+//	WithContext returns a copy of the storage performing its operations with the given context, such as the trace of a request.
 type Storage interface {
 	RegisterStorageListener(listener StorageListener)
+	WithContext(ctx context.Context) Storage
 	PerformTransaction(func(storage Storage) error) error
 
 	FindConfig(configType string, appID string, orgID string) (*model.Config, error)
@@ -32,18 +37,79 @@ type Storage interface {
 
 	GetOccupationData(id string) (*model.OccupationData, error)
 	GetAllOccupationDatas() ([]model.OccupationData, error)
+	InsertOccupationData(occupationData model.OccupationData) error
+	UpdateOccupationData(occupationData model.OccupationData) error
+	DeleteOccupationData(code string) error
 
 	GetUserMatchingResult(id string) (*model.UserMatchingResult, error)
+	FindUserMatchingResults(afterID string, limit int) ([]model.UserMatchingResult, error)
+	SearchUserMatchingResults(search model.UserDataSearch) ([]model.UserMatchingResult, error)
+	InsertUserMatchingResult(userMatchingResult model.UserMatchingResult) error
 	SaveUserMatchingResult(bessiData model.UserMatchingResult) error
 	DeleteUserMatchingResult(id string) error
 
+	InsertUserMatchingResultHistory(history model.UserMatchingResultHistory) error
+	FindUserMatchingResultHistories(accountID string) ([]model.UserMatchingResultHistory, error)
+	DeleteUserMatchingResultHistoriesByAccount(accountID string) (int64, error)
+
 	GetSurveyData(id string) (*model.SurveyData, error)
+	FindSurveyDatas(afterID string, limit int) ([]model.SurveyData, error)
+	SearchSurveyDatas(search model.UserDataSearch) ([]model.SurveyData, error)
 	CreateSurveyData(surveyData model.SurveyData) error
 	UpdateSurveyData(surveyData model.SurveyData) error
 	DeleteSurveyData(id string) error
+	DeleteSurveyDatasByAccount(accountID string) (int64, error)
+
+	InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) (bool, error)
+	FindIdempotencyKey(accountID string, key string) (*model.IdempotencyKey, error)
+	UpdateIdempotencyKey(idempotencyKey model.IdempotencyKey) error
+	DeleteIdempotencyKey(accountID string, key string) error
+	DeleteIdempotencyKeysByAccount(accountID string) (int64, error)
+
+	InsertUserDataExport(userDataExport model.UserDataExport) error
+	GetUserDataExport(id string) (*model.UserDataExport, error)
+	UpdateUserDataExport(userDataExport model.UserDataExport) error
+	DeleteUserDataExportsByAccount(accountID string) (int64, error)
+
+	InsertMatchingEvent(event model.MatchingEvent) error
+	GetMatchingEvent(id string) (*model.MatchingEvent, error)
+
+	CheckHealth() map[string][]model.HealthCheck
+
+	GetDataRetentionStatus(collection string, cutoff time.Time) (int64, *time.Time, error)
+	DeleteDataUpdatedBefore(collection string, cutoff time.Time) (int64, error)
 }
 
 // StorageListener represents storage listener
+//
+//	OnConfigsUpdated is called once for each change to the configs, OnOccupationDataUpdated once the storage data reflects
+//	a change or a burst of changes to the occupation data, and OnDataChanged for each document inserted, updated or
+//	deleted in any collection, in the order of the changes to each collection.
 type StorageListener interface {
 	OnConfigsUpdated()
+	OnOccupationDataUpdated()
+	OnDataChanged(change model.DataChange)
+}
+
+// Crosswalks is used by core to look up equivalent occupation codes in other classification systems
+type Crosswalks interface {
+	GetCrosswalkSystems() []string
+	FindCrosswalkCodes(occupationCode string, system string) []model.CrosswalkCode
+	FindOccupationCodes(system string, code string) []string
+}
+
+// RateLimits is used by core to count the requests of each client against its rate limit
+//
+//	Take removes a request from the token bucket of the given key, which is refilled evenly over the period of the limit.
+//	Implementations may keep the buckets in process or in a store shared by all the service instances.
+type RateLimits interface {
+	Take(key string, limit model.RateLimit, now time.Time) (model.RateLimitStatus, error)
+}
+
+// Metrics is used by core to record the metrics of the occupation matching
+//
+//	ObserveMatching is called once each matching run completes, with the error that made it fail if any.
+type Metrics interface {
+	ObserveMatching(duration time.Duration, occupationsScored int, err error)
+	SetMatchingQueueDepth(depth int64)
 }
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.28.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "application/core/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Crosswalks is an autogenerated mock type for the Crosswalks type
+type Crosswalks struct {
+	mock.Mock
+}
+
+// FindCrosswalkCodes provides a mock function with given fields: occupationCode, system
+func (_m *Crosswalks) FindCrosswalkCodes(occupationCode string, system string) []model.CrosswalkCode {
+	ret := _m.Called(occupationCode, system)
+
+	var r0 []model.CrosswalkCode
+	if rf, ok := ret.Get(0).(func(string, string) []model.CrosswalkCode); ok {
+		r0 = rf(occupationCode, system)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CrosswalkCode)
+		}
+	}
+
+	return r0
+}
+
+// FindOccupationCodes provides a mock function with given fields: system, code
+func (_m *Crosswalks) FindOccupationCodes(system string, code string) []string {
+	ret := _m.Called(system, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(system, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetCrosswalkSystems provides a mock function with given fields:
+func (_m *Crosswalks) GetCrosswalkSystems() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewCrosswalks interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCrosswalks creates a new instance of Crosswalks. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCrosswalks(t mockConstructorTestingTNewCrosswalks) *Crosswalks {
+	mock := &Crosswalks{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,10 +4,13 @@ package mocks
 
 import (
 	interfaces "application/core/interfaces"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
 	model "application/core/model"
+
+	time "time"
 )
 
 // Storage is an autogenerated mock type for the Storage type
@@ -15,6 +18,22 @@ type Storage struct {
 	mock.Mock
 }
 
+// CheckHealth provides a mock function with given fields:
+func (_m *Storage) CheckHealth() map[string][]model.HealthCheck {
+	ret := _m.Called()
+
+	var r0 map[string][]model.HealthCheck
+	if rf, ok := ret.Get(0).(func() map[string][]model.HealthCheck); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]model.HealthCheck)
+		}
+	}
+
+	return r0
+}
+
 // CreateSurveyData provides a mock function with given fields: surveyData
 func (_m *Storage) CreateSurveyData(surveyData model.SurveyData) error {
 	ret := _m.Called(surveyData)
@@ -43,6 +62,82 @@ func (_m *Storage) DeleteConfig(id string) error {
 	return r0
 }
 
+// DeleteDataUpdatedBefore provides a mock function with given fields: collection, cutoff
+func (_m *Storage) DeleteDataUpdatedBefore(collection string, cutoff time.Time) (int64, error) {
+	ret := _m.Called(collection, cutoff)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (int64, error)); ok {
+		return rf(collection, cutoff)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(collection, cutoff)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(collection, cutoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: accountID, key
+func (_m *Storage) DeleteIdempotencyKey(accountID string, key string) error {
+	ret := _m.Called(accountID, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(accountID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteIdempotencyKeysByAccount provides a mock function with given fields: accountID
+func (_m *Storage) DeleteIdempotencyKeysByAccount(accountID string) (int64, error) {
+	ret := _m.Called(accountID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(accountID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOccupationData provides a mock function with given fields: code
+func (_m *Storage) DeleteOccupationData(code string) error {
+	ret := _m.Called(code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSurveyData provides a mock function with given fields: id
 func (_m *Storage) DeleteSurveyData(id string) error {
 	ret := _m.Called(id)
@@ -57,6 +152,54 @@ func (_m *Storage) DeleteSurveyData(id string) error {
 	return r0
 }
 
+// DeleteSurveyDatasByAccount provides a mock function with given fields: accountID
+func (_m *Storage) DeleteSurveyDatasByAccount(accountID string) (int64, error) {
+	ret := _m.Called(accountID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(accountID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserDataExportsByAccount provides a mock function with given fields: accountID
+func (_m *Storage) DeleteUserDataExportsByAccount(accountID string) (int64, error) {
+	ret := _m.Called(accountID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(accountID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteUserMatchingResult provides a mock function with given fields: id
 func (_m *Storage) DeleteUserMatchingResult(id string) error {
 	ret := _m.Called(id)
@@ -71,6 +214,30 @@ func (_m *Storage) DeleteUserMatchingResult(id string) error {
 	return r0
 }
 
+// DeleteUserMatchingResultHistoriesByAccount provides a mock function with given fields: accountID
+func (_m *Storage) DeleteUserMatchingResultHistoriesByAccount(accountID string) (int64, error) {
+	ret := _m.Called(accountID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(accountID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindConfig provides a mock function with given fields: configType, appID, orgID
 func (_m *Storage) FindConfig(configType string, appID string, orgID string) (*model.Config, error) {
 	ret := _m.Called(configType, appID, orgID)
@@ -149,6 +316,110 @@ func (_m *Storage) FindConfigs(configType *string) ([]model.Config, error) {
 	return r0, r1
 }
 
+// FindIdempotencyKey provides a mock function with given fields: accountID, key
+func (_m *Storage) FindIdempotencyKey(accountID string, key string) (*model.IdempotencyKey, error) {
+	ret := _m.Called(accountID, key)
+
+	var r0 *model.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.IdempotencyKey, error)); ok {
+		return rf(accountID, key)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.IdempotencyKey); ok {
+		r0 = rf(accountID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(accountID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSurveyDatas provides a mock function with given fields: afterID, limit
+func (_m *Storage) FindSurveyDatas(afterID string, limit int) ([]model.SurveyData, error) {
+	ret := _m.Called(afterID, limit)
+
+	var r0 []model.SurveyData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]model.SurveyData, error)); ok {
+		return rf(afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []model.SurveyData); ok {
+		r0 = rf(afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SurveyData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserMatchingResultHistories provides a mock function with given fields: accountID
+func (_m *Storage) FindUserMatchingResultHistories(accountID string) ([]model.UserMatchingResultHistory, error) {
+	ret := _m.Called(accountID)
+
+	var r0 []model.UserMatchingResultHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]model.UserMatchingResultHistory, error)); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []model.UserMatchingResultHistory); ok {
+		r0 = rf(accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserMatchingResultHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserMatchingResults provides a mock function with given fields: afterID, limit
+func (_m *Storage) FindUserMatchingResults(afterID string, limit int) ([]model.UserMatchingResult, error) {
+	ret := _m.Called(afterID, limit)
+
+	var r0 []model.UserMatchingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]model.UserMatchingResult, error)); ok {
+		return rf(afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []model.UserMatchingResult); ok {
+		r0 = rf(afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserMatchingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllOccupationDatas provides a mock function with given fields:
 func (_m *Storage) GetAllOccupationDatas() ([]model.OccupationData, error) {
 	ret := _m.Called()
@@ -175,6 +446,65 @@ func (_m *Storage) GetAllOccupationDatas() ([]model.OccupationData, error) {
 	return r0, r1
 }
 
+// GetDataRetentionStatus provides a mock function with given fields: collection, cutoff
+func (_m *Storage) GetDataRetentionStatus(collection string, cutoff time.Time) (int64, *time.Time, error) {
+	ret := _m.Called(collection, cutoff)
+
+	var r0 int64
+	var r1 *time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (int64, *time.Time, error)); ok {
+		return rf(collection, cutoff)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) int64); ok {
+		r0 = rf(collection, cutoff)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) *time.Time); ok {
+		r1 = rf(collection, cutoff)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, time.Time) error); ok {
+		r2 = rf(collection, cutoff)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMatchingEvent provides a mock function with given fields: id
+func (_m *Storage) GetMatchingEvent(id string) (*model.MatchingEvent, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.MatchingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.MatchingEvent, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.MatchingEvent); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MatchingEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOccupationData provides a mock function with given fields: id
 func (_m *Storage) GetOccupationData(id string) (*model.OccupationData, error) {
 	ret := _m.Called(id)
@@ -227,6 +557,32 @@ func (_m *Storage) GetSurveyData(id string) (*model.SurveyData, error) {
 	return r0, r1
 }
 
+// GetUserDataExport provides a mock function with given fields: id
+func (_m *Storage) GetUserDataExport(id string) (*model.UserDataExport, error) {
+	ret := _m.Called(id)
+
+	var r0 *model.UserDataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.UserDataExport, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.UserDataExport); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserDataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserMatchingResult provides a mock function with given fields: id
 func (_m *Storage) GetUserMatchingResult(id string) (*model.UserMatchingResult, error) {
 	ret := _m.Called(id)
@@ -267,6 +623,100 @@ func (_m *Storage) InsertConfig(config model.Config) error {
 	return r0
 }
 
+// InsertIdempotencyKey provides a mock function with given fields: idempotencyKey
+func (_m *Storage) InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) (bool, error) {
+	ret := _m.Called(idempotencyKey)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.IdempotencyKey) (bool, error)); ok {
+		return rf(idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(model.IdempotencyKey) bool); ok {
+		r0 = rf(idempotencyKey)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.IdempotencyKey) error); ok {
+		r1 = rf(idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertMatchingEvent provides a mock function with given fields: event
+func (_m *Storage) InsertMatchingEvent(event model.MatchingEvent) error {
+	ret := _m.Called(event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.MatchingEvent) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertOccupationData provides a mock function with given fields: occupationData
+func (_m *Storage) InsertOccupationData(occupationData model.OccupationData) error {
+	ret := _m.Called(occupationData)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.OccupationData) error); ok {
+		r0 = rf(occupationData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertUserDataExport provides a mock function with given fields: userDataExport
+func (_m *Storage) InsertUserDataExport(userDataExport model.UserDataExport) error {
+	ret := _m.Called(userDataExport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.UserDataExport) error); ok {
+		r0 = rf(userDataExport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertUserMatchingResult provides a mock function with given fields: userMatchingResult
+func (_m *Storage) InsertUserMatchingResult(userMatchingResult model.UserMatchingResult) error {
+	ret := _m.Called(userMatchingResult)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.UserMatchingResult) error); ok {
+		r0 = rf(userMatchingResult)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertUserMatchingResultHistory provides a mock function with given fields: history
+func (_m *Storage) InsertUserMatchingResultHistory(history model.UserMatchingResultHistory) error {
+	ret := _m.Called(history)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.UserMatchingResultHistory) error); ok {
+		r0 = rf(history)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PerformTransaction provides a mock function with given fields: _a0
 func (_m *Storage) PerformTransaction(_a0 func(interfaces.Storage) error) error {
 	ret := _m.Called(_a0)
@@ -300,6 +750,58 @@ func (_m *Storage) SaveUserMatchingResult(bessiData model.UserMatchingResult) er
 	return r0
 }
 
+// SearchSurveyDatas provides a mock function with given fields: search
+func (_m *Storage) SearchSurveyDatas(search model.UserDataSearch) ([]model.SurveyData, error) {
+	ret := _m.Called(search)
+
+	var r0 []model.SurveyData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.UserDataSearch) ([]model.SurveyData, error)); ok {
+		return rf(search)
+	}
+	if rf, ok := ret.Get(0).(func(model.UserDataSearch) []model.SurveyData); ok {
+		r0 = rf(search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SurveyData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.UserDataSearch) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchUserMatchingResults provides a mock function with given fields: search
+func (_m *Storage) SearchUserMatchingResults(search model.UserDataSearch) ([]model.UserMatchingResult, error) {
+	ret := _m.Called(search)
+
+	var r0 []model.UserMatchingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.UserDataSearch) ([]model.UserMatchingResult, error)); ok {
+		return rf(search)
+	}
+	if rf, ok := ret.Get(0).(func(model.UserDataSearch) []model.UserMatchingResult); ok {
+		r0 = rf(search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserMatchingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.UserDataSearch) error); ok {
+		r1 = rf(search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateConfig provides a mock function with given fields: config
 func (_m *Storage) UpdateConfig(config model.Config) error {
 	ret := _m.Called(config)
@@ -314,6 +816,34 @@ func (_m *Storage) UpdateConfig(config model.Config) error {
 	return r0
 }
 
+// UpdateIdempotencyKey provides a mock function with given fields: idempotencyKey
+func (_m *Storage) UpdateIdempotencyKey(idempotencyKey model.IdempotencyKey) error {
+	ret := _m.Called(idempotencyKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.IdempotencyKey) error); ok {
+		r0 = rf(idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOccupationData provides a mock function with given fields: occupationData
+func (_m *Storage) UpdateOccupationData(occupationData model.OccupationData) error {
+	ret := _m.Called(occupationData)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.OccupationData) error); ok {
+		r0 = rf(occupationData)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateSurveyData provides a mock function with given fields: surveyData
 func (_m *Storage) UpdateSurveyData(surveyData model.SurveyData) error {
 	ret := _m.Called(surveyData)
@@ -328,6 +858,36 @@ func (_m *Storage) UpdateSurveyData(surveyData model.SurveyData) error {
 	return r0
 }
 
+// UpdateUserDataExport provides a mock function with given fields: userDataExport
+func (_m *Storage) UpdateUserDataExport(userDataExport model.UserDataExport) error {
+	ret := _m.Called(userDataExport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.UserDataExport) error); ok {
+		r0 = rf(userDataExport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WithContext provides a mock function with given fields: ctx
+func (_m *Storage) WithContext(ctx context.Context) interfaces.Storage {
+	ret := _m.Called(ctx)
+
+	var r0 interfaces.Storage
+	if rf, ok := ret.Get(0).(func(context.Context) interfaces.Storage); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.Storage)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewStorage interface {
 	mock.TestingT
 	Cleanup(func())
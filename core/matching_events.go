@@ -0,0 +1,205 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/logging-library-go/v2/errors"
+)
+
+const (
+	// matchingEventsBuffer is the number of events buffered for a subscriber, which is unsubscribed once it falls further behind
+	matchingEventsBuffer int = 16
+	// matchingSummaryTopMatches is the number of best matches in the summary of a completed matching
+	matchingSummaryTopMatches int = 3
+)
+
+// matchingSubscription receives the matching events of an account
+//
+//	lastRevision is the revision of the last match result sent, so that each result is sent once, and not when only re-encrypted.
+type matchingSubscription struct {
+	accountID    string
+	events       chan model.MatchingEvent
+	lastRevision int64
+}
+
+// subscribeMatchingEvents subscribes to the matching events of an account, starting after the given match result revision
+func (a *Application) subscribeMatchingEvents(accountID string, lastRevision int64) (*matchingSubscription, bool) {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	select {
+	case <-a.stopping:
+		return nil, false
+	default:
+	}
+
+	subscription := &matchingSubscription{accountID: accountID, events: make(chan model.MatchingEvent, matchingEventsBuffer), lastRevision: lastRevision}
+	a.matchingSubscriptions[accountID] = append(a.matchingSubscriptions[accountID], subscription)
+	return subscription, true
+}
+
+// unsubscribeMatchingEvents removes a subscription and closes its events channel, if not already done
+func (a *Application) unsubscribeMatchingEvents(subscription *matchingSubscription) {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	a.removeMatchingSubscription(subscription)
+}
+
+// removeMatchingSubscription must be called with the subscriptions lock held
+func (a *Application) removeMatchingSubscription(subscription *matchingSubscription) {
+	subscriptions := a.matchingSubscriptions[subscription.accountID]
+	for i, current := range subscriptions {
+		if current == subscription {
+			close(subscription.events)
+			subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+			break
+		}
+	}
+	if len(subscriptions) == 0 {
+		delete(a.matchingSubscriptions, subscription.accountID)
+	} else {
+		a.matchingSubscriptions[subscription.accountID] = subscriptions
+	}
+}
+
+// hasMatchingSubscriptions returns true if the account has subscribed to its matching events
+func (a *Application) hasMatchingSubscriptions(accountID string) bool {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	return len(a.matchingSubscriptions[accountID]) > 0
+}
+
+// hasAnyMatchingSubscriptions returns true if any account has subscribed to its matching events
+func (a *Application) hasAnyMatchingSubscriptions() bool {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	return len(a.matchingSubscriptions) > 0
+}
+
+// publishMatchingEvent sends an event to the subscribers of its account
+//
+//	The completed events are only sent to the subscribers which have not received the match result revision yet.
+//	Subscribers which do not keep up with the events are unsubscribed.
+func (a *Application) publishMatchingEvent(event model.MatchingEvent) {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	for _, subscription := range append([]*matchingSubscription{}, a.matchingSubscriptions[event.AccountID]...) {
+		if event.Summary != nil {
+			if event.Summary.Revision <= subscription.lastRevision {
+				continue
+			}
+			subscription.lastRevision = event.Summary.Revision
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			a.logger.Warnf("unsubscribing slow matching events subscriber of account %s", event.AccountID)
+			a.removeMatchingSubscription(subscription)
+		}
+	}
+}
+
+// sendMatchingEvent publishes a started or failed matching event to the subscribers of this instance of the service, and stores it
+// for the other instances to publish it to their subscribers
+func (a *Application) sendMatchingEvent(event model.MatchingEvent) {
+	a.publishMatchingEvent(event)
+
+	err := a.storage.InsertMatchingEvent(event)
+	if err != nil {
+		a.logger.Errorf("error storing the %s event of account %s for the other instances: %v", event.Type, event.AccountID, err)
+	}
+}
+
+// onMatchingEventChanged publishes a started or failed matching event stored by another instance of the service
+func (a *Application) onMatchingEventChanged(change model.DataChange) {
+	if change.Operation != model.DataChangeInsert || !a.hasAnyMatchingSubscriptions() {
+		return
+	}
+
+	event, err := a.storage.GetMatchingEvent(change.DocumentID)
+	if errors.Status(err) == utils.ErrorStatusNotFound || (err == nil && event == nil) {
+		// expired since it was stored
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("error finding the changed matching event %s: %v", change.DocumentID, err)
+		return
+	}
+	if event.InstanceID == a.instanceID {
+		// already published when sent
+		return
+	}
+	a.publishMatchingEvent(*event)
+}
+
+// onMatchResultChanged publishes the completed event of a saved match result, whichever instance of the service saved it
+func (a *Application) onMatchResultChanged(change model.DataChange) {
+	if change.Operation == model.DataChangeDelete || !a.hasMatchingSubscriptions(change.DocumentID) {
+		return
+	}
+
+	result, err := a.storage.GetUserMatchingResult(change.DocumentID)
+	if errors.Status(err) == utils.ErrorStatusNotFound || (err == nil && result == nil) {
+		// deleted since it changed
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("error finding the changed match result %s: %v", change.DocumentID, err)
+		return
+	}
+
+	summary := matchingSummary(*result)
+	a.publishMatchingEvent(model.MatchingEvent{Type: model.MatchingEventCompleted, AccountID: result.ID, Summary: &summary, Date: change.Date})
+}
+
+// closeMatchingSubscriptions unsubscribes all the subscribers, ending their streams
+func (a *Application) closeMatchingSubscriptions() {
+	a.matchingSubscriptionsLock.Lock()
+	defer a.matchingSubscriptionsLock.Unlock()
+
+	for accountID, subscriptions := range a.matchingSubscriptions {
+		for _, subscription := range subscriptions {
+			close(subscription.events)
+		}
+		delete(a.matchingSubscriptions, accountID)
+	}
+}
+
+// matchingSummary summarizes a match result with its best matches, the matches being sorted by decreasing match percent
+func matchingSummary(result model.UserMatchingResult) model.MatchingSummary {
+	topMatches := result.Matches
+	if len(topMatches) > matchingSummaryTopMatches {
+		topMatches = topMatches[:matchingSummaryTopMatches]
+	}
+	return model.MatchingSummary{Version: result.Version, Revision: result.Revision, MatchesCount: len(result.Matches),
+		TopMatches: append([]model.Match{}, topMatches...)}
+}
+
+// newMatchingEvent creates a started or failed matching event of a survey sent by this instance of the service
+func (a *Application) newMatchingEvent(eventType string, accountID string, surveyID string) model.MatchingEvent {
+	now := time.Now().UTC()
+	return model.MatchingEvent{ID: uuid.NewString(), Type: eventType, AccountID: accountID, SurveyID: surveyID, Date: now,
+		InstanceID: a.instanceID, DateExpires: now.Add(model.MatchingEventExpiry)}
+}
@@ -43,6 +43,7 @@ type Config struct {
 	Data        interface{} `json:"data" bson:"data"`
 	DateCreated time.Time   `json:"date_created" bson:"date_created"`
 	DateUpdated *time.Time  `json:"date_updated" bson:"date_updated"`
+	Revision    int64       `json:"revision" bson:"revision"`
 }
 
 // EnvConfigData contains environment configs for this service
@@ -60,5 +61,5 @@ func GetConfigData[T ConfigData](c Config) (*T, error) {
 
 // ConfigData represents any set of data that may be stored in a config
 type ConfigData interface {
-	EnvConfigData | map[string]interface{}
+	EnvConfigData | RetentionConfigData | RateLimitConfigData | map[string]interface{}
 }
@@ -0,0 +1,51 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	//TypeCrosswalk type
+	TypeCrosswalk logutils.MessageDataType = "crosswalk"
+	//TypeCrosswalkCode type
+	TypeCrosswalkCode logutils.MessageDataType = "crosswalk code"
+
+	// CrosswalkSystemSOC2018 is the 2018 Standard Occupational Classification system
+	CrosswalkSystemSOC2018 string = "soc2018"
+	// CrosswalkSystemISCO08 is the International Standard Classification of Occupations 2008 system
+	CrosswalkSystemISCO08 string = "isco08"
+	// CrosswalkSystemESCO is the European Skills, Competences, Qualifications and Occupations system
+	CrosswalkSystemESCO string = "esco"
+	// CrosswalkSystemCIP is the Classification of Instructional Programs system
+	CrosswalkSystemCIP string = "cip"
+)
+
+// CrosswalkSystems lists the classification systems supported by the crosswalks
+var CrosswalkSystems = []string{CrosswalkSystemSOC2018, CrosswalkSystemISCO08, CrosswalkSystemESCO, CrosswalkSystemCIP}
+
+// CrosswalkCode represents a code in another classification system equivalent to an ONET-SOC occupation code
+type CrosswalkCode struct {
+	System string `json:"system" bson:"system"`
+	Code   string `json:"code" bson:"code"`
+	Title  string `json:"title" bson:"title"`
+}
+
+// CrosswalkEntry represents a single row of a crosswalk table
+type CrosswalkEntry struct {
+	OccupationCode string `json:"occupation_code" bson:"occupation_code"`
+	CrosswalkCode
+}
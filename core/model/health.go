@@ -0,0 +1,64 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// TypeHealth health type
+	TypeHealth logutils.MessageDataType = "health"
+
+	// HealthStatusPass is the status of a healthy service or check
+	HealthStatusPass string = "pass"
+	// HealthStatusWarn is the status of a service or check that is healthy with concerns
+	HealthStatusWarn string = "warn"
+	// HealthStatusFail is the status of an unhealthy service or check
+	HealthStatusFail string = "fail"
+)
+
+// Health represents the health of the service and of the dependencies it checked, following the health check response format for HTTP APIs draft
+type Health struct {
+	Status  string                   `json:"status"`
+	Version string                   `json:"version"`
+	Checks  map[string][]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck represents the result of checking a dependency of the service
+type HealthCheck struct {
+	ComponentID   string      `json:"component_id,omitempty"`
+	Status        string      `json:"status"`
+	ObservedValue interface{} `json:"observed_value,omitempty"`
+	ObservedUnit  string      `json:"observed_unit,omitempty"`
+	Time          *time.Time  `json:"time,omitempty"`
+	Output        string      `json:"output,omitempty"`
+}
+
+// AddChecks adds the results of the checks with the given name, failing or warning the service health when any check does
+func (h *Health) AddChecks(name string, checks ...HealthCheck) {
+	if h.Checks == nil {
+		h.Checks = map[string][]HealthCheck{}
+	}
+	h.Checks[name] = append(h.Checks[name], checks...)
+
+	for _, check := range checks {
+		if check.Status == HealthStatusFail || (check.Status == HealthStatusWarn && h.Status == HealthStatusPass) {
+			h.Status = check.Status
+		}
+	}
+}
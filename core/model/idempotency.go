@@ -0,0 +1,42 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// TypeIdempotencyKey type
+	TypeIdempotencyKey logutils.MessageDataType = "idempotency key"
+
+	// CollectionIdempotencyKeys is the name of the idempotency keys collection
+	CollectionIdempotencyKeys string = "idempotency_keys"
+)
+
+// IdempotencyKey records a request sent with an Idempotency-Key header, so that its retries are given the outcome of the first request
+//
+//	ResourceID is empty while the first request is in progress, and is then set to the ID of the resource it created.
+//	RequestHash identifies the request data, so that a key reused for a different request is rejected.
+type IdempotencyKey struct {
+	AccountID   string    `json:"account_id" bson:"account_id"`
+	Key         string    `json:"key" bson:"key"`
+	RequestHash string    `json:"request_hash" bson:"request_hash"`
+	ResourceID  string    `json:"resource_id" bson:"resource_id"`
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+	DateExpires time.Time `json:"date_expires" bson:"date_expires"`
+}
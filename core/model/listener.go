@@ -14,8 +14,42 @@
 
 package model
 
+import "time"
+
+const (
+	// DataChangeInsert is the operation of a DataChange inserting a document
+	DataChangeInsert string = "insert"
+	// DataChangeUpdate is the operation of a DataChange updating or replacing a document
+	DataChangeUpdate string = "update"
+	// DataChangeDelete is the operation of a DataChange deleting a document
+	DataChangeDelete string = "delete"
+
+	// CollectionConfigs is the name of the configs collection
+	CollectionConfigs string = "configs"
+	// CollectionOccupationData is the name of the occupation data collection
+	CollectionOccupationData string = "occupation_data"
+	// CollectionSurveyResponses is the name of the survey responses collection
+	CollectionSurveyResponses string = "survey_responses"
+	// CollectionMatchResults is the name of the match results collection
+	CollectionMatchResults string = "match_results"
+)
+
+// DataChange represents a change to a stored document
+type DataChange struct {
+	Collection string
+	Operation  string
+	DocumentID string
+	Date       time.Time
+}
+
 // DefaultStorageListener default storage listener implementation
 type DefaultStorageListener struct{}
 
 // OnConfigsUpdated notifies that the configs collection has been updated
 func (d *DefaultStorageListener) OnConfigsUpdated() {}
+
+// OnOccupationDataUpdated notifies that the occupation data collection has been updated
+func (d *DefaultStorageListener) OnOccupationDataUpdated() {}
+
+// OnDataChanged notifies that a document has been inserted, updated or deleted
+func (d *DefaultStorageListener) OnDataChanged(change DataChange) {}
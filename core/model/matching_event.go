@@ -0,0 +1,61 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// TypeMatchingEvent type
+	TypeMatchingEvent logutils.MessageDataType = "matching event"
+
+	// CollectionMatchingEvents is the name of the matching events collection
+	CollectionMatchingEvents string = "matching_events"
+	// MatchingEventExpiry is how long the started and failed events are stored for the other instances of the service to send them
+	MatchingEventExpiry time.Duration = 10 * time.Minute
+
+	// MatchingEventStarted is the type of the event sent when the occupation matching of a survey starts
+	MatchingEventStarted string = "matching.started"
+	// MatchingEventCompleted is the type of the event sent when a new match result is saved
+	MatchingEventCompleted string = "matching.completed"
+	// MatchingEventFailed is the type of the event sent when the occupation matching of a survey fails
+	MatchingEventFailed string = "matching.failed"
+)
+
+// MatchingEvent represents a change to the state of the occupation matching of an account
+//
+//	SurveyID is only set for the started and failed events, and Summary for the completed events.
+//	The started and failed events are stored with the ID of the instance which sent them, until they expire.
+type MatchingEvent struct {
+	ID          string           `json:"-" bson:"_id"`
+	Type        string           `json:"type" bson:"type"`
+	AccountID   string           `json:"account_id" bson:"account_id"`
+	SurveyID    string           `json:"survey_id,omitempty" bson:"survey_id,omitempty"`
+	Summary     *MatchingSummary `json:"summary,omitempty" bson:"summary,omitempty"`
+	Date        time.Time        `json:"date" bson:"date"`
+	InstanceID  string           `json:"-" bson:"instance_id"`
+	DateExpires time.Time        `json:"-" bson:"date_expires"`
+}
+
+// MatchingSummary summarizes a saved match result with its best matches
+type MatchingSummary struct {
+	Version      string  `json:"version"`
+	Revision     int64   `json:"revision"`
+	MatchesCount int     `json:"matches_count"`
+	TopMatches   []Match `json:"top_matches"`
+}
@@ -15,6 +15,9 @@
 package model
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/rokwire/logging-library-go/v2/logutils"
 )
 
@@ -25,15 +28,52 @@ const (
 	TypeTechnologySkill logutils.MessageDataType = "technology skill"
 	//TypeWorkstyle type
 	TypeWorkstyle logutils.MessageDataType = "workstyle"
+	//TypeRelatedOccupation type
+	TypeRelatedOccupation logutils.MessageDataType = "related occupation"
+
+	// MaxJobZone is the highest ONET job zone
+	MaxJobZone int = 5
 )
 
+// OccupationCodeRegex matches ONET-SOC occupation codes (eg. 15-1252.00)
+var OccupationCodeRegex = regexp.MustCompile(`^\d{2}-\d{4}\.\d{2}$`)
+
+// OnetWorkstyles lists the names of the ONET work styles
+var OnetWorkstyles = []string{
+	"Achievement/Effort",
+	"Persistence",
+	"Initiative",
+	"Leadership",
+	"Cooperation",
+	"Concern for Others",
+	"Social Orientation",
+	"Self-Control",
+	"Stress Tolerance",
+	"Adaptability/Flexibility",
+	"Dependability",
+	"Attention to Detail",
+	"Integrity",
+	"Independence",
+	"Innovation",
+	"Analytical Thinking",
+}
+
+// WorkstyleScales maps the ONET work style scales to their minimum and maximum values
+var WorkstyleScales = map[string][2]float64{
+	"Importance": {0, 100},
+	"IM":         {1, 5},
+}
+
 // OccupationData stores the relevant information about each Occupation from ONET
 type OccupationData struct {
-	Code             string            `json:"code" bson:"code"`
-	Name             string            `json:"name" bson:"name"`
-	Description      string            `json:"description" bson:"description"`
-	TechnologySkills []TechnologySkill `json:"technology_skills" bson:"technology_skills"`
-	Workstyles       []Workstyle       `json:"work_styles" bson:"work_styles"`
+	Code               string              `json:"code" bson:"code"`
+	Name               string              `json:"name" bson:"name"`
+	Description        string              `json:"description" bson:"description"`
+	TechnologySkills   []TechnologySkill   `json:"technology_skills" bson:"technology_skills"`
+	Workstyles         []Workstyle         `json:"work_styles" bson:"work_styles"`
+	JobZone            int                 `json:"job_zone" bson:"job_zone"`
+	RelatedOccupations []RelatedOccupation `json:"related_occupations" bson:"related_occupations"`
+	DateUpdated        *time.Time          `json:"date_updated" bson:"date_updated"`
 }
 
 // TechnologySkill stores the relevant information about each Technology Skill for an occupation
@@ -51,3 +91,10 @@ type Workstyle struct {
 	Scale       string  `json:"scale" bson:"scale"`
 	Value       float64 `json:"value" bson:"value"`
 }
+
+// RelatedOccupation stores an occupation listed by ONET as related to another occupation
+type RelatedOccupation struct {
+	Code  string `json:"code" bson:"code"`
+	Name  string `json:"name" bson:"name"`
+	Index int    `json:"index" bson:"index"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	//TypeOccupationNeighbor type
+	TypeOccupationNeighbor logutils.MessageDataType = "occupation neighbor"
+	//TypeOccupationPathway type
+	TypeOccupationPathway logutils.MessageDataType = "occupation pathway"
+
+	// MaxOccupationPathways is the largest number of pathways found between two occupations, as each pathway takes several shortest path searches
+	MaxOccupationPathways int = 10
+)
+
+// OccupationNeighbor represents an occupation adjacent to another occupation in the related occupations graph
+type OccupationNeighbor struct {
+	Occupation OccupationMatch `json:"occupation"`
+	JobZone    int             `json:"job_zone"`
+	Related    bool            `json:"related"`
+	Similarity float64         `json:"similarity"`
+	Score      float64         `json:"score"`
+}
+
+// OccupationPathway represents a sequence of occupations leading from one occupation to another
+type OccupationPathway struct {
+	Steps []OccupationPathwayStep `json:"steps"`
+	Cost  float64                 `json:"cost"`
+}
+
+// OccupationPathwayStep represents a single occupation on an OccupationPathway
+type OccupationPathwayStep struct {
+	Occupation OccupationMatch `json:"occupation"`
+	JobZone    int             `json:"job_zone"`
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	//TypeOccupationDataReport type
+	TypeOccupationDataReport logutils.MessageDataType = "occupation data report"
+)
+
+// OccupationDataReport describes the quality and coverage of the occupation dataset used for matching
+type OccupationDataReport struct {
+	Statistics                OccupationDataStatistics    `json:"statistics"`
+	ExcludedOccupations       []OccupationMatch           `json:"excluded_occupations"`
+	MissingWorkstyles         []OccupationWorkstylesIssue `json:"missing_workstyles"`
+	UnknownWorkstyles         []OccupationWorkstylesIssue `json:"unknown_workstyles"`
+	DuplicateCodes            []string                    `json:"duplicate_codes"`
+	MalformedCodes            []string                    `json:"malformed_codes"`
+	InvalidRelatedOccupations []OccupationMatch           `json:"invalid_related_occupations"`
+	DateGenerated             time.Time                   `json:"date_generated"`
+}
+
+// OccupationDataStatistics contains dataset-level statistics about the occupation data
+type OccupationDataStatistics struct {
+	TotalOccupations          int            `json:"total_occupations"`
+	MatchableOccupations      int            `json:"matchable_occupations"`
+	FullyMappedOccupations    int            `json:"fully_mapped_occupations"`
+	MissingDescriptions       int            `json:"missing_descriptions"`
+	MissingJobZones           int            `json:"missing_job_zones"`
+	AverageWorkstyles         float64        `json:"average_workstyles"`
+	AverageTechnologySkills   float64        `json:"average_technology_skills"`
+	AverageRelatedOccupations float64        `json:"average_related_occupations"`
+	WorkstyleCoverage         map[string]int `json:"workstyle_coverage"`
+	JobZones                  map[string]int `json:"job_zones"`
+}
+
+// OccupationWorkstylesIssue lists the work styles affected by an issue for an occupation
+type OccupationWorkstylesIssue struct {
+	Occupation OccupationMatch `json:"occupation"`
+	Workstyles []string        `json:"workstyles"`
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	//TypeRateLimitConfigData type
+	TypeRateLimitConfigData logutils.MessageDataType = "rate limit config data"
+	//TypeRateLimit type
+	TypeRateLimit logutils.MessageDataType = "rate limit"
+
+	// ConfigTypeRateLimit is the Config Type for RateLimitConfigData
+	ConfigTypeRateLimit string = "rate_limit"
+)
+
+// DefaultRateLimits are the limits used when there is no rate limit config
+var DefaultRateLimits = []RateLimit{
+	{Route: "POST /api/survey-data", Requests: 10, PeriodSeconds: 60},
+}
+
+// RateLimitConfigData defines the number of requests each account may make to the limited routes
+type RateLimitConfigData struct {
+	Limits []RateLimit `json:"limits" bson:"limits"`
+}
+
+// RateLimit allows bursts of up to Requests requests to a route, refilled evenly over PeriodSeconds
+//
+//	Route is the HTTP method and path template of the route, as in the API docs (eg. "POST /api/survey-data")
+type RateLimit struct {
+	Route         string `json:"route" bson:"route"`
+	Requests      int    `json:"requests" bson:"requests"`
+	PeriodSeconds int    `json:"period_seconds" bson:"period_seconds"`
+}
+
+// Period returns the period over which the requests of the limit are refilled
+func (r RateLimit) Period() time.Duration {
+	return time.Duration(r.PeriodSeconds) * time.Second
+}
+
+// RateLimitStatus describes the requests left to a client after taking one from its limit
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Allowed   bool
+	// Reset is the time until the limit is fully refilled
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, when this one was not
+	RetryAfter time.Duration
+}
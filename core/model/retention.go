@@ -0,0 +1,77 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	//TypeRetentionConfigData type
+	TypeRetentionConfigData logutils.MessageDataType = "retention config data"
+	//TypeRetentionPolicy type
+	TypeRetentionPolicy logutils.MessageDataType = "retention policy"
+	//TypeRetentionReport type
+	TypeRetentionReport logutils.MessageDataType = "retention report"
+
+	// ConfigTypeRetention is the Config Type for RetentionConfigData
+	ConfigTypeRetention string = "retention"
+
+	// RetentionCollectionSurveyResponses is the collection storing SurveyData
+	RetentionCollectionSurveyResponses string = "survey_responses"
+	// RetentionCollectionMatchResults is the collection storing UserMatchingResults
+	RetentionCollectionMatchResults string = "match_results"
+	// RetentionCollectionMatchResultsHistory is the collection storing UserMatchingResultHistories
+	RetentionCollectionMatchResultsHistory string = "match_results_history"
+
+	// DefaultRetentionPurgeIntervalHours is the purge interval used when the retention config does not set one
+	DefaultRetentionPurgeIntervalHours int = 24
+)
+
+// RetentionCollections lists the collections which support retention policies
+var RetentionCollections = []string{RetentionCollectionSurveyResponses, RetentionCollectionMatchResults, RetentionCollectionMatchResultsHistory}
+
+// RetentionConfigData defines how long data is kept in each collection
+type RetentionConfigData struct {
+	PurgeIntervalHours int               `json:"purge_interval_hours" bson:"purge_interval_hours"`
+	Policies           []RetentionPolicy `json:"policies" bson:"policies"`
+}
+
+// RetentionPolicy defines the number of days documents in a collection are kept after they were last updated
+type RetentionPolicy struct {
+	Collection    string `json:"collection" bson:"collection"`
+	RetentionDays int    `json:"retention_days" bson:"retention_days"`
+}
+
+// RetentionReport describes the data that will be purged by the retention policies and when
+type RetentionReport struct {
+	Policies           []RetentionPolicyReport `json:"policies"`
+	PurgeIntervalHours int                     `json:"purge_interval_hours"`
+	LastPurge          *time.Time              `json:"last_purge"`
+	NextPurge          time.Time               `json:"next_purge"`
+	DateGenerated      time.Time               `json:"date_generated"`
+}
+
+// RetentionPolicyReport describes the data that will be purged from a collection
+type RetentionPolicyReport struct {
+	Collection      string     `json:"collection"`
+	RetentionDays   int        `json:"retention_days"`
+	Cutoff          time.Time  `json:"cutoff"`
+	ExpiredCount    int64      `json:"expired_count"`
+	NextExpiry      *time.Time `json:"next_expiry"`
+	LastPurgedCount int64      `json:"last_purged_count"`
+}
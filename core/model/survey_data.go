@@ -25,15 +25,57 @@ const (
 	TypeSurveyData logutils.MessageDataType = "survey data"
 	// TypeWorkstyleScore type
 	TypeWorkstyleScore logutils.MessageDataType = "workstyle score"
+
+	// SurveyVersionBESSI3 is the version of the BESSI survey with 16 skill facets
+	SurveyVersionBESSI3 string = "v3.0"
+	// CurrentSurveyVersion is the survey version assumed when a submission does not declare one
+	CurrentSurveyVersion string = SurveyVersionBESSI3
 )
 
+// SurveyInstrument defines the skills scored by a version of the BESSI survey and the range of their scores
+type SurveyInstrument struct {
+	Version  string
+	Skills   []string
+	MinScore int
+	MaxScore int
+}
+
+// SurveyInstruments maps the supported survey versions to their instrument definitions
+var SurveyInstruments = map[string]SurveyInstrument{
+	SurveyVersionBESSI3: {
+		Version: SurveyVersionBESSI3,
+		Skills: []string{
+			"abstract_thinking",
+			"adaptability",
+			"anger_management",
+			"capacity_consistency",
+			"capacity_independence",
+			"capacity_social_warmth",
+			"creativity",
+			"detail_management",
+			"ethical_competence",
+			"goal_regulation",
+			"initiative",
+			"leadership",
+			"perspective_taking",
+			"responsibility_management",
+			"stress_regulation",
+			"teamwork",
+		},
+		MinScore: 0,
+		MaxScore: 100,
+	},
+}
+
 // SurveyData represents the survey results from the BESSI Survey
 type SurveyData struct {
 	ID          string           `json:"id" bson:"_id"`
+	AccountID   string           `json:"account_id" bson:"account_id"`
 	Version     string           `json:"version" bson:"version"`
 	Scores      []WorkstyleScore `json:"scores" bson:"scores"`
 	DateCreated time.Time        `json:"date_created" bson:"date_created"`
 	DateUpdated *time.Time       `json:"date_updated" bson:"date_updated"`
+	Revision    int64            `json:"revision" bson:"revision"`
 }
 
 // WorkstyleScore represents the score for each workstyle
@@ -0,0 +1,106 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// TypeUserDataSearch type
+	TypeUserDataSearch logutils.MessageDataType = "user data search"
+	// TypeUserDataDeletion type
+	TypeUserDataDeletion logutils.MessageDataType = "user data deletion"
+	// TypeUserDataExport type
+	TypeUserDataExport logutils.MessageDataType = "user data export"
+	// TypeUserDataArchive type
+	TypeUserDataArchive logutils.MessageDataType = "user data archive"
+
+	// CollectionUserDataExports is the name of the user data exports collection
+	CollectionUserDataExports string = "user_data_exports"
+
+	// DefaultUserDataSearchLimit is the number of results returned by a user data search which does not set a limit
+	DefaultUserDataSearchLimit int = 50
+	// MaxUserDataSearchLimit is the largest number of results returned by a user data search
+	MaxUserDataSearchLimit int = 500
+
+	// UserDataExportFormatJSON exports the user data as a single JSON document
+	UserDataExportFormatJSON string = "json"
+	// UserDataExportFormatCSV exports the user data as a ZIP archive of CSV files
+	UserDataExportFormatCSV string = "csv"
+
+	// UserDataExportStatusPending is the status of an export being generated
+	UserDataExportStatusPending string = "pending"
+	// UserDataExportStatusReady is the status of an export which can be downloaded
+	UserDataExportStatusReady string = "ready"
+	// UserDataExportStatusFailed is the status of an export which could not be generated
+	UserDataExportStatusFailed string = "failed"
+
+	// UserDataExportSyncLimit is the largest number of survey data exported while the user waits, larger histories being exported in the background
+	UserDataExportSyncLimit int = 50
+	// UserDataExportExpiry is how long the download link of an export is valid, after which the export is deleted
+	UserDataExportExpiry time.Duration = 15 * time.Minute
+)
+
+// UserDataExportFormats are the formats the user data can be exported in
+var UserDataExportFormats = []string{UserDataExportFormatJSON, UserDataExportFormatCSV}
+
+// UserDataSearch filters the survey data and match results of the users, the unset fields not filtering them
+//
+//	From and To bound the creation date of the survey data, and the date match results were last updated.
+type UserDataSearch struct {
+	AccountID *string
+	Version   *string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// UserDataDeletion reports the number of documents deleted with the data of a user
+type UserDataDeletion struct {
+	AccountID           string `json:"account_id"`
+	SurveyDatas         int64  `json:"survey_data"`
+	MatchResults        int64  `json:"match_results"`
+	MatchResultsHistory int64  `json:"match_results_history"`
+	IdempotencyKeys     int64  `json:"idempotency_keys"`
+	UserDataExports     int64  `json:"user_data_exports"`
+}
+
+// UserDataArchive contains all the data stored about a user, as exported to them
+//
+//	The match results are the history of every match result saved for the user, from the oldest. No preferences of the user are stored.
+type UserDataArchive struct {
+	AccountID    string                      `json:"account_id"`
+	DateExported time.Time                   `json:"date_exported"`
+	SurveyDatas  []SurveyData                `json:"survey_data"`
+	MatchResults []UserMatchingResultHistory `json:"match_results"`
+}
+
+// UserDataExport is an export of the data of a user, downloaded with its token until it expires
+//
+//	Data is the exported archive once the export is ready, and is never returned with the export status.
+type UserDataExport struct {
+	ID          string    `json:"id" bson:"_id"`
+	AccountID   string    `json:"account_id" bson:"account_id"`
+	Format      string    `json:"format" bson:"format"`
+	Status      string    `json:"status" bson:"status"`
+	Token       string    `json:"-" bson:"token"`
+	Data        []byte    `json:"-" bson:"data"`
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
+	DateExpires time.Time `json:"date_expires" bson:"date_expires"`
+}
@@ -23,10 +23,15 @@ import (
 const (
 	//TypeUserMatchingResult type
 	TypeUserMatchingResult logutils.MessageDataType = "user matching result"
+	//TypeUserMatchingResultHistory type
+	TypeUserMatchingResultHistory logutils.MessageDataType = "user matching result history"
 	//TypeMatch type
 	TypeMatch logutils.MessageDataType = "match"
 	//TypeOccupationMatch type
 	TypeOccupationMatch logutils.MessageDataType = "occupation match"
+
+	// CollectionMatchResultsHistory is the name of the collection keeping every match result saved for the users
+	CollectionMatchResultsHistory string = "match_results_history"
 )
 
 // UserMatchingResult represents the matching results of a specific user
@@ -36,6 +41,16 @@ type UserMatchingResult struct {
 	Matches     []Match    `json:"matches" bson:"matches"`
 	DateCreated time.Time  `json:"date_created" bson:"date_created"`
 	DateUpdated *time.Time `json:"date_updated" bson:"date_updated"`
+	Revision    int64      `json:"revision" bson:"revision"`
+}
+
+// UserMatchingResultHistory is a match result of a user as it was saved, kept in the history of their match results
+type UserMatchingResultHistory struct {
+	ID          string    `json:"id" bson:"_id"`
+	AccountID   string    `json:"account_id" bson:"account_id"`
+	Version     string    `json:"version" bson:"version"`
+	Matches     []Match   `json:"matches" bson:"matches"`
+	DateCreated time.Time `json:"date_created" bson:"date_created"`
 }
 
 // Match represents a occupation match and the corresponding score
@@ -46,6 +61,7 @@ type Match struct {
 
 // OccupationMatch stores the relevant information about each Occupation match
 type OccupationMatch struct {
-	Code string `json:"code" bson:"code"`
-	Name string `json:"name" bson:"name"`
+	Code       string          `json:"code" bson:"code"`
+	Name       string          `json:"name" bson:"name"`
+	Crosswalks []CrosswalkCode `json:"crosswalks,omitempty" bson:"-"`
 }
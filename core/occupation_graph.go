@@ -0,0 +1,393 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"container/heap"
+	"math"
+	"sort"
+)
+
+const (
+	// number of most similar occupations linked to each occupation in addition to the ONET related occupations
+	graphSimilarNeighbors int = 10
+	// minimum profile similarity for two occupations to be linked by similarity alone
+	graphMinSimilarity float64 = 0.6
+	// share of the edge score given to ONET relatedness, the rest is given to profile similarity
+	graphRelatedWeight float64 = 0.5
+	// share of the profile similarity given to workstyles, the rest is given to technology skills
+	graphWorkstyleWeight float64 = 0.7
+
+	// fixed cost of every step on a pathway so that shorter pathways are preferred
+	pathwayStepCost float64 = 0.1
+	// cost of a step between occupations in the same job zone
+	pathwaySameZoneCost float64 = 0.1
+	// cost of every job zone skipped when moving up
+	pathwayZoneSkipCost float64 = 0.5
+	// cost of every job zone lost when moving down
+	pathwayZoneDropCost float64 = 0.3
+	// cost of a step when the job zone of either occupation is unknown
+	pathwayUnknownZoneCost float64 = 0.25
+	// maximum number of occupations on a pathway
+	pathwayMaxSteps int = 8
+)
+
+// occupationGraph is a weighted graph of occupations built from the ONET related occupations and profile similarity
+type occupationGraph struct {
+	nodes map[string]*occupationNode
+}
+
+type occupationNode struct {
+	occupation model.OccupationData
+	edges      map[string]occupationEdge
+}
+
+type occupationEdge struct {
+	to         string
+	related    bool
+	similarity float64
+	score      float64
+}
+
+type occupationProfile struct {
+	workstyles     map[string]float64
+	workstylesNorm float64
+	skills         map[int]bool
+}
+
+type graphPath struct {
+	nodes []string
+	cost  float64
+}
+
+// newOccupationGraph builds the related occupations graph for the given occupations
+func newOccupationGraph(occupations []model.OccupationData) *occupationGraph {
+	g := occupationGraph{nodes: make(map[string]*occupationNode, len(occupations))}
+	profiles := make(map[string]occupationProfile, len(occupations))
+	codes := make([]string, 0, len(occupations))
+	for _, occupation := range occupations {
+		if _, exists := g.nodes[occupation.Code]; exists {
+			continue
+		}
+		g.nodes[occupation.Code] = &occupationNode{occupation: occupation, edges: map[string]occupationEdge{}}
+		profiles[occupation.Code] = newOccupationProfile(occupation)
+		codes = append(codes, occupation.Code)
+	}
+	sort.Strings(codes)
+
+	// link the most similar occupations
+	for _, from := range codes {
+		similar := make([]occupationEdge, 0)
+		for _, to := range codes {
+			if from == to {
+				continue
+			}
+			similarity := profileSimilarity(profiles[from], profiles[to])
+			if similarity >= graphMinSimilarity {
+				similar = append(similar, occupationEdge{to: to, similarity: similarity})
+			}
+		}
+		sort.SliceStable(similar, func(i, j int) bool {
+			return similar[i].similarity > similar[j].similarity
+		})
+		if len(similar) > graphSimilarNeighbors {
+			similar = similar[:graphSimilarNeighbors]
+		}
+		for _, edge := range similar {
+			edge.score = (1 - graphRelatedWeight) * edge.similarity
+			g.nodes[from].edges[edge.to] = edge
+		}
+	}
+
+	// link the ONET related occupations
+	for _, from := range codes {
+		node := g.nodes[from]
+		for _, related := range node.occupation.RelatedOccupations {
+			if related.Code == from || g.nodes[related.Code] == nil {
+				continue
+			}
+			similarity := profileSimilarity(profiles[from], profiles[related.Code])
+			relatedness := math.Max(0.5, 1-0.02*float64(related.Index-1))
+			node.edges[related.Code] = occupationEdge{to: related.Code, related: true, similarity: similarity,
+				score: graphRelatedWeight*relatedness + (1-graphRelatedWeight)*similarity}
+		}
+	}
+
+	return &g
+}
+
+// neighbors returns the occupations adjacent to the occupation with the given code ordered by score
+//
+//	Returns nil if the occupation is not in the graph
+func (g *occupationGraph) neighbors(code string, limit int) []model.OccupationNeighbor {
+	node := g.nodes[code]
+	if node == nil {
+		return nil
+	}
+
+	neighbors := make([]model.OccupationNeighbor, 0, len(node.edges))
+	for _, edge := range node.edges {
+		to := g.nodes[edge.to].occupation
+		neighbors = append(neighbors, model.OccupationNeighbor{Occupation: model.OccupationMatch{Code: to.Code, Name: to.Name},
+			JobZone: to.JobZone, Related: edge.related, Similarity: edge.similarity, Score: edge.score})
+	}
+	sort.SliceStable(neighbors, func(i, j int) bool {
+		if neighbors[i].Score != neighbors[j].Score {
+			return neighbors[i].Score > neighbors[j].Score
+		}
+		return neighbors[i].Occupation.Code < neighbors[j].Occupation.Code
+	})
+	if limit > 0 && len(neighbors) > limit {
+		neighbors = neighbors[:limit]
+	}
+	return neighbors
+}
+
+// pathways returns up to limit of the lowest cost pathways between two occupations
+//
+//	Returns nil if either occupation is not in the graph
+func (g *occupationGraph) pathways(from string, to string, limit int) []model.OccupationPathway {
+	if g.nodes[from] == nil || g.nodes[to] == nil {
+		return nil
+	}
+
+	paths := g.kShortestPaths(from, to, limit)
+	pathways := make([]model.OccupationPathway, len(paths))
+	for i, path := range paths {
+		steps := make([]model.OccupationPathwayStep, len(path.nodes))
+		for j, code := range path.nodes {
+			occupation := g.nodes[code].occupation
+			steps[j] = model.OccupationPathwayStep{Occupation: model.OccupationMatch{Code: occupation.Code, Name: occupation.Name}, JobZone: occupation.JobZone}
+		}
+		pathways[i] = model.OccupationPathway{Steps: steps, Cost: path.cost}
+	}
+	return pathways
+}
+
+// edgeCost returns the cost of moving along an edge, favoring strong links and a gradual job zone progression
+func (g *occupationGraph) edgeCost(from string, edge occupationEdge) float64 {
+	cost := pathwayStepCost + (1 - edge.score)
+
+	fromZone := g.nodes[from].occupation.JobZone
+	toZone := g.nodes[edge.to].occupation.JobZone
+	if fromZone <= 0 || toZone <= 0 {
+		return cost + pathwayUnknownZoneCost
+	}
+
+	switch delta := toZone - fromZone; {
+	case delta == 0:
+		cost += pathwaySameZoneCost
+	case delta > 1:
+		cost += pathwayZoneSkipCost * float64(delta-1)
+	case delta < 0:
+		cost += pathwayZoneDropCost * float64(-delta)
+	}
+	return cost
+}
+
+// kShortestPaths finds up to k loopless pathways between two occupations using Yen's algorithm
+func (g *occupationGraph) kShortestPaths(from string, to string, k int) []graphPath {
+	if k <= 0 {
+		k = 1
+	}
+
+	first, ok := g.shortestPath(from, to, nil, nil)
+	if !ok {
+		return []graphPath{}
+	}
+	paths := []graphPath{first}
+	candidates := make([]graphPath, 0)
+	seen := map[string]bool{pathKey(first.nodes): true}
+
+	for len(paths) < k {
+		last := paths[len(paths)-1]
+		for i := 0; i < len(last.nodes)-1; i++ {
+			spur := last.nodes[i]
+			root := last.nodes[:i+1]
+
+			removedEdges := map[[2]string]bool{}
+			for _, path := range paths {
+				if len(path.nodes) > i && pathKey(path.nodes[:i+1]) == pathKey(root) {
+					removedEdges[[2]string{path.nodes[i], path.nodes[i+1]}] = true
+				}
+			}
+			removedNodes := map[string]bool{}
+			for _, code := range root[:len(root)-1] {
+				removedNodes[code] = true
+			}
+
+			spurPath, ok := g.shortestPath(spur, to, removedNodes, removedEdges)
+			if !ok {
+				continue
+			}
+
+			nodes := append(append([]string{}, root[:len(root)-1]...), spurPath.nodes...)
+			if len(nodes) > pathwayMaxSteps || seen[pathKey(nodes)] {
+				continue
+			}
+			seen[pathKey(nodes)] = true
+			candidates = append(candidates, graphPath{nodes: nodes, cost: g.pathCost(nodes)})
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].cost < candidates[j].cost
+		})
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return paths
+}
+
+// shortestPath finds the lowest cost pathway between two occupations using Dijkstra's algorithm
+func (g *occupationGraph) shortestPath(from string, to string, removedNodes map[string]bool, removedEdges map[[2]string]bool) (graphPath, bool) {
+	costs := map[string]float64{from: 0}
+	previous := map[string]string{}
+	steps := map[string]int{from: 1}
+	visited := map[string]bool{}
+
+	queue := &pathQueue{{code: from}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathQueueItem)
+		if visited[item.code] {
+			continue
+		}
+		visited[item.code] = true
+		if item.code == to {
+			break
+		}
+		if steps[item.code] >= pathwayMaxSteps {
+			continue
+		}
+
+		for _, edge := range g.nodes[item.code].edges {
+			if visited[edge.to] || removedNodes[edge.to] || removedEdges[[2]string{item.code, edge.to}] {
+				continue
+			}
+			cost := item.cost + g.edgeCost(item.code, edge)
+			if current, ok := costs[edge.to]; !ok || cost < current {
+				costs[edge.to] = cost
+				previous[edge.to] = item.code
+				steps[edge.to] = steps[item.code] + 1
+				heap.Push(queue, pathQueueItem{code: edge.to, cost: cost})
+			}
+		}
+	}
+
+	if !visited[to] {
+		return graphPath{}, false
+	}
+
+	nodes := []string{to}
+	for code := to; code != from; {
+		code = previous[code]
+		nodes = append([]string{code}, nodes...)
+	}
+	return graphPath{nodes: nodes, cost: costs[to]}, true
+}
+
+func (g *occupationGraph) pathCost(nodes []string) float64 {
+	cost := 0.0
+	for i := 0; i < len(nodes)-1; i++ {
+		cost += g.edgeCost(nodes[i], g.nodes[nodes[i]].edges[nodes[i+1]])
+	}
+	return cost
+}
+
+func newOccupationProfile(occupation model.OccupationData) occupationProfile {
+	profile := occupationProfile{workstyles: map[string]float64{}, skills: map[int]bool{}}
+	for _, workstyle := range occupation.Workstyles {
+		profile.workstyles[workstyle.Name] = workstyle.Value
+		profile.workstylesNorm += workstyle.Value * workstyle.Value
+	}
+	profile.workstylesNorm = math.Sqrt(profile.workstylesNorm)
+	for _, skill := range occupation.TechnologySkills {
+		profile.skills[skill.ID] = true
+	}
+	return profile
+}
+
+// profileSimilarity combines the cosine similarity of the workstyle values and the Jaccard similarity of the technology skills
+func profileSimilarity(a occupationProfile, b occupationProfile) float64 {
+	hasWorkstyles := a.workstylesNorm > 0 && b.workstylesNorm > 0
+	hasSkills := len(a.skills) > 0 && len(b.skills) > 0
+
+	workstyleSimilarity := 0.0
+	if hasWorkstyles {
+		dot := 0.0
+		for name, value := range a.workstyles {
+			dot += value * b.workstyles[name]
+		}
+		workstyleSimilarity = dot / (a.workstylesNorm * b.workstylesNorm)
+	}
+
+	skillSimilarity := 0.0
+	if hasSkills {
+		shared := 0
+		for id := range a.skills {
+			if b.skills[id] {
+				shared++
+			}
+		}
+		skillSimilarity = float64(shared) / float64(len(a.skills)+len(b.skills)-shared)
+	}
+
+	switch {
+	case hasWorkstyles && hasSkills:
+		return graphWorkstyleWeight*workstyleSimilarity + (1-graphWorkstyleWeight)*skillSimilarity
+	case hasWorkstyles:
+		return workstyleSimilarity
+	case hasSkills:
+		return skillSimilarity
+	default:
+		return 0
+	}
+}
+
+func pathKey(nodes []string) string {
+	key := ""
+	for _, code := range nodes {
+		key += code + ">"
+	}
+	return key
+}
+
+type pathQueueItem struct {
+	code string
+	cost float64
+}
+
+// pathQueue is a min-heap of occupations ordered by pathway cost
+type pathQueue []pathQueueItem
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *pathQueue) Push(x any) {
+	*q = append(*q, x.(pathQueueItem))
+}
+
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// newOccupationDataReport checks the occupations for the issues that affect matching and computes dataset statistics
+func newOccupationDataReport(occupations []model.OccupationData) model.OccupationDataReport {
+	report := model.OccupationDataReport{ExcludedOccupations: []model.OccupationMatch{}, MissingWorkstyles: []model.OccupationWorkstylesIssue{},
+		UnknownWorkstyles: []model.OccupationWorkstylesIssue{}, DuplicateCodes: []string{}, MalformedCodes: []string{},
+		InvalidRelatedOccupations: []model.OccupationMatch{}, DateGenerated: time.Now().UTC()}
+	stats := model.OccupationDataStatistics{TotalOccupations: len(occupations), WorkstyleCoverage: map[string]int{}, JobZones: map[string]int{}}
+
+	mappedWorkstyles := make([]string, 0, len(bessiToWorkstyles))
+	for _, workstyle := range bessiToWorkstyles {
+		mappedWorkstyles = append(mappedWorkstyles, workstyle)
+	}
+	sort.Strings(mappedWorkstyles)
+
+	codeCounts := map[string]int{}
+	for _, occupation := range occupations {
+		codeCounts[occupation.Code]++
+	}
+
+	workstyleCount, skillCount, relatedCount := 0, 0, 0
+	for _, occupation := range occupations {
+		match := model.OccupationMatch{Code: occupation.Code, Name: occupation.Name}
+		workstyleCount += len(occupation.Workstyles)
+		skillCount += len(occupation.TechnologySkills)
+		relatedCount += len(occupation.RelatedOccupations)
+
+		if !model.OccupationCodeRegex.MatchString(occupation.Code) {
+			report.MalformedCodes = append(report.MalformedCodes, occupation.Code)
+		}
+		if len(occupation.Description) == 0 {
+			stats.MissingDescriptions++
+		}
+		if occupation.JobZone <= 0 {
+			stats.MissingJobZones++
+		} else {
+			stats.JobZones[strconv.Itoa(occupation.JobZone)]++
+		}
+
+		// occupations without workstyles are skipped by the matching algorithm
+		if len(occupation.Workstyles) == 0 {
+			report.ExcludedOccupations = append(report.ExcludedOccupations, match)
+			continue
+		}
+		stats.MatchableOccupations++
+
+		present := map[string]bool{}
+		unknown := make([]string, 0)
+		for _, workstyle := range occupation.Workstyles {
+			present[workstyle.Name] = true
+			stats.WorkstyleCoverage[workstyle.Name]++
+			if !logutils.ContainsString(model.OnetWorkstyles, workstyle.Name) {
+				unknown = append(unknown, workstyle.Name)
+			}
+		}
+		if len(unknown) > 0 {
+			report.UnknownWorkstyles = append(report.UnknownWorkstyles, model.OccupationWorkstylesIssue{Occupation: match, Workstyles: unknown})
+		}
+
+		// mapped workstyles missing from an occupation are skipped when scoring it
+		missing := make([]string, 0)
+		for _, workstyle := range mappedWorkstyles {
+			if !present[workstyle] {
+				missing = append(missing, workstyle)
+			}
+		}
+		if len(missing) > 0 {
+			report.MissingWorkstyles = append(report.MissingWorkstyles, model.OccupationWorkstylesIssue{Occupation: match, Workstyles: missing})
+		} else {
+			stats.FullyMappedOccupations++
+		}
+
+		for _, related := range occupation.RelatedOccupations {
+			if codeCounts[related.Code] == 0 {
+				report.InvalidRelatedOccupations = append(report.InvalidRelatedOccupations, match)
+				break
+			}
+		}
+	}
+
+	for code, count := range codeCounts {
+		if count > 1 {
+			report.DuplicateCodes = append(report.DuplicateCodes, code)
+		}
+	}
+	sort.Strings(report.DuplicateCodes)
+
+	if len(occupations) > 0 {
+		total := float64(len(occupations))
+		stats.AverageWorkstyles = float64(workstyleCount) / total
+		stats.AverageTechnologySkills = float64(skillCount) / total
+		stats.AverageRelatedOccupations = float64(relatedCount) / total
+	}
+	report.Statistics = stats
+
+	return report
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/utils"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// getRateLimits returns the rate limits of an app and org, falling back to the system-wide limits then to the default limits
+func (a *Application) getRateLimits(appID string, orgID string) ([]model.RateLimit, error) {
+	config, err := a.storage.FindConfig(model.ConfigTypeRateLimit, appID, orgID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, &logutils.FieldArgs{"type": model.ConfigTypeRateLimit, "app_id": appID, "org_id": orgID}, err)
+	}
+	if config == nil && (appID != authutils.AllApps || orgID != authutils.AllOrgs) {
+		config, err = a.storage.FindConfig(model.ConfigTypeRateLimit, authutils.AllApps, authutils.AllOrgs)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, &logutils.FieldArgs{"type": model.ConfigTypeRateLimit}, err)
+		}
+	}
+	if config == nil {
+		return model.DefaultRateLimits, nil
+	}
+
+	data, err := model.GetConfigData[model.RateLimitConfigData](*config)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCast, model.TypeRateLimitConfigData, nil, err)
+	}
+	return data.Limits, nil
+}
+
+// takeRateLimit takes a request of an account from the limit of a route, returning nil if the route is not limited
+func (a *Application) takeRateLimit(route string, accountID string, appID string, orgID string) (*model.RateLimitStatus, error) {
+	if a.rateLimits == nil {
+		return nil, nil
+	}
+
+	limits, err := a.getRateLimits(appID, orgID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeRateLimitConfigData, nil, err)
+	}
+	for _, limit := range limits {
+		if limit.Route != route || limit.Requests <= 0 || limit.PeriodSeconds <= 0 {
+			continue
+		}
+
+		key := strings.Join([]string{appID, orgID, accountID, route}, "_")
+		status, err := a.rateLimits.Take(key, limit, time.Now())
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeRateLimit, &logutils.FieldArgs{"route": route}, err)
+		}
+		return &status, nil
+	}
+	return nil, nil
+}
+
+// validateRateLimitConfigData checks that the rate limit config data only contains positive limits, at most one for each route
+func validateRateLimitConfigData(data interface{}) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionMarshal, model.TypeRateLimitConfigData, nil, err)
+	}
+	var rateLimitData model.RateLimitConfigData
+	err = json.Unmarshal(dataBytes, &rateLimitData)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeRateLimitConfigData, nil, err).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	routes := make(map[string]bool)
+	for _, limit := range rateLimitData.Limits {
+		method, path, found := strings.Cut(limit.Route, " ")
+		if !found || len(method) == 0 || !strings.HasPrefix(path, "/") {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeRateLimit, &logutils.FieldArgs{"route": limit.Route}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if routes[limit.Route] {
+			return errors.ErrorData(logutils.StatusFound, model.TypeRateLimit, &logutils.FieldArgs{"route": limit.Route}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if limit.Requests <= 0 || limit.PeriodSeconds <= 0 {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeRateLimit, &logutils.FieldArgs{"requests": limit.Requests, "period_seconds": limit.PeriodSeconds}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		routes[limit.Route] = true
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/model"
+	"application/utils"
+	"encoding/json"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// retentionPurgeDelay is the delay before the first purge after the application starts
+const retentionPurgeDelay time.Duration = time.Minute
+
+// getRetentionConfig returns the retention config data, which is empty if there is no retention config
+func (a *Application) getRetentionConfig() (*model.RetentionConfigData, error) {
+	config, err := a.storage.FindConfig(model.ConfigTypeRetention, authutils.AllApps, authutils.AllOrgs)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, &logutils.FieldArgs{"type": model.ConfigTypeRetention}, err)
+	}
+	if config == nil {
+		return &model.RetentionConfigData{PurgeIntervalHours: model.DefaultRetentionPurgeIntervalHours, Policies: []model.RetentionPolicy{}}, nil
+	}
+
+	data, err := model.GetConfigData[model.RetentionConfigData](*config)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCast, model.TypeRetentionConfigData, nil, err)
+	}
+	if data.PurgeIntervalHours <= 0 {
+		data.PurgeIntervalHours = model.DefaultRetentionPurgeIntervalHours
+	}
+	return data, nil
+}
+
+// runRetentionPurges purges the expired data periodically, at the interval set in the retention config, until the application is stopped
+func (a *Application) runRetentionPurges() {
+	a.setNextRetentionPurge(time.Now().UTC().Add(retentionPurgeDelay))
+	if !a.sleep(retentionPurgeDelay) {
+		return
+	}
+
+	for {
+		interval := time.Duration(model.DefaultRetentionPurgeIntervalHours) * time.Hour
+		config, err := a.getRetentionConfig()
+		if err != nil {
+			a.logger.Errorf("error getting the retention config: %v", err)
+		} else {
+			interval = time.Duration(config.PurgeIntervalHours) * time.Hour
+			a.purgeExpiredData(*config)
+		}
+
+		a.setNextRetentionPurge(time.Now().UTC().Add(interval))
+		if !a.sleep(interval) {
+			return
+		}
+	}
+}
+
+// purgeExpiredData deletes the data older than the retention period of each policy
+func (a *Application) purgeExpiredData(config model.RetentionConfigData) {
+	now := time.Now().UTC()
+	purged := make(map[string]int64)
+	for _, policy := range config.Policies {
+		if policy.RetentionDays <= 0 {
+			continue
+		}
+
+		cutoff := now.AddDate(0, 0, -policy.RetentionDays)
+		count, err := a.storage.DeleteDataUpdatedBefore(policy.Collection, cutoff)
+		if err != nil {
+			a.logger.Errorf("error purging %s before %s: %v", policy.Collection, cutoff, err)
+			continue
+		}
+		purged[policy.Collection] = count
+		a.logger.Infof("purged %d documents from %s last updated before %s", count, policy.Collection, cutoff)
+	}
+
+	a.retentionLock.Lock()
+	defer a.retentionLock.Unlock()
+
+	a.lastRetentionPurge = &now
+	a.lastPurgedCounts = purged
+}
+
+func (a *Application) setNextRetentionPurge(next time.Time) {
+	a.retentionLock.Lock()
+	defer a.retentionLock.Unlock()
+
+	a.nextRetentionPurge = next
+}
+
+// newRetentionReport reports the data that the next purges will delete for each retention policy
+func (a *Application) newRetentionReport() (*model.RetentionReport, error) {
+	config, err := a.getRetentionConfig()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeRetentionConfigData, nil, err)
+	}
+
+	a.retentionLock.RLock()
+	report := model.RetentionReport{Policies: []model.RetentionPolicyReport{}, PurgeIntervalHours: config.PurgeIntervalHours,
+		LastPurge: a.lastRetentionPurge, NextPurge: a.nextRetentionPurge, DateGenerated: time.Now().UTC()}
+	lastPurgedCounts := a.lastPurgedCounts
+	a.retentionLock.RUnlock()
+
+	for _, policy := range config.Policies {
+		if policy.RetentionDays <= 0 {
+			continue
+		}
+
+		// data expiring before the next purge is purged then
+		cutoff := report.NextPurge.AddDate(0, 0, -policy.RetentionDays)
+		count, next, err := a.storage.GetDataRetentionStatus(policy.Collection, cutoff)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeRetentionPolicy, &logutils.FieldArgs{"collection": policy.Collection}, err)
+		}
+
+		policyReport := model.RetentionPolicyReport{Collection: policy.Collection, RetentionDays: policy.RetentionDays, Cutoff: cutoff,
+			ExpiredCount: count, LastPurgedCount: lastPurgedCounts[policy.Collection]}
+		if next != nil {
+			nextExpiry := next.AddDate(0, 0, policy.RetentionDays)
+			policyReport.NextExpiry = &nextExpiry
+		}
+		report.Policies = append(report.Policies, policyReport)
+	}
+
+	return &report, nil
+}
+
+// validateRetentionConfigData checks that the retention config data only contains policies for supported collections with positive retention periods
+func validateRetentionConfigData(data interface{}) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionMarshal, model.TypeRetentionConfigData, nil, err)
+	}
+	var retentionData model.RetentionConfigData
+	err = json.Unmarshal(dataBytes, &retentionData)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeRetentionConfigData, nil, err).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	if retentionData.PurgeIntervalHours < 0 {
+		return errors.ErrorData(logutils.StatusInvalid, "purge interval", &logutils.FieldArgs{"purge_interval_hours": retentionData.PurgeIntervalHours}).SetStatus(utils.ErrorStatusInvalid)
+	}
+	collections := make(map[string]bool)
+	for _, policy := range retentionData.Policies {
+		supported := false
+		for _, collection := range model.RetentionCollections {
+			supported = supported || collection == policy.Collection
+		}
+		if !supported {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeRetentionPolicy, &logutils.FieldArgs{"collection": policy.Collection}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if collections[policy.Collection] {
+			return errors.ErrorData(logutils.StatusFound, model.TypeRetentionPolicy, &logutils.FieldArgs{"collection": policy.Collection}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		if policy.RetentionDays <= 0 {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeRetentionPolicy, &logutils.FieldArgs{"retention_days": policy.RetentionDays}).SetStatus(utils.ErrorStatusInvalid)
+		}
+		collections[policy.Collection] = true
+	}
+	return nil
+}
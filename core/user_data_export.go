@@ -0,0 +1,137 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"application/core/interfaces"
+	"application/core/model"
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// userDataExportTokenSize is the number of random bytes of the token of an export download link
+	userDataExportTokenSize int = 32
+
+	surveyDataCSVFile  string = "survey_data.csv"
+	matchResultCSVFile string = "match_results.csv"
+)
+
+// generateUserDataExport generates the archive of an export in the background, setting its status once done
+func (a *Application) generateUserDataExport(userDataExport model.UserDataExport) {
+	surveyDatas, err := a.storage.SearchSurveyDatas(model.UserDataSearch{AccountID: &userDataExport.AccountID})
+	if err == nil {
+		userDataExport.Data, err = userDataArchive(a.storage, userDataExport.AccountID, surveyDatas, userDataExport.Format)
+	}
+	if err != nil {
+		a.logger.Errorf("error exporting the data of account %s: %v", userDataExport.AccountID, err)
+		userDataExport.Status = model.UserDataExportStatusFailed
+	} else {
+		userDataExport.Status = model.UserDataExportStatusReady
+		// the download link is valid from the time the export is ready
+		userDataExport.DateExpires = time.Now().UTC().Add(model.UserDataExportExpiry)
+	}
+
+	err = a.storage.UpdateUserDataExport(userDataExport)
+	if err != nil {
+		a.logger.Errorf("error saving the data export %s of account %s: %v", userDataExport.ID, userDataExport.AccountID, err)
+	}
+}
+
+// userDataArchive returns the archive of the survey data and match results history of an account in the given format
+func userDataArchive(storage interfaces.Storage, accountID string, surveyDatas []model.SurveyData, format string) ([]byte, error) {
+	histories, err := storage.FindUserMatchingResultHistories(accountID)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	archive := model.UserDataArchive{AccountID: accountID, DateExported: time.Now().UTC(), SurveyDatas: surveyDatas, MatchResults: histories}
+
+	if format == model.UserDataExportFormatCSV {
+		return userDataCSVArchive(archive)
+	}
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionMarshal, model.TypeUserDataArchive, nil, err)
+	}
+	return data, nil
+}
+
+// userDataCSVArchive returns a ZIP archive with a CSV file of the survey scores and a CSV file of the occupation matches of a user archive
+func userDataCSVArchive(archive model.UserDataArchive) ([]byte, error) {
+	surveyDataRows := [][]string{{"id", "version", "date_created", "date_updated", "revision", "workstyle", "score"}}
+	for _, surveyData := range archive.SurveyDatas {
+		for _, score := range surveyData.Scores {
+			surveyDataRows = append(surveyDataRows, []string{surveyData.ID, surveyData.Version, formatCSVDate(&surveyData.DateCreated), formatCSVDate(surveyData.DateUpdated),
+				strconv.FormatInt(surveyData.Revision, 10), score.Workstyle, strconv.Itoa(score.Score)})
+		}
+	}
+
+	matchResultRows := [][]string{{"id", "version", "date_created", "occupation_code", "occupation_name", "match_percent"}}
+	for _, matchResult := range archive.MatchResults {
+		for _, match := range matchResult.Matches {
+			matchResultRows = append(matchResultRows, []string{matchResult.ID, matchResult.Version, formatCSVDate(&matchResult.DateCreated),
+				match.Occupation.Code, match.Occupation.Name, strconv.FormatFloat(match.MatchPercent, 'f', -1, 64)})
+		}
+	}
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for _, file := range []struct {
+		name string
+		rows [][]string
+	}{{name: surveyDataCSVFile, rows: surveyDataRows}, {name: matchResultCSVFile, rows: matchResultRows}} {
+		header := zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: archive.DateExported}
+		fileWriter, err := zipWriter.CreateHeader(&header)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserDataArchive, &logutils.FieldArgs{"file": file.name}, err)
+		}
+		err = csv.NewWriter(fileWriter).WriteAll(file.rows)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserDataArchive, &logutils.FieldArgs{"file": file.name}, err)
+		}
+	}
+	err := zipWriter.Close()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserDataArchive, nil, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// formatCSVDate formats a date of a CSV file, leaving unset dates empty
+func formatCSVDate(date *time.Time) string {
+	if date == nil || date.IsZero() {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
+
+// newUserDataExportToken returns a random token for the download link of an export
+func newUserDataExportToken() (string, error) {
+	token := make([]byte, userDataExportTokenSize)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", errors.WrapErrorAction(logutils.ActionGenerate, "user data export token", nil, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
@@ -94,6 +94,8 @@ def filter_occupation_data(raw_occupation_data):
     occupation_data['name'] = raw_occupation_data['occupation']['title']
     occupation_data['description'] = raw_occupation_data['occupation']['description']
     occupation_data['technology_skills'] = filter_tech_skills(raw_occupation_data.get('technology_skills', {'category': []}))
+    occupation_data['job_zone'] = raw_occupation_data.get('job_zone', {}).get('code', 0)
+    occupation_data['related_occupations'] = filter_related_occupations(raw_occupation_data.get('related_occupations', {'occupation': []}))
     return occupation_data
 
 # Converts a raw technology skills to tech skills data
@@ -109,6 +111,17 @@ def filter_tech_skills(raw_tech_skills):
         tech_skills.append(data)
     return tech_skills
 
+# Converts raw related occupations to related occupation data, keeping the order given by ONET
+def filter_related_occupations(raw_related_occupations):
+    related_occupations = []
+    for index, occupation in enumerate(raw_related_occupations['occupation']):
+        data = {}
+        data['code'] = occupation['code']
+        data['name'] = occupation['title']
+        data['index'] = index + 1
+        related_occupations.append(data)
+    return related_occupations
+
 # Converts a raw_workstyle_data to workstyle data that we will actually use and need
 def filter_workstyle_data(raw_workstyle_data):   
     workstyle_data = []
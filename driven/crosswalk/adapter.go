@@ -0,0 +1,180 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosswalk
+
+import (
+	"application/core/model"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	columnOccupationCode string = "onet_soc_code"
+	columnCode           string = "code"
+	columnTitle          string = "title"
+)
+
+// Adapter implements the Crosswalks interface using crosswalk tables loaded from local CSV files
+//
+//	Each supported system is loaded from a "<system>.csv" file in the crosswalks directory with the columns
+//	"onet_soc_code", "code" and "title". The "onet_soc_code" column may contain either full ONET-SOC codes (eg. 15-1252.00)
+//	or SOC codes (eg. 15-1252) which apply to every ONET-SOC occupation in that SOC occupation.
+type Adapter struct {
+	directory string
+
+	codes       map[string]map[string][]model.CrosswalkCode
+	occupations map[string]map[string][]string
+
+	logger *logs.Logger
+}
+
+// Start loads the crosswalk tables
+func (a *Adapter) Start() error {
+	for _, system := range model.CrosswalkSystems {
+		path := filepath.Join(a.directory, system+".csv")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			a.logger.Warnf("crosswalk table for %s not found at %s", system, path)
+			continue
+		}
+
+		entries, err := loadCrosswalkFile(path, system)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionLoad, model.TypeCrosswalk, &logutils.FieldArgs{"system": system}, err)
+		}
+
+		codes := map[string][]model.CrosswalkCode{}
+		occupations := map[string][]string{}
+		for _, entry := range entries {
+			codes[entry.OccupationCode] = append(codes[entry.OccupationCode], entry.CrosswalkCode)
+			occupations[entry.Code] = append(occupations[entry.Code], entry.OccupationCode)
+		}
+		a.codes[system] = codes
+		a.occupations[system] = occupations
+		a.logger.Infof("loaded %d %s crosswalk entries", len(entries), system)
+	}
+
+	return nil
+}
+
+// GetCrosswalkSystems returns the systems with a loaded crosswalk table
+func (a *Adapter) GetCrosswalkSystems() []string {
+	systems := make([]string, 0)
+	for _, system := range model.CrosswalkSystems {
+		if a.codes[system] != nil {
+			systems = append(systems, system)
+		}
+	}
+	return systems
+}
+
+// FindCrosswalkCodes finds the codes in the given system equivalent to an ONET-SOC occupation code
+func (a *Adapter) FindCrosswalkCodes(occupationCode string, system string) []model.CrosswalkCode {
+	codes := a.codes[system]
+	if codes == nil {
+		return nil
+	}
+	if found, ok := codes[occupationCode]; ok {
+		return found
+	}
+	// fall back to the SOC occupation the ONET-SOC occupation belongs to
+	return codes[socCode(occupationCode)]
+}
+
+// FindOccupationCodes finds the ONET-SOC or SOC occupation codes equivalent to a code in the given system
+func (a *Adapter) FindOccupationCodes(system string, code string) []string {
+	occupations := a.occupations[system]
+	if occupations == nil {
+		return nil
+	}
+	return occupations[code]
+}
+
+// loadCrosswalkFile reads the entries of a crosswalk table, failing if a required column is missing or the file is not valid CSV
+func loadCrosswalkFile(path string, system string) ([]model.CrosswalkEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionRead, "crosswalk file", &logutils.FieldArgs{"path": path}, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionRead, "crosswalk header", &logutils.FieldArgs{"path": path}, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{columnOccupationCode, columnCode} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.ErrorData(logutils.StatusMissing, "crosswalk column", &logutils.FieldArgs{"path": path, "column": name})
+		}
+	}
+
+	entries := make([]model.CrosswalkEntry, 0)
+	loaded := map[[2]string]bool{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionRead, "crosswalk record", &logutils.FieldArgs{"path": path}, err)
+		}
+
+		entry := model.CrosswalkEntry{OccupationCode: column(record, columns, columnOccupationCode),
+			CrosswalkCode: model.CrosswalkCode{System: system, Code: column(record, columns, columnCode), Title: column(record, columns, columnTitle)}}
+		// rows without codes are skipped, as are the rows repeating the codes of a previous row
+		key := [2]string{entry.OccupationCode, entry.Code}
+		if entry.OccupationCode == "" || entry.Code == "" || loaded[key] {
+			continue
+		}
+		loaded[key] = true
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func column(record []string, columns map[string]int, name string) string {
+	index, ok := columns[name]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// socCode returns the SOC code (eg. 15-1252) of an ONET-SOC code (eg. 15-1252.00)
+func socCode(occupationCode string) string {
+	code, _, _ := strings.Cut(occupationCode, ".")
+	return code
+}
+
+// NewCrosswalkAdapter creates a new crosswalk adapter instance
+func NewCrosswalkAdapter(directory string, logger *logs.Logger) *Adapter {
+	return &Adapter{directory: directory, codes: map[string]map[string][]model.CrosswalkCode{},
+		occupations: map[string]map[string][]string{}, logger: logger}
+}
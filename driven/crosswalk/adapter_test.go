@@ -0,0 +1,99 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosswalk_test
+
+import (
+	"application/core/model"
+	"application/driven/crosswalk"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+func buildTestAdapter(t *testing.T, tables map[string]string) (*crosswalk.Adapter, error) {
+	directory := t.TempDir()
+	for system, content := range tables {
+		err := os.WriteFile(filepath.Join(directory, system+".csv"), []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("error writing crosswalk table: %v", err)
+		}
+	}
+	adapter := crosswalk.NewCrosswalkAdapter(directory, logs.NewLogger("skills-to-jobs", nil))
+	return adapter, adapter.Start()
+}
+
+func TestAdapter_Start(t *testing.T) {
+	table := "onet_soc_code,code,title\n" +
+		"15-1252.00,2512,Software developers\n" +
+		"15-1252.00,2512,Software developers again\n" +
+		"15-1252.00,2519,Other software developers\n" +
+		"15-1211,2511,Systems analysts\n"
+	adapter, err := buildTestAdapter(t, map[string]string{model.CrosswalkSystemISCO08: table})
+	if err != nil {
+		t.Fatalf("crosswalk.Adapter.Start() error = %v", err)
+	}
+
+	if systems := adapter.GetCrosswalkSystems(); !reflect.DeepEqual(systems, []string{model.CrosswalkSystemISCO08}) {
+		t.Errorf("crosswalk.Adapter.GetCrosswalkSystems() = %v, want %v", systems, []string{model.CrosswalkSystemISCO08})
+	}
+
+	// the duplicate row is only loaded once, with the title of its first row
+	want := []model.CrosswalkCode{{System: model.CrosswalkSystemISCO08, Code: "2512", Title: "Software developers"},
+		{System: model.CrosswalkSystemISCO08, Code: "2519", Title: "Other software developers"}}
+	if got := adapter.FindCrosswalkCodes("15-1252.00", model.CrosswalkSystemISCO08); !reflect.DeepEqual(got, want) {
+		t.Errorf("crosswalk.Adapter.FindCrosswalkCodes() = %v, want %v", got, want)
+	}
+	// ONET-SOC occupations fall back to the codes of their SOC occupation
+	if got := adapter.FindCrosswalkCodes("15-1211.01", model.CrosswalkSystemISCO08); len(got) != 1 || got[0].Code != "2511" {
+		t.Errorf("crosswalk.Adapter.FindCrosswalkCodes() SOC fallback = %v, want 2511", got)
+	}
+	if got := adapter.FindOccupationCodes(model.CrosswalkSystemISCO08, "2512"); !reflect.DeepEqual(got, []string{"15-1252.00"}) {
+		t.Errorf("crosswalk.Adapter.FindOccupationCodes() = %v, want [15-1252.00]", got)
+	}
+	if got := adapter.FindCrosswalkCodes("15-1252.00", model.CrosswalkSystemESCO); got != nil {
+		t.Errorf("crosswalk.Adapter.FindCrosswalkCodes() of a system without table = %v, want nil", got)
+	}
+}
+
+func TestAdapter_StartInvalidTables(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     string
+		wantErr   bool
+		wantCodes int
+	}{
+		{"missing code column", "onet_soc_code,title\n15-1252.00,Software developers\n", true, 0},
+		{"missing occupation code column", "code,title\n2512,Software developers\n", true, 0},
+		{"unterminated quote", "onet_soc_code,code,title\n15-1252.00,2512,\"Software developers\n", true, 0},
+		{"rows without codes", "onet_soc_code,code,title\n15-1252.00,,Software developers\n,2512,Software developers\n15-1252.00\n15-1252.00,2512\n", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := buildTestAdapter(t, map[string]string{model.CrosswalkSystemISCO08: tt.table})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("crosswalk.Adapter.Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := adapter.FindCrosswalkCodes("15-1252.00", model.CrosswalkSystemISCO08); len(got) != tt.wantCodes {
+				t.Errorf("crosswalk.Adapter.FindCrosswalkCodes() = %v, want %d codes", got, tt.wantCodes)
+			}
+		})
+	}
+}
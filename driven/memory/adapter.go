@@ -0,0 +1,459 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/interfaces"
+	"application/core/model"
+	"application/utils"
+	"context"
+	"sync"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Adapter implements the Storage interface in memory, for running the service and tests without MongoDB
+type Adapter struct {
+	store *store
+
+	// transaction is set on the adapters passed to PerformTransaction callbacks
+	transaction *transaction
+}
+
+// store holds the committed data shared by an Adapter and its transactions
+type store struct {
+	db   *database
+	lock *sync.RWMutex
+
+	cachedConfigs []model.Config
+	listeners     []interfaces.StorageListener
+	listenersLock *sync.RWMutex
+
+	// the changes are queued as they are committed and notified in order by one goroutine at a time, as the MongoDB change streams do
+	pendingChanges     []model.DataChange
+	pendingChangesLock *sync.Mutex
+	notifyingChanges   bool
+
+	fixturesDir string
+	logger      *logs.Logger
+}
+
+// database holds the collections, named as in MongoDB
+type database struct {
+	configs         *collection
+	occupationData  *collection
+	matchResults    *collection
+	surveyResponses *collection
+	idempotencyKeys *collection
+	userDataExports *collection
+	matchingEvents  *collection
+
+	matchResultsHistory *collection
+}
+
+// takeChanges returns the changes recorded in all collections since the last call
+func (d *database) takeChanges() []model.DataChange {
+	changes := make([]model.DataChange, 0)
+	for _, coll := range []*collection{d.configs, d.occupationData, d.matchResults, d.surveyResponses, d.matchingEvents} {
+		changes = append(changes, coll.takeChanges()...)
+	}
+	// the idempotency keys, user data exports and match results history are not watched in MongoDB
+	d.idempotencyKeys.takeChanges()
+	d.userDataExports.takeChanges()
+	d.matchResultsHistory.takeChanges()
+	return changes
+}
+
+func (d *database) clone() *database {
+	return &database{configs: d.configs.clone(), occupationData: d.occupationData.clone(),
+		matchResults: d.matchResults.clone(), surveyResponses: d.surveyResponses.clone(), idempotencyKeys: d.idempotencyKeys.clone(),
+		userDataExports: d.userDataExports.clone(), matchingEvents: d.matchingEvents.clone(),
+		matchResultsHistory: d.matchResultsHistory.clone()}
+}
+
+// transaction holds a copy of the data which replaces the committed data once the transaction succeeds
+type transaction struct {
+	db *database
+}
+
+// Start starts the storage
+func (a *Adapter) Start() error {
+	if a.store.fixturesDir != "" {
+		err := a.loadFixtures(a.store.fixturesDir)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInitialize, "memory storage adapter", nil, err)
+		}
+	}
+
+	//cache the configs
+	err := a.cacheConfigs()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCache, model.TypeConfig, nil, err)
+	}
+
+	return nil
+}
+
+// Stop stops the storage, keeping the data in memory
+func (a *Adapter) Stop(ctx context.Context) error {
+	return nil
+}
+
+// WithContext returns the storage, whose operations in memory do not use a context
+func (a *Adapter) WithContext(ctx context.Context) interfaces.Storage {
+	return a
+}
+
+// RegisterStorageListener registers a data change listener with the storage adapter
+func (a *Adapter) RegisterStorageListener(listener interfaces.StorageListener) {
+	a.store.listenersLock.Lock()
+	defer a.store.listenersLock.Unlock()
+
+	a.store.listeners = append(a.store.listeners, listener)
+}
+
+// read runs the given function with the data visible to the adapter
+func (a *Adapter) read(fn func(db *database) error) error {
+	if a.transaction != nil {
+		return fn(a.transaction.db)
+	}
+
+	a.store.lock.RLock()
+	defer a.store.lock.RUnlock()
+
+	return fn(a.store.db)
+}
+
+// write runs the given function with the data visible to the adapter, notifying the listeners of the changes once they are committed
+func (a *Adapter) write(fn func(db *database) error) error {
+	if a.transaction != nil {
+		return fn(a.transaction.db)
+	}
+
+	a.store.lock.Lock()
+	err := fn(a.store.db)
+	changes := a.store.db.takeChanges()
+	a.queueDataChanges(changes)
+	if hasChanges(changes, model.CollectionConfigs) {
+		cacheErr := a.cacheConfigsLocked()
+		if err == nil {
+			err = cacheErr
+		}
+	}
+	a.store.lock.Unlock()
+
+	a.onDataChanged(changes)
+	return err
+}
+
+// onDataChanged notifies the listeners asynchronously of the updated collections, as the MongoDB change streams do
+func (a *Adapter) onDataChanged(changes []model.DataChange) {
+	a.store.listenersLock.RLock()
+	defer a.store.listenersLock.RUnlock()
+
+	if hasChanges(changes, model.CollectionConfigs) {
+		for _, listener := range a.store.listeners {
+			go listener.OnConfigsUpdated()
+		}
+	}
+	if hasChanges(changes, model.CollectionOccupationData) {
+		for _, listener := range a.store.listeners {
+			go listener.OnOccupationDataUpdated()
+		}
+	}
+}
+
+// queueDataChanges queues the committed changes for the listeners, starting a goroutine to notify them unless one is running
+func (a *Adapter) queueDataChanges(changes []model.DataChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	a.store.pendingChangesLock.Lock()
+	defer a.store.pendingChangesLock.Unlock()
+
+	a.store.pendingChanges = append(a.store.pendingChanges, changes...)
+	if !a.store.notifyingChanges {
+		a.store.notifyingChanges = true
+		go a.notifyDataChanges()
+	}
+}
+
+// notifyDataChanges notifies the listeners of the queued changes in order, until none is left
+func (a *Adapter) notifyDataChanges() {
+	for {
+		a.store.pendingChangesLock.Lock()
+		changes := a.store.pendingChanges
+		a.store.pendingChanges = nil
+		a.store.notifyingChanges = len(changes) > 0
+		a.store.pendingChangesLock.Unlock()
+		if len(changes) == 0 {
+			return
+		}
+
+		a.store.listenersLock.RLock()
+		listeners := a.store.listeners
+		a.store.listenersLock.RUnlock()
+		for _, change := range changes {
+			for _, listener := range listeners {
+				listener.OnDataChanged(change)
+			}
+		}
+	}
+}
+
+func hasChanges(changes []model.DataChange, coll string) bool {
+	for _, change := range changes {
+		if change.Collection == coll {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheConfigs caches the configs from the committed data
+func (a *Adapter) cacheConfigs() error {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	return a.cacheConfigsLocked()
+}
+
+func (a *Adapter) cacheConfigsLocked() error {
+	configs, err := loadConfigs(a.store.db, a.store.logger)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeConfig, nil, err)
+	}
+
+	a.store.cachedConfigs = configs
+	return nil
+}
+
+// getConfigs returns the cached configs, or the configs of the current transaction
+//
+//	A transaction already holds the store lock, so its configs are loaded from its own copy of the data.
+func (a *Adapter) getConfigs() ([]model.Config, error) {
+	if a.transaction != nil {
+		return loadConfigs(a.transaction.db, a.store.logger)
+	}
+
+	a.store.lock.RLock()
+	defer a.store.lock.RUnlock()
+
+	return a.store.cachedConfigs, nil
+}
+
+func loadConfigs(db *database, logger *logs.Logger) ([]model.Config, error) {
+	configs, err := findAll[model.Config](db.configs)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
+	}
+
+	for i, config := range configs {
+		switch config.Type {
+		case model.ConfigTypeEnv:
+			err = parseConfigsData[model.EnvConfigData](&configs[i])
+		case model.ConfigTypeRetention:
+			err = parseConfigsData[model.RetentionConfigData](&configs[i])
+		case model.ConfigTypeRateLimit:
+			err = parseConfigsData[model.RateLimitConfigData](&configs[i])
+		default:
+			err = parseConfigsData[map[string]interface{}](&configs[i])
+		}
+		if err != nil {
+			logger.Warn(err.Error())
+		}
+	}
+	return configs, nil
+}
+
+func parseConfigsData[T model.ConfigData](config *model.Config) error {
+	bsonBytes, err := bson.Marshal(config.Data)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeConfig, nil, err)
+	}
+
+	var data T
+	err = bson.Unmarshal(bsonBytes, &data)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeConfigData, &logutils.FieldArgs{"type": config.Type}, err)
+	}
+
+	config.Data = data
+	return nil
+}
+
+// FindConfig finds the config for the specified type, appID, and orgID
+func (a *Adapter) FindConfig(configType string, appID string, orgID string) (*model.Config, error) {
+	configs, err := a.getConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, config := range configs {
+		if config.Type == configType && config.AppID == appID && config.OrgID == orgID {
+			return &config, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindConfigByID finds the config for the specified ID
+func (a *Adapter) FindConfigByID(id string) (*model.Config, error) {
+	configs, err := a.getConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, config := range configs {
+		if config.ID == id {
+			return &config, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindConfigs finds all configs for the specified type
+func (a *Adapter) FindConfigs(configType *string) ([]model.Config, error) {
+	configs, err := a.getConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	configList := make([]model.Config, 0)
+	for _, config := range configs {
+		if configType == nil || config.Type == *configType {
+			configList = append(configList, config)
+		}
+	}
+	return configList, nil
+}
+
+// InsertConfig inserts a new config
+func (a *Adapter) InsertConfig(config model.Config) error {
+	return a.write(func(db *database) error {
+		err := checkConfigUnique(db, config)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeConfig, nil, err)
+		}
+
+		err = db.configs.insert(config.ID, config)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeConfig, nil, err)
+		}
+		return nil
+	})
+}
+
+// UpdateConfig updates an existing config, only if its revision matches when the config revision is set
+func (a *Adapter) UpdateConfig(config model.Config) error {
+	return a.write(func(db *database) error {
+		existing, err := findOne[model.Config](db.configs, config.ID)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeConfig, &logutils.FieldArgs{"id": config.ID}, err)
+		}
+		if config.Revision > 0 && (existing == nil || existing.Revision != config.Revision) {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeConfig, &logutils.FieldArgs{"id": config.ID, "revision": config.Revision}).SetStatus(utils.ErrorStatusConflict)
+		}
+		if existing == nil {
+			return nil
+		}
+
+		err = checkConfigUnique(db, config)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeConfig, &logutils.FieldArgs{"id": config.ID}, err)
+		}
+
+		config.DateCreated = existing.DateCreated
+		config.Revision = existing.Revision + 1
+		err = db.configs.replace(config.ID, config)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeConfig, &logutils.FieldArgs{"id": config.ID}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteConfig deletes a configuration from storage
+func (a *Adapter) DeleteConfig(id string) error {
+	return a.write(func(db *database) error {
+		db.configs.delete(id)
+		return nil
+	})
+}
+
+// checkConfigUnique mirrors the unique index on the type, app_id and org_id of the configs collection
+func checkConfigUnique(db *database, config model.Config) error {
+	configs, err := findAll[model.Config](db.configs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range configs {
+		if item.ID != config.ID && item.Type == config.Type && item.AppID == config.AppID && item.OrgID == config.OrgID {
+			return errors.ErrorData(logutils.StatusFound, model.TypeConfig, &logutils.FieldArgs{"type": config.Type, "app_id": config.AppID, "org_id": config.OrgID})
+		}
+	}
+	return nil
+}
+
+// PerformTransaction performs a transaction
+//
+//	The transaction runs on a copy of the data, which replaces the stored data only if the transaction succeeds.
+//	Transactions and writes are serialized, so the transaction must only use the storage it is given.
+func (a *Adapter) PerformTransaction(transaction func(storage interfaces.Storage) error) error {
+	if a.transaction != nil {
+		return transaction(a)
+	}
+
+	a.store.lock.Lock()
+	adapter := a.withTransaction(a.store.db.clone())
+	err := transaction(adapter)
+	changes := make([]model.DataChange, 0)
+	if err == nil {
+		a.store.db = adapter.transaction.db
+		changes = a.store.db.takeChanges()
+		a.queueDataChanges(changes)
+		if hasChanges(changes, model.CollectionConfigs) {
+			err = a.cacheConfigsLocked()
+		}
+	}
+	a.store.lock.Unlock()
+
+	if err != nil {
+		return errors.WrapErrorAction("performing", logutils.TypeTransaction, nil, err)
+	}
+	a.onDataChanged(changes)
+	return nil
+}
+
+// Creates a new Adapter working on a copy of the data
+func (a *Adapter) withTransaction(db *database) *Adapter {
+	return &Adapter{store: a.store, transaction: &transaction{db: db}}
+}
+
+// NewStorageAdapter creates a new in-memory storage adapter instance, seeded from the JSON fixture files in fixturesDir if it is not empty
+func NewStorageAdapter(fixturesDir string, logger *logs.Logger) *Adapter {
+	db := &database{configs: newCollection("configs"), occupationData: newCollection("occupation_data"),
+		matchResults: newCollection("match_results"), surveyResponses: newCollection("survey_responses"),
+		idempotencyKeys: newCollection("idempotency_keys"), userDataExports: newCollection(model.CollectionUserDataExports),
+		matchingEvents: newCollection(model.CollectionMatchingEvents), matchResultsHistory: newCollection(model.CollectionMatchResultsHistory)}
+	store := &store{db: db, lock: &sync.RWMutex{}, cachedConfigs: make([]model.Config, 0), listenersLock: &sync.RWMutex{},
+		pendingChangesLock: &sync.Mutex{}, fixturesDir: fixturesDir, logger: logger}
+	return &Adapter{store: store}
+}
@@ -0,0 +1,347 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"application/core/interfaces"
+	"application/core/model"
+	"application/driven/memory"
+	"application/utils"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authutils"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+type testListener struct {
+	configsUpdated        chan bool
+	occupationDataUpdated chan bool
+	dataChanged           chan model.DataChange
+}
+
+func (l *testListener) OnConfigsUpdated() {
+	l.configsUpdated <- true
+}
+
+func (l *testListener) OnOccupationDataUpdated() {
+	l.occupationDataUpdated <- true
+}
+
+func (l *testListener) OnDataChanged(change model.DataChange) {
+	l.dataChanged <- change
+}
+
+func newTestListener() *testListener {
+	return &testListener{configsUpdated: make(chan bool, 1), occupationDataUpdated: make(chan bool, 1), dataChanged: make(chan model.DataChange, 10)}
+}
+
+func waitForUpdate(t *testing.T, updated chan bool, name string) {
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Errorf("storage listener %s not notified", name)
+	}
+}
+
+func buildTestAdapter(t *testing.T, fixturesDir string) *memory.Adapter {
+	logger := logs.NewLogger("skills-to-jobs", nil)
+	adapter := memory.NewStorageAdapter(fixturesDir, logger)
+	err := adapter.Start()
+	if err != nil {
+		t.Fatalf("memory.Adapter.Start() error = %v", err)
+	}
+	return adapter
+}
+
+func TestAdapter_Fixtures(t *testing.T) {
+	dir := t.TempDir()
+	configs := `[{"id": "env", "type": "env", "app_id": "all", "org_id": "all", "data": {"example_env": "example"}}]`
+	occupations := `[{"code": "11-1011.00", "name": "Chief Executives"}, {"code": "15-1252.00", "name": "Software Developers"}]`
+	for name, content := range map[string]string{"configs.json": configs, "occupation_data.json": occupations} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	adapter := buildTestAdapter(t, dir)
+
+	config, err := adapter.FindConfig(model.ConfigTypeEnv, authutils.AllApps, authutils.AllOrgs)
+	if err != nil || config == nil {
+		t.Fatalf("memory.Adapter.FindConfig() = %v, %v", config, err)
+	}
+	data, err := model.GetConfigData[model.EnvConfigData](*config)
+	if err != nil || data.ExampleEnv != "example" {
+		t.Errorf("memory.Adapter.FindConfig() data = %v, %v", config.Data, err)
+	}
+
+	all, err := adapter.GetAllOccupationDatas()
+	if err != nil || len(all) != 2 || all[0].Code != "11-1011.00" {
+		t.Errorf("memory.Adapter.GetAllOccupationDatas() = %v, %v", all, err)
+	}
+	missing, err := adapter.GetOccupationData("99-9999.00")
+	if missing != nil || errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("memory.Adapter.GetOccupationData() = %v, %v, want not found", missing, err)
+	}
+}
+
+func TestAdapter_PerformTransaction(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+	listener := newTestListener()
+	adapter.RegisterStorageListener(listener)
+
+	failed := errors.New("failed")
+	err := adapter.PerformTransaction(func(storage interfaces.Storage) error {
+		err := storage.InsertConfig(model.Config{ID: "rolled-back", Type: "test", DateCreated: time.Now()})
+		if err != nil {
+			return err
+		}
+		config, err := storage.FindConfigByID("rolled-back")
+		if err != nil || config == nil {
+			t.Errorf("transaction FindConfigByID() = %v, %v, want inserted config", config, err)
+		}
+		return failed
+	})
+	if err == nil {
+		t.Errorf("memory.Adapter.PerformTransaction() error = nil, want error")
+	}
+	if config, _ := adapter.FindConfigByID("rolled-back"); config != nil {
+		t.Errorf("memory.Adapter.PerformTransaction() did not roll back the inserted config")
+	}
+
+	err = adapter.PerformTransaction(func(storage interfaces.Storage) error {
+		return storage.InsertConfig(model.Config{ID: "committed", Type: "test", DateCreated: time.Now()})
+	})
+	if err != nil {
+		t.Fatalf("memory.Adapter.PerformTransaction() error = %v", err)
+	}
+	if config, _ := adapter.FindConfigByID("committed"); config == nil {
+		t.Errorf("memory.Adapter.PerformTransaction() did not commit the inserted config")
+	}
+
+	waitForUpdate(t, listener.configsUpdated, "OnConfigsUpdated")
+}
+
+func TestAdapter_OccupationData(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+	listener := newTestListener()
+	adapter.RegisterStorageListener(listener)
+
+	occupationData := model.OccupationData{Code: "15-1252.00", Name: "Software Developers"}
+	err := adapter.InsertOccupationData(occupationData)
+	if err != nil {
+		t.Fatalf("memory.Adapter.InsertOccupationData() error = %v", err)
+	}
+	waitForUpdate(t, listener.occupationDataUpdated, "OnOccupationDataUpdated")
+	if err = adapter.InsertOccupationData(occupationData); errors.Status(err) != utils.ErrorStatusExists {
+		t.Errorf("memory.Adapter.InsertOccupationData() existing error = %v, want exists", err)
+	}
+
+	occupationData.Name = "Developers"
+	err = adapter.UpdateOccupationData(occupationData)
+	if err != nil {
+		t.Fatalf("memory.Adapter.UpdateOccupationData() error = %v", err)
+	}
+	waitForUpdate(t, listener.occupationDataUpdated, "OnOccupationDataUpdated")
+
+	got, err := adapter.GetOccupationData(occupationData.Code)
+	if err != nil || got == nil || got.Name != "Developers" {
+		t.Errorf("memory.Adapter.GetOccupationData() = %v, %v", got, err)
+	}
+
+	if err = adapter.DeleteOccupationData(occupationData.Code); err != nil {
+		t.Errorf("memory.Adapter.DeleteOccupationData() error = %v", err)
+	}
+	if err = adapter.DeleteOccupationData(occupationData.Code); err == nil {
+		t.Errorf("memory.Adapter.DeleteOccupationData() missing error = nil, want error")
+	}
+}
+
+func TestAdapter_PerformTransactionFindConfigs(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+	err := adapter.InsertConfig(model.Config{ID: "existing", Type: "test", DateCreated: time.Now()})
+	if err != nil {
+		t.Fatalf("memory.Adapter.InsertConfig() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- adapter.PerformTransaction(func(storage interfaces.Storage) error {
+			configs, err := storage.FindConfigs(nil)
+			if err != nil || len(configs) != 1 {
+				t.Errorf("transaction FindConfigs() = %v, %v, want the existing config", configs, err)
+			}
+			return err
+		})
+	}()
+
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Errorf("memory.Adapter.PerformTransaction() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("memory.Adapter.PerformTransaction() did not return, FindConfigs deadlocked")
+	}
+}
+
+func TestAdapter_DataChanges(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+	listener := newTestListener()
+	adapter.RegisterStorageListener(listener)
+
+	adapter.CreateSurveyData(model.SurveyData{ID: "survey", DateCreated: time.Now()})
+	adapter.UpdateSurveyData(model.SurveyData{ID: "survey"})
+	adapter.DeleteSurveyData("survey")
+	adapter.DeleteSurveyData("survey")
+
+	for _, operation := range []string{model.DataChangeInsert, model.DataChangeUpdate, model.DataChangeDelete} {
+		select {
+		case change := <-listener.dataChanged:
+			if change.Collection != model.CollectionSurveyResponses || change.DocumentID != "survey" || change.Operation != operation {
+				t.Errorf("storage listener OnDataChanged() change = %+v, want %s", change, operation)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("storage listener OnDataChanged() %s change not notified", operation)
+		}
+	}
+	select {
+	case change := <-listener.dataChanged:
+		t.Errorf("storage listener OnDataChanged() unexpected change = %+v", change)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAdapter_SurveyData(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+
+	surveyData := model.SurveyData{ID: "survey", Scores: []model.WorkstyleScore{{Workstyle: "Leadership", Score: 3}}, DateCreated: time.Now()}
+	err := adapter.CreateSurveyData(surveyData)
+	if err != nil {
+		t.Fatalf("memory.Adapter.CreateSurveyData() error = %v", err)
+	}
+	if err = adapter.CreateSurveyData(surveyData); err == nil {
+		t.Errorf("memory.Adapter.CreateSurveyData() duplicate error = nil, want error")
+	}
+
+	got, err := adapter.GetSurveyData("survey")
+	if err != nil {
+		t.Fatalf("memory.Adapter.GetSurveyData() error = %v", err)
+	}
+	got.Scores[0].Score = 5
+	stored, _ := adapter.GetSurveyData("survey")
+	if stored.Scores[0].Score != 3 {
+		t.Errorf("memory.Adapter.GetSurveyData() returned a reference to the stored data")
+	}
+
+	if err = adapter.DeleteSurveyData("survey"); err != nil {
+		t.Errorf("memory.Adapter.DeleteSurveyData() error = %v", err)
+	}
+	if _, err = adapter.GetSurveyData("survey"); errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("memory.Adapter.GetSurveyData() after delete error = %v, want not found", err)
+	}
+	if err = adapter.DeleteSurveyData("survey"); errors.Status(err) != utils.ErrorStatusNotFound {
+		t.Errorf("memory.Adapter.DeleteSurveyData() after delete error = %v, want not found", err)
+	}
+}
+
+func TestAdapter_SearchSurveyDatas(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+
+	now := time.Now().UTC()
+	surveyDatas := []model.SurveyData{
+		{ID: "old", AccountID: "account", Version: "v1", DateCreated: now.Add(-48 * time.Hour)},
+		{ID: "recent", AccountID: "account", Version: "v2", DateCreated: now.Add(-time.Hour)},
+		{ID: "latest", AccountID: "account", Version: "v2", DateCreated: now},
+		{ID: "other", AccountID: "other", Version: "v2", DateCreated: now},
+	}
+	for _, surveyData := range surveyDatas {
+		if err := adapter.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("memory.Adapter.CreateSurveyData() error = %v", err)
+		}
+	}
+
+	accountID := "account"
+	version := "v2"
+	from := now.Add(-24 * time.Hour)
+	tests := []struct {
+		name    string
+		search  model.UserDataSearch
+		wantIDs []string
+	}{
+		{"account", model.UserDataSearch{AccountID: &accountID}, []string{"latest", "recent", "old"}},
+		{"version", model.UserDataSearch{AccountID: &accountID, Version: &version}, []string{"latest", "recent"}},
+		{"date range", model.UserDataSearch{From: &from, To: &from}, []string{}},
+		{"from", model.UserDataSearch{AccountID: &accountID, From: &from}, []string{"latest", "recent"}},
+		{"page", model.UserDataSearch{AccountID: &accountID, Limit: 1, Offset: 1}, []string{"recent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := adapter.SearchSurveyDatas(tt.search)
+			if err != nil {
+				t.Fatalf("memory.Adapter.SearchSurveyDatas() error = %v", err)
+			}
+			gotIDs := []string{}
+			for _, surveyData := range got {
+				gotIDs = append(gotIDs, surveyData.ID)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("memory.Adapter.SearchSurveyDatas() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+
+	deleted, err := adapter.DeleteSurveyDatasByAccount("account")
+	if err != nil || deleted != 3 {
+		t.Errorf("memory.Adapter.DeleteSurveyDatasByAccount() = %d, %v, want 3", deleted, err)
+	}
+	if _, err = adapter.GetSurveyData("other"); err != nil {
+		t.Errorf("memory.Adapter.DeleteSurveyDatasByAccount() deleted the survey data of another account: %v", err)
+	}
+}
+
+func TestAdapter_UpdateSurveyDataRevision(t *testing.T) {
+	adapter := buildTestAdapter(t, "")
+
+	surveyData := model.SurveyData{ID: "survey", DateCreated: time.Now(), Revision: 1}
+	if err := adapter.CreateSurveyData(surveyData); err != nil {
+		t.Fatalf("memory.Adapter.CreateSurveyData() error = %v", err)
+	}
+
+	update := surveyData
+	update.Scores = []model.WorkstyleScore{{Workstyle: "Leadership", Score: 4}}
+	if err := adapter.UpdateSurveyData(update); err != nil {
+		t.Fatalf("memory.Adapter.UpdateSurveyData() error = %v", err)
+	}
+	stored, _ := adapter.GetSurveyData("survey")
+	if stored.Revision != 2 {
+		t.Errorf("memory.Adapter.UpdateSurveyData() revision = %d, want 2", stored.Revision)
+	}
+
+	// the update was made against a revision that is no longer current
+	err := adapter.UpdateSurveyData(update)
+	if errors.Status(err) != utils.ErrorStatusConflict {
+		t.Errorf("memory.Adapter.UpdateSurveyData() stale revision error = %v, want conflict", err)
+	}
+
+	update.Revision = 0
+	if err = adapter.UpdateSurveyData(update); err != nil {
+		t.Errorf("memory.Adapter.UpdateSurveyData() without revision error = %v", err)
+	}
+}
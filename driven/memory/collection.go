@@ -0,0 +1,201 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"sort"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// collection stores BSON encoded documents in insertion order, so that stored documents are copies and behave as they would in MongoDB
+//
+//	The changes made to a collection are recorded until taken to notify the storage listeners.
+type collection struct {
+	name    string
+	keys    []string
+	docs    map[string][]byte
+	changes []model.DataChange
+}
+
+func (c *collection) clone() *collection {
+	keys := make([]string, len(c.keys))
+	copy(keys, c.keys)
+	docs := make(map[string][]byte, len(c.docs))
+	for key, doc := range c.docs {
+		docs[key] = doc
+	}
+	return &collection{name: c.name, keys: keys, docs: docs, changes: make([]model.DataChange, 0)}
+}
+
+// takeChanges returns the changes recorded since the last call
+func (c *collection) takeChanges() []model.DataChange {
+	changes := c.changes
+	c.changes = make([]model.DataChange, 0)
+	return changes
+}
+
+func (c *collection) recordChange(operation string, key string) {
+	c.changes = append(c.changes, model.DataChange{Collection: c.name, Operation: operation, DocumentID: key, Date: time.Now().UTC()})
+}
+
+func (c *collection) contains(key string) bool {
+	_, ok := c.docs[key]
+	return ok
+}
+
+func (c *collection) insert(key string, doc interface{}) error {
+	if c.contains(key) {
+		return errors.Newf("%s: duplicate key %s", c.name, key)
+	}
+
+	bytes, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	c.keys = append(c.keys, key)
+	c.docs[key] = bytes
+	c.recordChange(model.DataChangeInsert, key)
+	return nil
+}
+
+func (c *collection) replace(key string, doc interface{}) error {
+	if !c.contains(key) {
+		return c.insert(key, doc)
+	}
+
+	bytes, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	c.docs[key] = bytes
+	c.recordChange(model.DataChangeUpdate, key)
+	return nil
+}
+
+func (c *collection) delete(key string) bool {
+	if !c.contains(key) {
+		return false
+	}
+
+	delete(c.docs, key)
+	for i, item := range c.keys {
+		if item == key {
+			c.keys = append(c.keys[:i], c.keys[i+1:]...)
+			break
+		}
+	}
+	c.recordChange(model.DataChangeDelete, key)
+	return true
+}
+
+// findOne decodes the document with the given key, returning nil if there is no such document
+func findOne[T any](c *collection, key string) (*T, error) {
+	bytes, ok := c.docs[key]
+	if !ok {
+		return nil, nil
+	}
+
+	var doc T
+	err := bson.Unmarshal(bytes, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// findAll decodes all documents in insertion order
+func findAll[T any](c *collection) ([]T, error) {
+	docs := make([]T, len(c.keys))
+	for i, key := range c.keys {
+		err := bson.Unmarshal(c.docs[key], &docs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// findPage decodes up to limit documents with keys after the given key, in key order
+func findPage[T any](c *collection, afterKey string, limit int) ([]T, error) {
+	keys := make([]string, 0)
+	for _, key := range c.keys {
+		if key > afterKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	docs := make([]T, len(keys))
+	for i, key := range keys {
+		err := bson.Unmarshal(c.docs[key], &docs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// userDataFields are the fields of a document filtered by a user data search
+type userDataFields struct {
+	id        string
+	accountID string
+	version   string
+	date      time.Time
+}
+
+// findSearch decodes the documents matching a user data search, from the most recent searched date and without a limit when it is zero, as MongoDB does
+func findSearch[T any](c *collection, search model.UserDataSearch, fields func(doc T) userDataFields) ([]T, error) {
+	docs, err := findAll[T](c)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]T, 0)
+	for _, doc := range docs {
+		docFields := fields(doc)
+		if (search.AccountID != nil && docFields.accountID != *search.AccountID) || (search.Version != nil && docFields.version != *search.Version) ||
+			(search.From != nil && docFields.date.Before(*search.From)) || (search.To != nil && docFields.date.After(*search.To)) {
+			continue
+		}
+		matching = append(matching, doc)
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		first, second := fields(matching[i]), fields(matching[j])
+		if !first.date.Equal(second.date) {
+			return first.date.After(second.date)
+		}
+		return first.id < second.id
+	})
+
+	if search.Offset >= len(matching) {
+		return []T{}, nil
+	}
+	matching = matching[search.Offset:]
+	if search.Limit > 0 && len(matching) > search.Limit {
+		matching = matching[:search.Limit]
+	}
+	return matching, nil
+}
+
+func newCollection(name string) *collection {
+	return &collection{name: name, keys: make([]string, 0), docs: make(map[string][]byte), changes: make([]model.DataChange, 0)}
+}
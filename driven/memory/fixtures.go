@@ -0,0 +1,81 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// loadFixtures seeds the collections from the <collection>.json files in the given directory, each containing a JSON array of documents
+func (a *Adapter) loadFixtures(directory string) error {
+	a.store.lock.Lock()
+	defer a.store.lock.Unlock()
+
+	db := a.store.db
+	err := loadFixture(directory, db.configs, func(config model.Config) string { return config.ID })
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeConfig, nil, err)
+	}
+	err = loadFixture(directory, db.occupationData, func(occupationData model.OccupationData) string { return occupationData.Code })
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeOccupationData, nil, err)
+	}
+	err = loadFixture(directory, db.matchResults, func(userMatchingResult model.UserMatchingResult) string { return userMatchingResult.ID })
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeUserMatchingResult, nil, err)
+	}
+	err = loadFixture(directory, db.surveyResponses, func(surveyData model.SurveyData) string { return surveyData.ID })
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeSurveyData, nil, err)
+	}
+
+	// the fixtures are the initial data rather than changes to notify
+	db.takeChanges()
+
+	a.store.logger.Infof("loaded storage fixtures from %s", directory)
+	return nil
+}
+
+func loadFixture[T any](directory string, coll *collection, key func(T) string) error {
+	path := filepath.Join(directory, coll.name+".json")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionRead, "fixture file", &logutils.FieldArgs{"path": path}, err)
+	}
+
+	var docs []T
+	err = json.Unmarshal(bytes, &docs)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUnmarshal, "fixture file", &logutils.FieldArgs{"path": path}, err)
+	}
+
+	for _, doc := range docs {
+		err = coll.insert(key(doc), doc)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, "fixture", &logutils.FieldArgs{"path": path}, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// InsertIdempotencyKey inserts an idempotencyKey, returning false when the account already has an unexpired key with the same value
+func (a *Adapter) InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) (bool, error) {
+	inserted := false
+	err := a.write(func(db *database) error {
+		docKey := idempotencyKeyDocKey(idempotencyKey.AccountID, idempotencyKey.Key)
+		existing, err := findOne[model.IdempotencyKey](db.idempotencyKeys, docKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+		}
+		if existing != nil && existing.DateExpires.After(idempotencyKey.DateCreated) {
+			return nil
+		}
+
+		err = db.idempotencyKeys.replace(docKey, idempotencyKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+		}
+		inserted = true
+		return nil
+	})
+	return inserted, err
+}
+
+// FindIdempotencyKey finds the idempotencyKey of an account with the given value
+func (a *Adapter) FindIdempotencyKey(accountID string, key string) (*model.IdempotencyKey, error) {
+	var idempotencyKey *model.IdempotencyKey
+	err := a.read(func(db *database) error {
+		var err error
+		idempotencyKey, err = findOne[model.IdempotencyKey](db.idempotencyKeys, idempotencyKeyDocKey(accountID, key))
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+
+	return idempotencyKey, nil
+}
+
+// UpdateIdempotencyKey sets the ID of the resource created by the request of an idempotencyKey
+func (a *Adapter) UpdateIdempotencyKey(idempotencyKey model.IdempotencyKey) error {
+	return a.write(func(db *database) error {
+		docKey := idempotencyKeyDocKey(idempotencyKey.AccountID, idempotencyKey.Key)
+		existing, err := findOne[model.IdempotencyKey](db.idempotencyKeys, docKey)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+		}
+		if existing == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}).SetStatus(utils.ErrorStatusNotFound)
+		}
+
+		existing.ResourceID = idempotencyKey.ResourceID
+		err = db.idempotencyKeys.replace(docKey, existing)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteIdempotencyKey deletes the idempotencyKey of an account with the given value
+func (a *Adapter) DeleteIdempotencyKey(accountID string, key string) error {
+	return a.write(func(db *database) error {
+		db.idempotencyKeys.delete(idempotencyKeyDocKey(accountID, key))
+		return nil
+	})
+}
+
+// DeleteIdempotencyKeysByAccount deletes the idempotencyKeys of an account, returning the number deleted
+func (a *Adapter) DeleteIdempotencyKeysByAccount(accountID string) (int64, error) {
+	var count int64
+	err := a.write(func(db *database) error {
+		idempotencyKeys, err := findAll[model.IdempotencyKey](db.idempotencyKeys)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": accountID}, err)
+		}
+		for _, idempotencyKey := range idempotencyKeys {
+			if idempotencyKey.AccountID == accountID && db.idempotencyKeys.delete(idempotencyKeyDocKey(accountID, idempotencyKey.Key)) {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// idempotencyKeyDocKey returns the key of the document storing the idempotency key of an account, unique as the unique index in MongoDB
+func idempotencyKeyDocKey(accountID string, key string) string {
+	return accountID + "/" + key
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// InsertMatchingEvent inserts a matching event
+func (a *Adapter) InsertMatchingEvent(event model.MatchingEvent) error {
+	return a.write(func(db *database) error {
+		err := db.matchingEvents.insert(event.ID, event)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeMatchingEvent, &logutils.FieldArgs{"_id": event.ID}, err)
+		}
+		return nil
+	})
+}
+
+// GetMatchingEvent finds a matching event by id
+//
+//	Expired events are only removed by the TTL index of MongoDB, so they are returned until the adapter is discarded.
+func (a *Adapter) GetMatchingEvent(id string) (*model.MatchingEvent, error) {
+	var event *model.MatchingEvent
+	err := a.read(func(db *database) error {
+		var err error
+		event, err = findOne[model.MatchingEvent](db.matchingEvents, id)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeMatchingEvent, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if event == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeMatchingEvent, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// GetOccupationData finds occupationData by code
+func (a *Adapter) GetOccupationData(code string) (*model.OccupationData, error) {
+	var data *model.OccupationData
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findOne[model.OccupationData](db.occupationData, code)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, &logutils.FieldArgs{"code": code}, err)
+	}
+	if data == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"code": code}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	return data, nil
+}
+
+// GetAllOccupationDatas finds all occupationData
+func (a *Adapter) GetAllOccupationDatas() ([]model.OccupationData, error) {
+	var data []model.OccupationData
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findAll[model.OccupationData](db.occupationData)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+
+	return data, nil
+}
+
+// InsertOccupationData inserts a new occupationData
+func (a *Adapter) InsertOccupationData(occupationData model.OccupationData) error {
+	return a.write(func(db *database) error {
+		if db.occupationData.contains(occupationData.Code) {
+			return errors.ErrorData(logutils.StatusFound, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}).SetStatus(utils.ErrorStatusExists)
+		}
+
+		err := db.occupationData.insert(occupationData.Code, occupationData)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}, err)
+		}
+		return nil
+	})
+}
+
+// UpdateOccupationData replaces the occupationData with the same code
+func (a *Adapter) UpdateOccupationData(occupationData model.OccupationData) error {
+	return a.write(func(db *database) error {
+		if !db.occupationData.contains(occupationData.Code) {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}).SetStatus(utils.ErrorStatusNotFound)
+		}
+
+		err := db.occupationData.replace(occupationData.Code, occupationData)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionReplace, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteOccupationData deletes the occupationData with the given code
+func (a *Adapter) DeleteOccupationData(code string) error {
+	return a.write(func(db *database) error {
+		if !db.occupationData.delete(code) {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"code": code}).SetStatus(utils.ErrorStatusNotFound)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	typeRetentionData logutils.MessageDataType = "retention data"
+)
+
+// retentionDates holds the dates of a document used by the retention policies
+type retentionDates struct {
+	DateCreated time.Time  `bson:"date_created"`
+	DateUpdated *time.Time `bson:"date_updated"`
+}
+
+func (d retentionDates) lastUpdated() time.Time {
+	if d.DateUpdated != nil {
+		return *d.DateUpdated
+	}
+	return d.DateCreated
+}
+
+// retentionCollection returns the collection for the given retention collection name
+func retentionCollection(db *database, name string) (*collection, error) {
+	switch name {
+	case model.RetentionCollectionSurveyResponses:
+		return db.surveyResponses, nil
+	case model.RetentionCollectionMatchResults:
+		return db.matchResults, nil
+	case model.RetentionCollectionMatchResultsHistory:
+		return db.matchResultsHistory, nil
+	}
+	return nil, errors.ErrorData(logutils.StatusInvalid, typeRetentionData, &logutils.FieldArgs{"collection": name})
+}
+
+// GetDataRetentionStatus counts the documents in the collection last updated before the cutoff, and finds the earliest last update at or after the cutoff
+func (a *Adapter) GetDataRetentionStatus(collection string, cutoff time.Time) (int64, *time.Time, error) {
+	var count int64
+	var next *time.Time
+	err := a.read(func(db *database) error {
+		coll, err := retentionCollection(db, collection)
+		if err != nil {
+			return err
+		}
+
+		docs, err := findAll[retentionDates](coll)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, typeRetentionData, &logutils.FieldArgs{"collection": collection}, err)
+		}
+		for _, doc := range docs {
+			lastUpdated := doc.lastUpdated()
+			if lastUpdated.Before(cutoff) {
+				count++
+			} else if next == nil || lastUpdated.Before(*next) {
+				next = &lastUpdated
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return count, next, nil
+}
+
+// DeleteDataUpdatedBefore deletes the documents in the collection last updated before the cutoff
+func (a *Adapter) DeleteDataUpdatedBefore(collection string, cutoff time.Time) (int64, error) {
+	var count int64
+	err := a.write(func(db *database) error {
+		coll, err := retentionCollection(db, collection)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range append([]string{}, coll.keys...) {
+			doc, err := findOne[retentionDates](coll, key)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionFind, typeRetentionData, &logutils.FieldArgs{"collection": collection}, err)
+			}
+			if doc.lastUpdated().Before(cutoff) {
+				coll.delete(key)
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// GetSurveyData finds surveyData by id
+func (a *Adapter) GetSurveyData(id string) (*model.SurveyData, error) {
+	var data *model.SurveyData
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findOne[model.SurveyData](db.surveyResponses, id)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if data == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	return data, nil
+}
+
+// FindSurveyDatas finds up to limit surveyDatas with IDs after afterID, ordered by ID
+func (a *Adapter) FindSurveyDatas(afterID string, limit int) ([]model.SurveyData, error) {
+	var data []model.SurveyData
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findPage[model.SurveyData](db.surveyResponses, afterID, limit)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"after_id": afterID}, err)
+	}
+
+	return data, nil
+}
+
+// SearchSurveyDatas finds the surveyDatas matching the search, from the most recently created
+func (a *Adapter) SearchSurveyDatas(search model.UserDataSearch) ([]model.SurveyData, error) {
+	var data []model.SurveyData
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findSearch(db.surveyResponses, search, func(surveyData model.SurveyData) userDataFields {
+			return userDataFields{id: surveyData.ID, accountID: surveyData.AccountID, version: surveyData.Version, date: surveyData.DateCreated}
+		})
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, nil, err)
+	}
+
+	return data, nil
+}
+
+// CreateSurveyData inserts a new surveyData
+func (a *Adapter) CreateSurveyData(surveyData model.SurveyData) error {
+	return a.write(func(db *database) error {
+		err := db.surveyResponses.insert(surveyData.ID, surveyData)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeSurveyData, nil, err)
+		}
+		return nil
+	})
+}
+
+// UpdateSurveyData updates a surveyData, only if its revision matches when the surveyData revision is set
+func (a *Adapter) UpdateSurveyData(surveyData model.SurveyData) error {
+	return a.write(func(db *database) error {
+		existing, err := findOne[model.SurveyData](db.surveyResponses, surveyData.ID)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeSurveyData, &logutils.FieldArgs{"_id": surveyData.ID}, err)
+		}
+		if surveyData.Revision > 0 && (existing == nil || existing.Revision != surveyData.Revision) {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeSurveyData, &logutils.FieldArgs{"_id": surveyData.ID, "revision": surveyData.Revision}).SetStatus(utils.ErrorStatusConflict)
+		}
+		if existing == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, &logutils.FieldArgs{"_id": surveyData.ID}).SetStatus(utils.ErrorStatusNotFound)
+		}
+
+		now := time.Now()
+		existing.Scores = surveyData.Scores
+		existing.DateUpdated = &now
+		existing.Revision++
+		err = db.surveyResponses.replace(existing.ID, existing)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeSurveyData, &logutils.FieldArgs{"_id": surveyData.ID}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteSurveyData deletes a surveyData
+func (a *Adapter) DeleteSurveyData(id string) error {
+	return a.write(func(db *database) error {
+		if !db.surveyResponses.delete(id) {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+		}
+		return nil
+	})
+}
+
+// DeleteSurveyDatasByAccount deletes the surveyDatas of an account, returning the number deleted
+func (a *Adapter) DeleteSurveyDatasByAccount(accountID string) (int64, error) {
+	var count int64
+	err := a.write(func(db *database) error {
+		surveyDatas, err := findAll[model.SurveyData](db.surveyResponses)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"account_id": accountID}, err)
+		}
+		for _, surveyData := range surveyDatas {
+			if surveyData.AccountID == accountID && db.surveyResponses.delete(surveyData.ID) {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// InsertUserDataExport inserts a userDataExport
+func (a *Adapter) InsertUserDataExport(userDataExport model.UserDataExport) error {
+	return a.write(func(db *database) error {
+		err := db.userDataExports.insert(userDataExport.ID, userDataExport)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserDataExport, &logutils.FieldArgs{"_id": userDataExport.ID}, err)
+		}
+		return nil
+	})
+}
+
+// GetUserDataExport finds a userDataExport by id
+//
+//	Expired exports are only removed by the TTL index of MongoDB, so they are returned until deleted with their account.
+func (a *Adapter) GetUserDataExport(id string) (*model.UserDataExport, error) {
+	var userDataExport *model.UserDataExport
+	err := a.read(func(db *database) error {
+		var err error
+		userDataExport, err = findOne[model.UserDataExport](db.userDataExports, id)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if userDataExport == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	return userDataExport, nil
+}
+
+// UpdateUserDataExport sets the status, data and expiry date of a userDataExport
+func (a *Adapter) UpdateUserDataExport(userDataExport model.UserDataExport) error {
+	return a.write(func(db *database) error {
+		existing, err := findOne[model.UserDataExport](db.userDataExports, userDataExport.ID)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserDataExport, &logutils.FieldArgs{"_id": userDataExport.ID}, err)
+		}
+		if existing == nil {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, &logutils.FieldArgs{"_id": userDataExport.ID}).SetStatus(utils.ErrorStatusNotFound)
+		}
+
+		existing.Status = userDataExport.Status
+		existing.Data = userDataExport.Data
+		existing.DateExpires = userDataExport.DateExpires
+		err = db.userDataExports.replace(userDataExport.ID, existing)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserDataExport, &logutils.FieldArgs{"_id": userDataExport.ID}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteUserDataExportsByAccount deletes the userDataExports of an account, returning the number deleted
+func (a *Adapter) DeleteUserDataExportsByAccount(accountID string) (int64, error) {
+	var count int64
+	err := a.write(func(db *database) error {
+		userDataExports, err := findAll[model.UserDataExport](db.userDataExports)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserDataExport, &logutils.FieldArgs{"account_id": accountID}, err)
+		}
+		for _, userDataExport := range userDataExports {
+			if userDataExport.AccountID == accountID && db.userDataExports.delete(userDataExport.ID) {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
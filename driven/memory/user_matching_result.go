@@ -0,0 +1,126 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"application/utils"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// GetUserMatchingResult finds userMatchingResult by id
+func (a *Adapter) GetUserMatchingResult(id string) (*model.UserMatchingResult, error) {
+	var data *model.UserMatchingResult
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findOne[model.UserMatchingResult](db.matchResults, id)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": id}, err)
+	}
+	if data == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	return data, nil
+}
+
+// FindUserMatchingResults finds up to limit userMatchingResults with IDs after afterID, ordered by ID
+func (a *Adapter) FindUserMatchingResults(afterID string, limit int) ([]model.UserMatchingResult, error) {
+	var data []model.UserMatchingResult
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findPage[model.UserMatchingResult](db.matchResults, afterID, limit)
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"after_id": afterID}, err)
+	}
+
+	return data, nil
+}
+
+// SearchUserMatchingResults finds the userMatchingResults matching the search, from the most recently updated
+func (a *Adapter) SearchUserMatchingResults(search model.UserDataSearch) ([]model.UserMatchingResult, error) {
+	var data []model.UserMatchingResult
+	err := a.read(func(db *database) error {
+		var err error
+		data, err = findSearch(db.matchResults, search, func(result model.UserMatchingResult) userDataFields {
+			fields := userDataFields{id: result.ID, accountID: result.ID, version: result.Version, date: result.DateCreated}
+			if result.DateUpdated != nil {
+				fields.date = *result.DateUpdated
+			}
+			return fields
+		})
+		return err
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, nil, err)
+	}
+
+	return data, nil
+}
+
+// InsertUserMatchingResult inserts a new userMatchingResult
+func (a *Adapter) InsertUserMatchingResult(userMatchingResult model.UserMatchingResult) error {
+	return a.write(func(db *database) error {
+		err := db.matchResults.insert(userMatchingResult.ID, userMatchingResult)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserMatchingResult, nil, err)
+		}
+		return nil
+	})
+}
+
+// SaveUserMatchingResult inserts or updates the version and matches of a userMatchingResult, only updating it if its revision matches when the userMatchingResult revision is set
+func (a *Adapter) SaveUserMatchingResult(userMatchingResult model.UserMatchingResult) error {
+	return a.write(func(db *database) error {
+		existing, err := findOne[model.UserMatchingResult](db.matchResults, userMatchingResult.ID)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": userMatchingResult.ID}, err)
+		}
+		if userMatchingResult.Revision > 0 && (existing == nil || existing.Revision != userMatchingResult.Revision) {
+			return errors.ErrorData(logutils.StatusInvalid, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": userMatchingResult.ID, "revision": userMatchingResult.Revision}).SetStatus(utils.ErrorStatusConflict)
+		}
+
+		now := time.Now().UTC()
+		if existing == nil {
+			existing = &model.UserMatchingResult{ID: userMatchingResult.ID, DateCreated: now}
+		}
+		existing.Version = userMatchingResult.Version
+		existing.Matches = userMatchingResult.Matches
+		existing.DateUpdated = &now
+		existing.Revision++
+		err = db.matchResults.replace(existing.ID, existing)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": userMatchingResult.ID}, err)
+		}
+		return nil
+	})
+}
+
+// DeleteUserMatchingResult deletes a userMatchingResult
+func (a *Adapter) DeleteUserMatchingResult(id string) error {
+	return a.write(func(db *database) error {
+		if !db.matchResults.delete(id) {
+			return errors.ErrorData(logutils.StatusMissing, model.TypeUserMatchingResult, &logutils.FieldArgs{"_id": id}).SetStatus(utils.ErrorStatusNotFound)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"application/core/model"
+	"sort"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// InsertUserMatchingResultHistory inserts a userMatchingResultHistory
+func (a *Adapter) InsertUserMatchingResultHistory(history model.UserMatchingResultHistory) error {
+	return a.write(func(db *database) error {
+		err := db.matchResultsHistory.insert(history.ID, history)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"id": history.ID}, err)
+		}
+		return nil
+	})
+}
+
+// FindUserMatchingResultHistories finds the userMatchingResultHistories of an account, from the oldest
+func (a *Adapter) FindUserMatchingResultHistories(accountID string) ([]model.UserMatchingResultHistory, error) {
+	histories := make([]model.UserMatchingResultHistory, 0)
+	err := a.read(func(db *database) error {
+		all, err := findAll[model.UserMatchingResultHistory](db.matchResultsHistory)
+		if err != nil {
+			return err
+		}
+		for _, history := range all {
+			if history.AccountID == accountID {
+				histories = append(histories, history)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		return histories[i].DateCreated.Before(histories[j].DateCreated)
+	})
+	return histories, nil
+}
+
+// DeleteUserMatchingResultHistoriesByAccount deletes the userMatchingResultHistories of an account, returning the number deleted
+func (a *Adapter) DeleteUserMatchingResultHistoriesByAccount(accountID string) (int64, error) {
+	var count int64
+	err := a.write(func(db *database) error {
+		histories, err := findAll[model.UserMatchingResultHistory](db.matchResultsHistory)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"account_id": accountID}, err)
+		}
+		for _, history := range histories {
+			if history.AccountID == accountID && db.matchResultsHistory.delete(history.ID) {
+				count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
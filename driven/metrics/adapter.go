@@ -0,0 +1,118 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "skills_to_jobs"
+
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// Adapter records the metrics of the service in a Prometheus registry and serves them in the Prometheus text format
+type Adapter struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+
+	matchings                 *prometheus.CounterVec
+	matchingDuration          prometheus.Histogram
+	matchingOccupationsScored prometheus.Histogram
+	matchingQueueDepth        prometheus.Gauge
+	databaseOperationDuration *prometheus.HistogramVec
+	changeStreamReconnects    *prometheus.CounterVec
+}
+
+// Handler returns the handler serving the metrics
+func (a *Adapter) Handler() http.Handler {
+	return promhttp.HandlerFor(a.registry, promhttp.HandlerOpts{Registry: a.registry})
+}
+
+// ObserveRequest records an HTTP request handled on the route with the given path template
+func (a *Adapter) ObserveRequest(method string, route string, statusCode int, duration time.Duration) {
+	status := strconv.Itoa(statusCode)
+	a.httpRequests.WithLabelValues(method, route, status).Inc()
+	a.httpRequestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// ObserveMatching records an occupation matching run, which scored the given number of occupations unless it failed
+func (a *Adapter) ObserveMatching(duration time.Duration, occupationsScored int, err error) {
+	if err != nil {
+		a.matchings.WithLabelValues(statusError).Inc()
+		return
+	}
+	a.matchings.WithLabelValues(statusSuccess).Inc()
+	a.matchingDuration.Observe(duration.Seconds())
+	a.matchingOccupationsScored.Observe(float64(occupationsScored))
+}
+
+// SetMatchingQueueDepth records the number of occupation matchings started and not yet completed
+func (a *Adapter) SetMatchingQueueDepth(depth int64) {
+	a.matchingQueueDepth.Set(float64(depth))
+}
+
+// ObserveDatabaseOperation records an operation on a database collection
+func (a *Adapter) ObserveDatabaseOperation(collection string, operation string, duration time.Duration, err error) {
+	status := statusSuccess
+	if err != nil {
+		status = statusError
+	}
+	a.databaseOperationDuration.WithLabelValues(collection, operation, status).Observe(duration.Seconds())
+}
+
+// IncChangeStreamReconnects records a change stream reopened on a database collection after an error
+func (a *Adapter) IncChangeStreamReconnects(collection string) {
+	a.changeStreamReconnects.WithLabelValues(collection).Inc()
+}
+
+// NewMetricsAdapter creates a new metrics adapter, which also records the Go runtime and process metrics
+func NewMetricsAdapter() *Adapter {
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	return &Adapter{
+		registry: registry,
+		httpRequests: factory.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Subsystem: "http", Name: "requests_total",
+			Help: "Number of HTTP requests handled per route and status code."}, []string{"method", "route", "status_code"}),
+		httpRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{Namespace: namespace, Subsystem: "http", Name: "request_duration_seconds",
+			Help: "Duration of the HTTP requests per route and status code.", Buckets: prometheus.DefBuckets}, []string{"method", "route", "status_code"}),
+		matchings: factory.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Subsystem: "matching", Name: "runs_total",
+			Help: "Number of occupation matching runs per status."}, []string{"status"}),
+		matchingDuration: factory.NewHistogram(prometheus.HistogramOpts{Namespace: namespace, Subsystem: "matching", Name: "duration_seconds",
+			Help: "Duration of the successful occupation matching runs.", Buckets: prometheus.ExponentialBuckets(0.05, 2, 10)}),
+		matchingOccupationsScored: factory.NewHistogram(prometheus.HistogramOpts{Namespace: namespace, Subsystem: "matching", Name: "occupations_scored",
+			Help: "Number of occupations scored per successful occupation matching run.", Buckets: prometheus.ExponentialBuckets(10, 2, 10)}),
+		matchingQueueDepth: factory.NewGauge(prometheus.GaugeOpts{Namespace: namespace, Subsystem: "matching", Name: "queue_depth",
+			Help: "Number of occupation matchings started and not yet completed."}),
+		databaseOperationDuration: factory.NewHistogramVec(prometheus.HistogramOpts{Namespace: namespace, Subsystem: "mongo", Name: "operation_duration_seconds",
+			Help: "Duration of the MongoDB operations per collection, operation and status.", Buckets: prometheus.ExponentialBuckets(0.001, 2, 14)},
+			[]string{"collection", "operation", "status"}),
+		changeStreamReconnects: factory.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Subsystem: "mongo", Name: "change_stream_reconnects_total",
+			Help: "Number of MongoDB change streams reopened after an error per collection."}, []string{"collection"}),
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"application/driven/metrics"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdapter_Handler(t *testing.T) {
+	adapter := metrics.NewMetricsAdapter()
+	adapter.ObserveMatching(2*time.Second, 900, nil)
+	adapter.ObserveMatching(time.Second, 0, errors.New("storage unavailable"))
+	adapter.SetMatchingQueueDepth(3)
+	adapter.ObserveDatabaseOperation("match_results", "find_one", 5*time.Millisecond, nil)
+	adapter.IncChangeStreamReconnects("configs")
+
+	recorder := httptest.NewRecorder()
+	adapter.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`skills_to_jobs_matching_runs_total{status="success"} 1`,
+		`skills_to_jobs_matching_runs_total{status="error"} 1`,
+		`skills_to_jobs_matching_duration_seconds_count 1`,
+		`skills_to_jobs_matching_occupations_scored_sum 900`,
+		`skills_to_jobs_matching_queue_depth 3`,
+		`skills_to_jobs_mongo_operation_duration_seconds_count{collection="match_results",operation="find_one",status="success"} 1`,
+		`skills_to_jobs_mongo_change_stream_reconnects_total{collection="configs"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+}
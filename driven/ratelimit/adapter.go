@@ -0,0 +1,98 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"application/core/model"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the minimum time between removals of the idle buckets
+const sweepInterval time.Duration = time.Minute
+
+// bucket holds the tokens left to a client, as of the last time it was refilled
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// Adapter implements the RateLimits interface with token buckets kept in process
+//
+//	The limits only apply to the requests handled by this instance. Buckets are removed once they are full again.
+type Adapter struct {
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	lock      *sync.Mutex
+}
+
+// Take removes a request from the bucket of the given key, refilling it first for the time elapsed since it was last used
+func (a *Adapter) Take(key string, limit model.RateLimit, now time.Time) (model.RateLimitStatus, error) {
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period().Seconds()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.sweep(now)
+
+	b, ok := a.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		a.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	status := model.RateLimitStatus{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		status.Allowed = true
+	} else {
+		status.RetryAfter = secondsDuration((1 - b.tokens) / rate)
+	}
+	status.Remaining = int(b.tokens)
+	status.Reset = secondsDuration((capacity - b.tokens) / rate)
+	b.full = now.Add(status.Reset)
+
+	return status, nil
+}
+
+// sweep removes the buckets which have been refilled since they were last used
+func (a *Adapter) sweep(now time.Time) {
+	if now.Sub(a.lastSweep) < sweepInterval {
+		return
+	}
+	a.lastSweep = now
+
+	for key, b := range a.buckets {
+		if !now.Before(b.full) {
+			delete(a.buckets, key)
+		}
+	}
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+// NewRateLimitAdapter creates a new in process rate limits adapter
+func NewRateLimitAdapter() *Adapter {
+	return &Adapter{buckets: map[string]*bucket{}, lock: &sync.Mutex{}}
+}
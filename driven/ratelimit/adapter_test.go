@@ -0,0 +1,53 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"application/core/model"
+	"application/driven/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestAdapter_Take(t *testing.T) {
+	adapter := ratelimit.NewRateLimitAdapter()
+	limit := model.RateLimit{Route: "POST /api/survey-data", Requests: 3, PeriodSeconds: 30}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < limit.Requests; i++ {
+		status, _ := adapter.Take("user", limit, now)
+		if !status.Allowed || status.Remaining != limit.Requests-i-1 {
+			t.Fatalf("Take() request %d = %+v, want allowed with %d remaining", i, status, limit.Requests-i-1)
+		}
+	}
+	if status, _ := adapter.Take("other", limit, now); !status.Allowed {
+		t.Errorf("Take() other key = %+v, want allowed", status)
+	}
+
+	status, _ := adapter.Take("user", limit, now)
+	if status.Allowed || status.RetryAfter != 10*time.Second || status.Reset != 30*time.Second {
+		t.Errorf("Take() exceeded = %+v, want retry after 10s and reset after 30s", status)
+	}
+
+	// one request is refilled every 10 seconds
+	status, _ = adapter.Take("user", limit, now.Add(10*time.Second))
+	if !status.Allowed || status.Remaining != 0 {
+		t.Errorf("Take() after refill = %+v, want allowed with 0 remaining", status)
+	}
+	status, _ = adapter.Take("user", limit, now.Add(time.Hour))
+	if !status.Allowed || status.Remaining != limit.Requests-1 {
+		t.Errorf("Take() after full refill = %+v, want allowed with %d remaining", status, limit.Requests-1)
+	}
+}
@@ -17,6 +17,7 @@ package storage
 import (
 	"application/core/interfaces"
 	"application/core/model"
+	"application/utils"
 	"context"
 	"fmt"
 	"strconv"
@@ -32,6 +33,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Adapter implements the Storage interface
@@ -39,9 +41,14 @@ type Adapter struct {
 	db *database
 
 	context mongo.SessionContext
+	// ctx is the context of the operations outside of a transaction, such as the trace of a request
+	ctx context.Context
 
 	cachedConfigs *syncmap.Map
 	configsLock   *sync.RWMutex
+
+	cachedOccupationDatas *syncmap.Map
+	occupationDatasLock   *sync.RWMutex
 }
 
 // Start starts the storage
@@ -61,6 +68,21 @@ func (a *Adapter) Start() error {
 		return errors.WrapErrorAction(logutils.ActionCache, model.TypeConfig, nil, err)
 	}
 
+	//cache the occupation data
+	err = a.cacheOccupationDatas()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCache, model.TypeOccupationData, nil, err)
+	}
+
+	return nil
+}
+
+// Stop stops watching the data changes and disconnects from the database until the context is done
+func (a *Adapter) Stop(ctx context.Context) error {
+	err := a.db.stop(ctx)
+	if err != nil {
+		return errors.WrapErrorAction("stopping", "storage adapter", nil, err)
+	}
 	return nil
 }
 
@@ -71,7 +93,23 @@ func (a *Adapter) RegisterStorageListener(listener interfaces.StorageListener) {
 
 // Creates a new Adapter with provided context
 func (a *Adapter) withContext(context mongo.SessionContext) *Adapter {
-	return &Adapter{db: a.db, context: context, cachedConfigs: a.cachedConfigs, configsLock: a.configsLock}
+	return &Adapter{db: a.db, context: context, ctx: a.ctx, cachedConfigs: a.cachedConfigs, configsLock: a.configsLock,
+		cachedOccupationDatas: a.cachedOccupationDatas, occupationDatasLock: a.occupationDatasLock}
+}
+
+// WithContext returns a copy of the storage performing its operations with the given context
+func (a *Adapter) WithContext(ctx context.Context) interfaces.Storage {
+	adapter := a.withContext(a.context)
+	adapter.ctx = ctx
+	return adapter
+}
+
+// operationContext returns the context of the operations, which is the session context within a transaction
+func (a *Adapter) operationContext() context.Context {
+	if a.context != nil {
+		return a.context
+	}
+	return a.ctx
 }
 
 // cacheConfigs caches the configs from the DB
@@ -99,6 +137,10 @@ func (a *Adapter) setCachedConfigs(configs []model.Config) {
 		switch config.Type {
 		case model.ConfigTypeEnv:
 			err = parseConfigsData[model.EnvConfigData](&config)
+		case model.ConfigTypeRetention:
+			err = parseConfigsData[model.RetentionConfigData](&config)
+		case model.ConfigTypeRateLimit:
+			err = parseConfigsData[model.RateLimitConfigData](&config)
 		default:
 			err = parseConfigsData[map[string]interface{}](&config)
 		}
@@ -186,7 +228,7 @@ func (a *Adapter) loadConfigs() ([]model.Config, error) {
 	filter := bson.M{}
 
 	var configs []model.Config
-	err := a.db.configs.Find(a.context, filter, &configs, nil)
+	err := a.db.configs.Find(a.operationContext(), filter, &configs, nil)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
 	}
@@ -211,7 +253,7 @@ func (a *Adapter) FindConfigs(configType *string) ([]model.Config, error) {
 
 // InsertConfig inserts a new config
 func (a *Adapter) InsertConfig(config model.Config) error {
-	_, err := a.db.configs.InsertOne(a.context, config)
+	_, err := a.db.configs.InsertOne(a.operationContext(), config)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeConfig, nil, err)
 	}
@@ -219,9 +261,12 @@ func (a *Adapter) InsertConfig(config model.Config) error {
 	return nil
 }
 
-// UpdateConfig updates an existing config
+// UpdateConfig updates an existing config, only if its revision matches when the config revision is set
 func (a *Adapter) UpdateConfig(config model.Config) error {
 	filter := bson.M{"_id": config.ID}
+	if config.Revision > 0 {
+		filter["revision"] = config.Revision
+	}
 	update := bson.D{
 		primitive.E{Key: "$set", Value: bson.D{
 			primitive.E{Key: "type", Value: config.Type},
@@ -231,11 +276,15 @@ func (a *Adapter) UpdateConfig(config model.Config) error {
 			primitive.E{Key: "data", Value: config.Data},
 			primitive.E{Key: "date_updated", Value: config.DateUpdated},
 		}},
+		primitive.E{Key: "$inc", Value: bson.D{primitive.E{Key: "revision", Value: 1}}},
 	}
-	_, err := a.db.configs.UpdateOne(a.context, filter, update, nil)
+	res, err := a.db.configs.UpdateOne(a.operationContext(), filter, update, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeConfig, &logutils.FieldArgs{"id": config.ID}, err)
 	}
+	if config.Revision > 0 && res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeConfig, filterArgs(filter)).SetStatus(utils.ErrorStatusConflict)
+	}
 
 	return nil
 }
@@ -243,7 +292,7 @@ func (a *Adapter) UpdateConfig(config model.Config) error {
 // DeleteConfig deletes a configuration from storage
 func (a *Adapter) DeleteConfig(id string) error {
 	delFilter := bson.M{"_id": id}
-	_, err := a.db.configs.DeleteMany(a.context, delFilter, nil)
+	_, err := a.db.configs.DeleteMany(a.operationContext(), delFilter, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeConfig, &logutils.FieldArgs{"id": id}, err)
 	}
@@ -272,10 +321,13 @@ func (a *Adapter) PerformTransaction(transaction func(storage interfaces.Storage
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionStart, "mongo session", nil, err)
 	}
-	context := context.Background()
-	defer session.EndSession(context)
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	defer session.EndSession(ctx)
 
-	_, err = session.WithTransaction(context, callback)
+	_, err = session.WithTransaction(ctx, callback)
 	if err != nil {
 		return errors.WrapErrorAction("performing", logutils.TypeTransaction, nil, err)
 	}
@@ -290,8 +342,38 @@ func filterArgs(filter bson.M) *logutils.FieldArgs {
 	return &args
 }
 
+// userDataSearchFilter returns the filter of a user data search on the collection storing the account ID and the searched date in the given fields
+func userDataSearchFilter(search model.UserDataSearch, accountIDField string, dateField string) bson.M {
+	filter := bson.M{}
+	if search.AccountID != nil {
+		filter[accountIDField] = *search.AccountID
+	}
+	if search.Version != nil {
+		filter["version"] = *search.Version
+	}
+	dateFilter := bson.M{}
+	if search.From != nil {
+		dateFilter["$gte"] = *search.From
+	}
+	if search.To != nil {
+		dateFilter["$lte"] = *search.To
+	}
+	if len(dateFilter) > 0 {
+		filter[dateField] = dateFilter
+	}
+	return filter
+}
+
+// userDataSearchOptions returns the options of a user data search, sorted by the searched date from the most recent
+func userDataSearchOptions(search model.UserDataSearch, dateField string) *options.FindOptions {
+	return options.Find().SetSort(bson.D{primitive.E{Key: dateField, Value: -1}, primitive.E{Key: "_id", Value: 1}}).
+		SetSkip(int64(search.Offset)).SetLimit(int64(search.Limit))
+}
+
 // NewStorageAdapter creates a new storage adapter instance
-func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout string, logger *logs.Logger) *Adapter {
+//
+//	Survey scores and matching results are encrypted with the given master keys, as described in newEncryption.
+func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout string, encryptionKeys string, metrics Metrics, logger *logs.Logger) *Adapter {
 	timeout, err := strconv.Atoi(mongoTimeout)
 	if err != nil {
 		logger.Infof("Set default timeout - 2000")
@@ -301,6 +383,12 @@ func NewStorageAdapter(mongoDBAuth string, mongoDBName string, mongoTimeout stri
 	cachedConfigs := &syncmap.Map{}
 	configsLock := &sync.RWMutex{}
 
-	db := &database{mongoDBAuth: mongoDBAuth, mongoDBName: mongoDBName, mongoTimeout: time.Millisecond * time.Duration(timeout), logger: logger}
-	return &Adapter{db: db, cachedConfigs: cachedConfigs, configsLock: configsLock}
+	cachedOccupationDatas := &syncmap.Map{}
+	occupationDatasLock := &sync.RWMutex{}
+
+	db := &database{mongoDBAuth: mongoDBAuth, mongoDBName: mongoDBName, mongoTimeout: time.Millisecond * time.Duration(timeout),
+		encryptionKeys: encryptionKeys, metrics: metrics, logger: logger,
+		occupationDataChanged: make(chan struct{}, 1), occupationDataRefreshDelay: occupationDataRefreshDelay}
+	return &Adapter{db: db, cachedConfigs: cachedConfigs, configsLock: configsLock,
+		cachedOccupationDatas: cachedOccupationDatas, occupationDatasLock: occupationDatasLock}
 }
@@ -18,17 +18,85 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/rokwire/logging-library-go/v2/logs"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer creates the spans of the database operations with the tracer provider set by the tracing adapter
+var tracer = otel.Tracer("application/driven/storage")
+
 type collectionWrapper struct {
 	database *database
 	coll     *mongo.Collection
+
+	watchStatus changeStreamStatus
+}
+
+// changeStreamStatus holds the state of the change stream watched on a collection
+type changeStreamStatus struct {
+	lock sync.RWMutex
+
+	open      bool
+	lastEvent *time.Time
+	lastError error
+}
+
+func (s *changeStreamStatus) setOpen(open bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.open = open
+	if err != nil {
+		s.lastError = err
+	}
+}
+
+func (s *changeStreamStatus) setLastEvent() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now().UTC()
+	s.lastEvent = &now
+}
+
+func (s *changeStreamStatus) get() (bool, *time.Time, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.open, s.lastEvent, s.lastError
+}
+
+// startOperation starts the span of an operation on the collection, returning the function ending it and recording its metrics
+//
+//	A missing document is not counted as an error.
+func (collWrapper *collectionWrapper) startOperation(ctx context.Context, operation string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "mongodb."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(semconv.DBSystemMongoDB,
+		semconv.DBName(collWrapper.database.mongoDBName), semconv.DBMongoDBCollection(collWrapper.coll.Name()), semconv.DBOperation(operation)))
+
+	return ctx, func(err error) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = nil
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		if collWrapper.database.metrics != nil {
+			collWrapper.database.metrics.ObserveDatabaseOperation(collWrapper.coll.Name(), operation, time.Since(start), err)
+		}
+	}
 }
 
 func (collWrapper *collectionWrapper) Find(ctx context.Context, filter interface{}, result interface{},
@@ -45,11 +113,13 @@ func (collWrapper *collectionWrapper) Find(ctx context.Context, filter interface
 		filter = bson.D{}
 	}
 
+	ctx, end := collWrapper.startOperation(ctx, "find")
 	cur, err := collWrapper.coll.Find(ctx, filter, findOptions)
 
 	if err == nil {
 		err = cur.All(ctx, result)
 	}
+	end(err)
 
 	return err
 }
@@ -65,7 +135,9 @@ func (collWrapper *collectionWrapper) FindOne(ctx context.Context, filter interf
 		findOptions = options.FindOne() // crash if not added!
 	}
 
+	ctx, end := collWrapper.startOperation(ctx, "find_one")
 	singleResult := collWrapper.coll.FindOne(ctx, filter, findOptions)
+	end(singleResult.Err())
 	if singleResult.Err() != nil {
 		return singleResult.Err()
 	}
@@ -90,7 +162,9 @@ func (collWrapper *collectionWrapper) ReplaceOne(ctx context.Context, filter int
 		replaceOptions = options.Replace() // crash if not added!
 	}
 
+	ctx, end := collWrapper.startOperation(ctx, "replace_one")
 	res, err := collWrapper.coll.ReplaceOne(ctx, filter, replacement, replaceOptions)
+	end(err)
 	if err != nil {
 		return err
 	}
@@ -113,8 +187,10 @@ func (collWrapper *collectionWrapper) InsertOne(ctx context.Context, data interf
 	}
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 
+	ctx, end := collWrapper.startOperation(ctx, "insert_one")
 	ins, err := collWrapper.coll.InsertOne(ctx, data)
 	cancel()
+	end(err)
 
 	if err == nil {
 		return ins.InsertedID, nil
@@ -130,7 +206,9 @@ func (collWrapper *collectionWrapper) InsertMany(ctx context.Context, documents
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "insert_many")
 	result, err := collWrapper.coll.InsertMany(ctx, documents, opts)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +224,9 @@ func (collWrapper *collectionWrapper) DeleteMany(ctx context.Context, filter int
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "delete_many")
 	result, err := collWrapper.coll.DeleteMany(ctx, filter, opts)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +241,9 @@ func (collWrapper *collectionWrapper) DeleteOne(ctx context.Context, filter inte
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "delete_one")
 	result, err := collWrapper.coll.DeleteOne(ctx, filter, opts)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +258,9 @@ func (collWrapper *collectionWrapper) UpdateOne(ctx context.Context, filter inte
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "update_one")
 	updateResult, err := collWrapper.coll.UpdateOne(ctx, filter, update, opts)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +275,9 @@ func (collWrapper *collectionWrapper) UpdateMany(ctx context.Context, filter int
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "update_many")
 	updateResult, err := collWrapper.coll.UpdateMany(ctx, filter, update, opts)
+	end(err)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +292,9 @@ func (collWrapper *collectionWrapper) FindOneAndUpdate(ctx context.Context, filt
 	ctx, cancel := context.WithTimeout(ctx, collWrapper.database.mongoTimeout)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "find_one_and_update")
 	singleResult := collWrapper.coll.FindOneAndUpdate(ctx, filter, update, opts)
+	end(singleResult.Err())
 	if singleResult.Err() != nil {
 		return singleResult.Err()
 	}
@@ -228,7 +316,9 @@ func (collWrapper *collectionWrapper) CountDocuments(ctx context.Context, filter
 		filter = bson.D{}
 	}
 
+	ctx, end := collWrapper.startOperation(ctx, "count_documents")
 	count, err := collWrapper.coll.CountDocuments(ctx, filter)
+	end(err)
 
 	if err != nil {
 		return -1, err
@@ -243,11 +333,13 @@ func (collWrapper *collectionWrapper) Aggregate(ctx context.Context, pipeline in
 	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*15000)
 	defer cancel()
 
+	ctx, end := collWrapper.startOperation(ctx, "aggregate")
 	cursor, err := collWrapper.coll.Aggregate(ctx, pipeline, ops)
 
 	if err == nil {
 		err = cursor.All(ctx, result)
 	}
+	end(err)
 
 	return err
 }
@@ -334,36 +426,44 @@ func (collWrapper *collectionWrapper) Drop(ctx context.Context) error {
 	return nil
 }
 
-func (collWrapper *collectionWrapper) Watch(pipeline interface{}, l *logs.Logger) {
+// Watch watches the changes of the collection, reopening the change stream on errors until the context is cancelled
+func (collWrapper *collectionWrapper) Watch(ctx context.Context, pipeline interface{}, l *logs.Logger) {
 	var rt bson.Raw
 	var err error
-	for {
-		rt, err = collWrapper.watch(pipeline, rt, l)
-		if err != nil {
+	for ctx.Err() == nil {
+		rt, err = collWrapper.watch(ctx, pipeline, rt, l)
+		if err != nil && ctx.Err() == nil {
 			l.Errorf("mongo watch error: %s\n", err.Error())
+			if collWrapper.database.metrics != nil {
+				collWrapper.database.metrics.IncChangeStreamReconnects(collWrapper.coll.Name())
+			}
 		}
 	}
+	collWrapper.watchStatus.setOpen(false, ctx.Err())
 }
 
 // Helper function for Watch
-func (collWrapper *collectionWrapper) watch(pipeline interface{}, resumeToken bson.Raw, l *logs.Logger) (bson.Raw, error) {
+func (collWrapper *collectionWrapper) watch(ctx context.Context, pipeline interface{}, resumeToken bson.Raw, l *logs.Logger) (bson.Raw, error) {
 	if pipeline == nil {
 		pipeline = []bson.M{}
 	}
 
 	opts := options.ChangeStream()
-	opts.SetFullDocument(options.UpdateLookup)
 	if resumeToken != nil {
 		opts.SetResumeAfter(resumeToken)
 	}
 
-	ctx := context.Background()
 	cur, err := collWrapper.coll.Watch(ctx, pipeline, opts)
 	if err != nil {
-		time.Sleep(time.Second * 3)
+		collWrapper.watchStatus.setOpen(false, err)
+		select {
+		case <-time.After(time.Second * 3):
+		case <-ctx.Done():
+		}
 		return nil, fmt.Errorf("error watching: %s", err)
 	}
 	defer cur.Close(ctx)
+	collWrapper.watchStatus.setOpen(true, nil)
 
 	var changeDoc map[string]interface{}
 	l.Infof("%s: waiting for changes\n", collWrapper.coll.Name())
@@ -371,12 +471,16 @@ func (collWrapper *collectionWrapper) watch(pipeline interface{}, resumeToken bs
 		if e := cur.Decode(&changeDoc); e != nil {
 			l.Errorf("error decoding: %s\n", e)
 		}
+		collWrapper.watchStatus.setLastEvent()
 		collWrapper.database.onDataChanged(changeDoc)
 	}
 
 	if err := cur.Err(); err != nil {
+		collWrapper.watchStatus.setOpen(false, err)
 		return cur.ResumeToken(), fmt.Errorf("error cur.Err(): %s", err)
 	}
 
-	return cur.ResumeToken(), errors.New("unknown error occurred")
+	err = errors.New("unknown error occurred")
+	collWrapper.watchStatus.setOpen(false, err)
+	return cur.ResumeToken(), err
 }
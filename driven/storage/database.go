@@ -16,39 +16,75 @@ package storage
 
 import (
 	"application/core/interfaces"
+	"application/core/model"
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/rokwire/logging-library-go/v2/logs"
 
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// occupationDataRefreshDelay is how long the changes to the occupation data are collected before notifying them once
+const occupationDataRefreshDelay = time.Second
+
+// Metrics records the metrics of the database operations
+type Metrics interface {
+	ObserveDatabaseOperation(collection string, operation string, duration time.Duration, err error)
+	IncChangeStreamReconnects(collection string)
+}
+
 type database struct {
 	mongoDBAuth  string
 	mongoDBName  string
 	mongoTimeout time.Duration
 
+	encryptionKeys string
+	encryption     *encryption
+
 	db       *mongo.Database
 	dbClient *mongo.Client
+	metrics  Metrics
 	logger   *logs.Logger
 
 	configs         *collectionWrapper
 	occupationData  *collectionWrapper
 	matchResults    *collectionWrapper
 	surveyResponses *collectionWrapper
+	migrations      *collectionWrapper
+	idempotencyKeys *collectionWrapper
+	userDataExports *collectionWrapper
+	matchingEvents  *collectionWrapper
+
+	matchResultsHistory *collectionWrapper
 
 	listeners []interfaces.StorageListener
+
+	//the changes to the occupation data are coalesced and notified by a single worker
+	occupationDataChanged      chan struct{}
+	occupationDataRefreshDelay time.Duration
+
+	//the change streams and background work stop once the context is cancelled
+	workersContext context.Context
+	stopWorkers    context.CancelFunc
+	workers        *sync.WaitGroup
 }
 
 func (d *database) start() error {
 
 	d.logger.Info("database -> start")
 
+	//load the encryption keys
+	encryption, err := newEncryption(d.encryptionKeys)
+	if err != nil {
+		return err
+	}
+	d.encryption = encryption
+
 	//connect to the database
 	clientOptions := options.Client().ApplyURI(d.mongoDBAuth)
 	connectContext, cancel := context.WithTimeout(context.Background(), d.mongoTimeout)
@@ -66,103 +102,166 @@ func (d *database) start() error {
 		return err
 	}
 
-	//apply checks
 	db := client.Database(d.mongoDBName)
 
-	configs := &collectionWrapper{database: d, coll: db.Collection("configs")}
-	err = d.applyConfigsChecks(configs)
-	if err != nil {
-		return err
-	}
-
-	occupationData := &collectionWrapper{database: d, coll: db.Collection("occupation_data")}
-	err = d.applyOccupationDataChecks(occupationData)
-	if err != nil {
-		return err
-	}
-
-	matchResults := &collectionWrapper{database: d, coll: db.Collection("match_results")}
-	err = d.applyMatchResultsChecks(matchResults)
-	if err != nil {
-		return err
-	}
-
-	surveyResponses := &collectionWrapper{database: d, coll: db.Collection("survey_responses")}
-	err = d.applySurveyResponsesChecks(surveyResponses)
-	if err != nil {
-		return err
-	}
-
 	//assign the db, db client and the collections
 	d.db = db
 	d.dbClient = client
 
-	d.configs = configs
-	d.occupationData = occupationData
-	d.matchResults = matchResults
-	d.surveyResponses = surveyResponses
-
-	go d.configs.Watch(nil, d.logger)
-
-	return nil
-}
-
-func (d *database) applyConfigsChecks(configs *collectionWrapper) error {
-	d.logger.Info("apply configs checks.....")
-
-	err := configs.AddIndex(nil, bson.D{primitive.E{Key: "type", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "org_id", Value: 1}}, true)
+	d.configs = &collectionWrapper{database: d, coll: db.Collection("configs")}
+	d.occupationData = &collectionWrapper{database: d, coll: db.Collection("occupation_data")}
+	d.matchResults = &collectionWrapper{database: d, coll: db.Collection("match_results")}
+	d.surveyResponses = &collectionWrapper{database: d, coll: db.Collection("survey_responses")}
+	d.migrations = &collectionWrapper{database: d, coll: db.Collection("migrations")}
+	d.idempotencyKeys = &collectionWrapper{database: d, coll: db.Collection(model.CollectionIdempotencyKeys)}
+	d.userDataExports = &collectionWrapper{database: d, coll: db.Collection(model.CollectionUserDataExports)}
+	d.matchingEvents = &collectionWrapper{database: d, coll: db.Collection(model.CollectionMatchingEvents)}
+	d.matchResultsHistory = &collectionWrapper{database: d, coll: db.Collection(model.CollectionMatchResultsHistory)}
+
+	//apply the migrations
+	err = d.applyMigrations()
 	if err != nil {
 		return err
 	}
 
-	d.logger.Info("apply configs passed")
-	return nil
-}
-
-func (d *database) applyOccupationDataChecks(occupationData *collectionWrapper) error {
-	d.logger.Info("apply occupationData checks.....")
-
-	err := occupationData.AddIndex(nil, bson.D{primitive.E{Key: "code", Value: 1}}, true)
-	if err != nil {
-		return err
+	d.workersContext, d.stopWorkers = context.WithCancel(context.Background())
+	d.workers = &sync.WaitGroup{}
+	for _, coll := range d.watchedCollections() {
+		coll := coll
+		d.goWorker(func(ctx context.Context) { coll.Watch(ctx, nil, d.logger) })
 	}
+	d.goWorker(d.notifyOccupationDataUpdated)
+
+	d.goWorker(d.reencryptData)
 
-	d.logger.Info("apply occupationData passed")
 	return nil
 }
 
-func (d *database) applyMatchResultsChecks(matchResults *collectionWrapper) error {
-	d.logger.Info("apply matchResults checks.....")
+// stop stops the change streams and background work, then disconnects from the database until the context is done
+func (d *database) stop(ctx context.Context) error {
+	d.logger.Info("database -> stop")
+
+	d.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		d.logger.Warnf("disconnecting with background work in progress: %v", ctx.Err())
+	}
 
-	d.logger.Info("apply matchResults passed")
-	return nil
+	return d.dbClient.Disconnect(ctx)
 }
 
-func (d *database) applySurveyResponsesChecks(surveyResponses *collectionWrapper) error {
-	d.logger.Info("apply surveyResponses checks.....")
+// goWorker runs fn in the background with a context cancelled when the database is stopped
+func (d *database) goWorker(fn func(ctx context.Context)) {
+	d.workers.Add(1)
+	go func() {
+		defer d.workers.Done()
+		fn(d.workersContext)
+	}()
+}
 
-	d.logger.Info("apply surveyResponses passed")
-	return nil
+// watchedCollections returns the collections whose changes are watched and notified to the storage listeners
+func (d *database) watchedCollections() []*collectionWrapper {
+	return []*collectionWrapper{d.configs, d.occupationData, d.surveyResponses, d.matchResults, d.matchingEvents}
 }
 
 func (d *database) onDataChanged(changeDoc map[string]interface{}) {
 	if changeDoc == nil {
 		return
 	}
-	d.logger.Infof("onDataChanged: %+v\n", changeDoc)
-	ns := changeDoc["ns"]
-	if ns == nil {
+	ns, ok := changeDoc["ns"].(map[string]interface{})
+	if !ok {
 		return
 	}
-	nsMap := ns.(map[string]interface{})
-	coll := nsMap["coll"]
+	coll, _ := ns["coll"].(string)
+	operationType, _ := changeDoc["operationType"].(string)
+
+	change := model.DataChange{Collection: coll, Date: time.Now().UTC()}
+	switch operationType {
+	case "insert":
+		change.Operation = model.DataChangeInsert
+	case "update", "replace":
+		change.Operation = model.DataChangeUpdate
+	case "delete":
+		change.Operation = model.DataChangeDelete
+	}
+	if documentKey, ok := changeDoc["documentKey"].(map[string]interface{}); ok {
+		change.DocumentID = documentID(documentKey["_id"])
+	}
+	if clusterTime, ok := changeDoc["clusterTime"].(primitive.Timestamp); ok {
+		change.Date = time.Unix(int64(clusterTime.T), 0).UTC()
+	}
+	d.logger.Infof("onDataChanged: %s %s %s", coll, operationType, change.DocumentID)
+
+	//the listeners are notified from the change stream of the collection, so that they receive its changes in order
+	if change.Operation != "" {
+		for _, listener := range d.listeners {
+			listener.OnDataChanged(change)
+		}
+	}
 
 	switch coll {
-	case "configs":
+	case model.CollectionConfigs:
 		d.logger.Info("configs collection changed")
 
 		for _, listener := range d.listeners {
 			go listener.OnConfigsUpdated()
 		}
+	case model.CollectionOccupationData:
+		d.logger.Info("occupation_data collection changed")
+
+		select {
+		case d.occupationDataChanged <- struct{}{}:
+		default:
+			//a notification is already pending
+		}
+	}
+}
+
+// notifyOccupationDataUpdated notifies the listeners once for each burst of changes to the occupation data, until the context is cancelled
+//
+//	The listeners are notified in order, so the storage adapter, which registers first, refreshes its cache before core uses it.
+//	Changes made while the listeners are notified trigger one more notification.
+func (d *database) notifyOccupationDataUpdated(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.occupationDataChanged:
+		}
+
+		timer := time.NewTimer(d.occupationDataRefreshDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		select {
+		case <-d.occupationDataChanged:
+		default:
+		}
+		for _, listener := range d.listeners {
+			listener.OnOccupationDataUpdated()
+		}
+	}
+}
+
+// documentID returns the string form of a document ID
+func documentID(id interface{}) string {
+	switch id := id.(type) {
+	case string:
+		return id
+	case primitive.ObjectID:
+		return id.Hex()
+	case nil:
+		return ""
 	}
+	return fmt.Sprint(id)
 }
@@ -0,0 +1,108 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/interfaces"
+	"application/core/model"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+type testListener struct {
+	name    string
+	updates chan string
+	changes []model.DataChange
+	model.DefaultStorageListener
+}
+
+func (l *testListener) OnOccupationDataUpdated() {
+	l.updates <- l.name
+}
+
+func (l *testListener) OnDataChanged(change model.DataChange) {
+	l.changes = append(l.changes, change)
+}
+
+func TestDatabase_OnDataChanged(t *testing.T) {
+	listener := &testListener{}
+	d := &database{logger: logs.NewLogger("skills-to-jobs", nil), occupationDataChanged: make(chan struct{}, 1), listeners: []interfaces.StorageListener{listener}}
+
+	for _, operationType := range []string{"insert", "update", "delete"} {
+		d.onDataChanged(map[string]interface{}{"ns": map[string]interface{}{"coll": model.CollectionOccupationData}, "operationType": operationType,
+			"documentKey": map[string]interface{}{"_id": "15-1252.00"}})
+	}
+
+	//the listeners are notified before onDataChanged returns, in the order of the changes
+	want := []string{model.DataChangeInsert, model.DataChangeUpdate, model.DataChangeDelete}
+	if len(listener.changes) != len(want) {
+		t.Fatalf("database.onDataChanged() notified %d changes, want %d", len(listener.changes), len(want))
+	}
+	for i, change := range listener.changes {
+		if change.Operation != want[i] || change.Collection != model.CollectionOccupationData || change.DocumentID != "15-1252.00" {
+			t.Errorf("database.onDataChanged() change %d = %+v, want %s of 15-1252.00", i, change, want[i])
+		}
+	}
+}
+
+func TestDatabase_NotifyOccupationDataUpdated(t *testing.T) {
+	updates := make(chan string, 10)
+	d := &database{logger: logs.NewLogger("skills-to-jobs", nil), occupationDataChanged: make(chan struct{}, 1), occupationDataRefreshDelay: 50 * time.Millisecond,
+		listeners: []interfaces.StorageListener{&testListener{name: "storage", updates: updates}, &testListener{name: "core", updates: updates}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	workers := &sync.WaitGroup{}
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		d.notifyOccupationDataUpdated(ctx)
+	}()
+	defer func() {
+		cancel()
+		workers.Wait()
+	}()
+
+	//a bulk import is notified once, to the storage adapter first
+	for i := 0; i < 20; i++ {
+		d.onDataChanged(map[string]interface{}{"ns": map[string]interface{}{"coll": model.CollectionOccupationData}, "operationType": "insert"})
+	}
+	for _, want := range []string{"storage", "core"} {
+		select {
+		case got := <-updates:
+			if got != want {
+				t.Errorf("database.notifyOccupationDataUpdated() notified %s, want %s", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("database.notifyOccupationDataUpdated() did not notify %s", want)
+		}
+	}
+	select {
+	case got := <-updates:
+		t.Errorf("database.notifyOccupationDataUpdated() notified %s again for the same burst", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	//other collections are not notified as occupation data updates
+	d.onDataChanged(map[string]interface{}{"ns": map[string]interface{}{"coll": model.CollectionMatchResults}, "operationType": "insert"})
+	select {
+	case got := <-updates:
+		t.Errorf("database.notifyOccupationDataUpdated() notified %s for a match result change", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
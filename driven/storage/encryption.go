@@ -0,0 +1,303 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	typeEncryptionKey  logutils.MessageDataType = "encryption key"
+	typeEncryptedValue logutils.MessageDataType = "encrypted value"
+
+	encryptionKeySize     int   = 32
+	reencryptionBatchSize int64 = 100
+)
+
+// encryptedValue is a BSON value encrypted with its own data key, which is stored wrapped by a master key
+type encryptedValue struct {
+	KeyID   string `bson:"key_id"`
+	DataKey []byte `bson:"data_key"`
+	Data    []byte `bson:"data"`
+}
+
+// encryption encrypts values with the current master key and decrypts values encrypted with any of the master keys
+type encryption struct {
+	keyID string
+	keys  map[string]cipher.AEAD
+}
+
+// encrypt encrypts the value of the document with the given ID with a new data key
+func (e *encryption) encrypt(id string, value interface{}) (*encryptedValue, error) {
+	data, err := bson.Marshal(bson.M{"value": value})
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionMarshal, typeEncryptedValue, nil, err)
+	}
+
+	dataKey := make([]byte, encryptionKeySize)
+	_, err = rand.Read(dataKey)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGenerate, typeEncryptionKey, nil, err)
+	}
+	dataCipher, err := newEncryptionCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, err := seal(dataCipher, data, []byte(id))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionEncrypt, typeEncryptedValue, nil, err)
+	}
+
+	wrappedKey, err := seal(e.keys[e.keyID], dataKey, []byte(e.keyID))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionEncrypt, typeEncryptionKey, nil, err)
+	}
+	return &encryptedValue{KeyID: e.keyID, DataKey: wrappedKey, Data: encryptedData}, nil
+}
+
+// unwrapDataKey decrypts the data key of an encrypted value with the master key it was wrapped by
+func (e *encryption) unwrapDataKey(value *encryptedValue) ([]byte, error) {
+	masterCipher, ok := e.keys[value.KeyID]
+	if !ok {
+		return nil, errors.ErrorData(logutils.StatusMissing, typeEncryptionKey, &logutils.FieldArgs{"key_id": value.KeyID})
+	}
+	dataKey, err := open(masterCipher, value.DataKey, []byte(value.KeyID))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionDecrypt, typeEncryptionKey, &logutils.FieldArgs{"key_id": value.KeyID}, err)
+	}
+	return dataKey, nil
+}
+
+// rewrap wraps the data key of an encrypted value with the current master key, leaving the encrypted data unchanged
+func (e *encryption) rewrap(value *encryptedValue) (*encryptedValue, error) {
+	dataKey, err := e.unwrapDataKey(value)
+	if err != nil {
+		return nil, err
+	}
+	wrappedKey, err := seal(e.keys[e.keyID], dataKey, []byte(e.keyID))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionEncrypt, typeEncryptionKey, nil, err)
+	}
+	return &encryptedValue{KeyID: e.keyID, DataKey: wrappedKey, Data: value.Data}, nil
+}
+
+// decryptValue decrypts the value of the document with the given ID
+func decryptValue[T any](e *encryption, id string, value *encryptedValue) (T, error) {
+	var wrapper struct {
+		Value T `bson:"value"`
+	}
+	if e == nil {
+		return wrapper.Value, errors.ErrorData(logutils.StatusMissing, typeEncryptionKey, &logutils.FieldArgs{"key_id": value.KeyID})
+	}
+
+	dataKey, err := e.unwrapDataKey(value)
+	if err != nil {
+		return wrapper.Value, err
+	}
+	dataCipher, err := newEncryptionCipher(dataKey)
+	if err != nil {
+		return wrapper.Value, err
+	}
+	data, err := open(dataCipher, value.Data, []byte(id))
+	if err != nil {
+		return wrapper.Value, errors.WrapErrorAction(logutils.ActionDecrypt, typeEncryptedValue, &logutils.FieldArgs{"id": id}, err)
+	}
+
+	err = bson.Unmarshal(data, &wrapper)
+	if err != nil {
+		return wrapper.Value, errors.WrapErrorAction(logutils.ActionUnmarshal, typeEncryptedValue, &logutils.FieldArgs{"id": id}, err)
+	}
+	return wrapper.Value, nil
+}
+
+func newEncryptionCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInitialize, typeEncryptionKey, nil, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionInitialize, typeEncryptionKey, nil, err)
+	}
+	return aead, nil
+}
+
+// seal encrypts the plaintext, prefixing the result with a random nonce
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext produced by seal
+func open(aead cipher.AEAD, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, data := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, data, additionalData)
+}
+
+// newEncryption creates a new encryption from a comma separated list of "<key id>:<base64 encoded 32 byte key>" master keys
+//
+//	The first key encrypts new values and the others are only used to decrypt values encrypted before a key rotation.
+//	No encryption is returned when the list is empty.
+func newEncryption(masterKeys string) (*encryption, error) {
+	if strings.TrimSpace(masterKeys) == "" {
+		return nil, nil
+	}
+
+	e := encryption{keys: map[string]cipher.AEAD{}}
+	for _, entry := range strings.Split(masterKeys, ",") {
+		keyID, encodedKey, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || keyID == "" {
+			return nil, errors.ErrorData(logutils.StatusInvalid, typeEncryptionKey, &logutils.FieldArgs{"key_id": keyID})
+		}
+		if _, ok := e.keys[keyID]; ok {
+			return nil, errors.ErrorData(logutils.StatusInvalid, typeEncryptionKey, &logutils.FieldArgs{"key_id": keyID, "duplicate": true})
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDecode, typeEncryptionKey, &logutils.FieldArgs{"key_id": keyID}, err)
+		}
+		if len(key) != encryptionKeySize {
+			return nil, errors.ErrorData(logutils.StatusInvalid, typeEncryptionKey, &logutils.FieldArgs{"key_id": keyID, "size": len(key)})
+		}
+		aead, err := newEncryptionCipher(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.keyID == "" {
+			e.keyID = keyID
+		}
+		e.keys[keyID] = aead
+	}
+	return &e, nil
+}
+
+// reencryptData encrypts the plain values and rewraps the data keys not wrapped by the current master key in all encrypted fields, until the context is cancelled
+func (d *database) reencryptData(ctx context.Context) {
+	if d.encryption == nil {
+		d.logger.Warn("no encryption keys set, survey scores, match results and user data exports are stored unencrypted")
+		return
+	}
+
+	fields := []struct {
+		coll           *collectionWrapper
+		field          string
+		encryptedField string
+	}{
+		{coll: d.surveyResponses, field: "scores", encryptedField: "encrypted_scores"},
+		{coll: d.matchResults, field: "matches", encryptedField: "encrypted_matches"},
+		{coll: d.matchResultsHistory, field: "matches", encryptedField: "encrypted_matches"},
+		{coll: d.userDataExports, field: "data", encryptedField: "encrypted_data"},
+	}
+	complete := true
+	for _, f := range fields {
+		count, failed, err := d.reencryptField(ctx, f.coll, f.field, f.encryptedField)
+		if ctx.Err() != nil {
+			d.logger.Infof("re-encryption stopped after %d documents of %s", count, f.field)
+			return
+		}
+		if err != nil {
+			d.logger.Errorf("error re-encrypting %s: %v", f.field, err)
+			complete = false
+		}
+		if failed > 0 {
+			complete = false
+		}
+		if count > 0 {
+			d.logger.Infof("re-encrypted %s of %d documents with key %s", f.field, count, d.encryption.keyID)
+		}
+	}
+	if complete {
+		d.logger.Infof("all encrypted data uses key %s", d.encryption.keyID)
+	}
+}
+
+// reencryptField re-encrypts the field of the documents in the collection in batches, returning the number of documents updated and failed
+//
+//	Documents are only updated if unchanged since they were read, so that a concurrent update is never overwritten.
+func (d *database) reencryptField(ctx context.Context, coll *collectionWrapper, field string, encryptedField string) (int, int, error) {
+	keyIDField := encryptedField + ".key_id"
+	count := 0
+	failed := 0
+	lastID := ""
+	for {
+		filter := bson.M{"_id": bson.M{"$gt": lastID}, keyIDField: bson.M{"$ne": d.encryption.keyID}}
+		findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}}).SetLimit(reencryptionBatchSize)
+		var docs []bson.Raw
+		err := coll.Find(ctx, filter, &docs, findOptions)
+		if err != nil {
+			return count, failed, errors.WrapErrorAction(logutils.ActionFind, typeEncryptedValue, &logutils.FieldArgs{"field": field}, err)
+		}
+
+		for _, doc := range docs {
+			lastID = doc.Lookup("_id").StringValue()
+			updateFilter := bson.M{"_id": lastID}
+			var value *encryptedValue
+			if encrypted, lookupErr := doc.LookupErr(encryptedField); lookupErr == nil && encrypted.Type == bson.TypeEmbeddedDocument {
+				var current encryptedValue
+				err = encrypted.Unmarshal(&current)
+				if err == nil {
+					value, err = d.encryption.rewrap(&current)
+				}
+				updateFilter[keyIDField] = current.KeyID
+			} else {
+				var plain interface{}
+				if plainValue, lookupErr := doc.LookupErr(field); lookupErr == nil {
+					plain = plainValue
+				}
+				value, err = d.encryption.encrypt(lastID, plain)
+				updateFilter[encryptedField] = nil
+				if revision, ok := doc.Lookup("revision").AsInt64OK(); ok {
+					updateFilter["revision"] = revision
+				}
+			}
+			if err != nil {
+				d.logger.Errorf("error re-encrypting %s of %s: %v", field, lastID, err)
+				failed++
+				continue
+			}
+
+			update := bson.M{"$set": bson.M{field: nil, encryptedField: value}}
+			res, err := coll.UpdateOne(ctx, updateFilter, update, nil)
+			if err != nil {
+				return count, failed, errors.WrapErrorAction(logutils.ActionUpdate, typeEncryptedValue, &logutils.FieldArgs{"field": field, "id": lastID}, err)
+			}
+			count += int(res.ModifiedCount)
+		}
+
+		if int64(len(docs)) < reencryptionBatchSize {
+			return count, failed, nil
+		}
+	}
+}
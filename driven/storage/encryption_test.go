@@ -0,0 +1,101 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"crypto/rand"
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func newTestEncryptionKey(t *testing.T, keyID string) string {
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return keyID + ":" + base64.StdEncoding.EncodeToString(key)
+}
+
+func TestNewEncryption(t *testing.T) {
+	key := newTestEncryptionKey(t, "current")
+	tests := []struct {
+		name    string
+		keys    string
+		wantNil bool
+		wantErr bool
+	}{
+		{"empty", "", true, false},
+		{"single key", key, false, false},
+		{"rotated keys", key + "," + newTestEncryptionKey(t, "previous"), false, false},
+		{"missing key id", ":" + base64.StdEncoding.EncodeToString(make([]byte, encryptionKeySize)), true, true},
+		{"short key", "short:" + base64.StdEncoding.EncodeToString(make([]byte, 16)), true, true},
+		{"duplicate key id", key + "," + key, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newEncryption(tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newEncryption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("newEncryption() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestEncryption_Rotation(t *testing.T) {
+	previousKey := newTestEncryptionKey(t, "previous")
+	previous, err := newEncryption(previousKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := newEncryption(newTestEncryptionKey(t, "current") + "," + previousKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scores := []model.WorkstyleScore{{Workstyle: "leadership", Score: 4}, {Workstyle: "teamwork", Score: 3}}
+	encrypted, err := previous.encrypt("survey", scores)
+	if err != nil {
+		t.Fatalf("encryption.encrypt() error = %v", err)
+	}
+	if _, err = decryptValue[[]model.WorkstyleScore](previous, "other", encrypted); err == nil {
+		t.Errorf("decryptValue() with another document ID error = nil, want error")
+	}
+
+	rewrapped, err := current.rewrap(encrypted)
+	if err != nil {
+		t.Fatalf("encryption.rewrap() error = %v", err)
+	}
+	if rewrapped.KeyID != "current" {
+		t.Errorf("encryption.rewrap() key ID = %s, want current", rewrapped.KeyID)
+	}
+	if _, err = decryptValue[[]model.WorkstyleScore](previous, "survey", rewrapped); err == nil {
+		t.Errorf("decryptValue() with the previous key only error = nil, want error")
+	}
+
+	for _, value := range []*encryptedValue{encrypted, rewrapped} {
+		got, err := decryptValue[[]model.WorkstyleScore](current, "survey", value)
+		if err != nil {
+			t.Fatalf("decryptValue() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, scores) {
+			t.Errorf("decryptValue() = %v, want %v", got, scores)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"context"
+	"time"
+)
+
+// CheckHealth checks the MongoDB response time and the change streams watched on the collections
+func (a *Adapter) CheckHealth() map[string][]model.HealthCheck {
+	now := time.Now().UTC()
+
+	ping := model.HealthCheck{ComponentID: "mongodb", Status: model.HealthStatusPass, ObservedUnit: "ms", Time: &now}
+	ctx, cancel := context.WithTimeout(context.Background(), a.db.mongoTimeout)
+	err := a.db.dbClient.Ping(ctx, nil)
+	cancel()
+	ping.ObservedValue = time.Since(now).Milliseconds()
+	if err != nil {
+		ping.Status = model.HealthStatusFail
+		ping.Output = err.Error()
+	}
+
+	changeStreams := make([]model.HealthCheck, 0)
+	for _, coll := range a.db.watchedCollections() {
+		open, lastEvent, lastError := coll.watchStatus.get()
+		check := model.HealthCheck{ComponentID: coll.coll.Name(), Status: model.HealthStatusPass, Time: &now}
+		if lastEvent != nil {
+			check.ObservedValue = lastEvent
+		}
+		if !open {
+			check.Status = model.HealthStatusFail
+			if lastError != nil {
+				check.Output = lastError.Error()
+			}
+		}
+		changeStreams = append(changeStreams, check)
+	}
+
+	return map[string][]model.HealthCheck{"mongodb:response_time": {ping}, "change_stream:last_event": changeStreams}
+}
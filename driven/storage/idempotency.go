@@ -0,0 +1,96 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertIdempotencyKey inserts an idempotencyKey, returning false when the account already has an unexpired key with the same value
+func (a Adapter) InsertIdempotencyKey(idempotencyKey model.IdempotencyKey) (bool, error) {
+	// an expired key not yet removed by the TTL index is replaced, and the upsert fails with a duplicate key error while the key has not expired
+	filter := bson.M{"account_id": idempotencyKey.AccountID, "key": idempotencyKey.Key, "date_expires": bson.M{"$lte": idempotencyKey.DateCreated}}
+	update := bson.M{"$set": idempotencyKey}
+
+	_, err := a.db.idempotencyKeys.UpdateOne(a.operationContext(), filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WrapErrorAction(logutils.ActionInsert, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+	}
+
+	return true, nil
+}
+
+// FindIdempotencyKey finds the idempotencyKey of an account with the given value
+func (a Adapter) FindIdempotencyKey(accountID string, key string) (*model.IdempotencyKey, error) {
+	filter := bson.M{"account_id": accountID, "key": key}
+
+	var idempotencyKey *model.IdempotencyKey
+	err := a.db.idempotencyKeys.FindOne(a.operationContext(), filter, &idempotencyKey, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+
+	return idempotencyKey, nil
+}
+
+// UpdateIdempotencyKey sets the ID of the resource created by the request of an idempotencyKey
+func (a Adapter) UpdateIdempotencyKey(idempotencyKey model.IdempotencyKey) error {
+	filter := bson.M{"account_id": idempotencyKey.AccountID, "key": idempotencyKey.Key}
+	update := bson.M{"$set": bson.M{"resource_id": idempotencyKey.ResourceID}}
+
+	res, err := a.db.idempotencyKeys.UpdateOne(a.operationContext(), filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}, err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": idempotencyKey.AccountID}).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey deletes the idempotencyKey of an account with the given value
+func (a Adapter) DeleteIdempotencyKey(accountID string, key string) error {
+	filter := bson.M{"account_id": accountID, "key": key}
+
+	_, err := a.db.idempotencyKeys.DeleteOne(a.operationContext(), filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	return nil
+}
+
+// DeleteIdempotencyKeysByAccount deletes the idempotencyKeys of an account, returning the number deleted
+func (a Adapter) DeleteIdempotencyKeysByAccount(accountID string) (int64, error) {
+	filter := bson.M{"account_id": accountID}
+
+	res, err := a.db.idempotencyKeys.DeleteMany(a.operationContext(), filter, nil)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeIdempotencyKey, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	return res.DeletedCount, nil
+}
@@ -24,3 +24,7 @@ type storageListener struct {
 func (s *storageListener) OnConfigsUpdated() {
 	s.adapter.cacheConfigs()
 }
+
+func (s *storageListener) OnOccupationDataUpdated() {
+	s.adapter.cacheOccupationDatas()
+}
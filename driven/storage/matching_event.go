@@ -0,0 +1,50 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// InsertMatchingEvent inserts a matching event, which is removed by the TTL index once expired
+func (a Adapter) InsertMatchingEvent(event model.MatchingEvent) error {
+	_, err := a.db.matchingEvents.InsertOne(a.operationContext(), event)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeMatchingEvent, &logutils.FieldArgs{"_id": event.ID}, err)
+	}
+	return nil
+}
+
+// GetMatchingEvent finds a matching event by id
+func (a Adapter) GetMatchingEvent(id string) (*model.MatchingEvent, error) {
+	filter := bson.M{"_id": id}
+
+	var event *model.MatchingEvent
+	err := a.db.matchingEvents.FindOne(a.operationContext(), filter, &event, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeMatchingEvent, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeMatchingEvent, filterArgs(filter), err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	typeMigration     logutils.MessageDataType = "migration"
+	typeMigrationLock logutils.MessageDataType = "migration lock"
+
+	migrationsLockID      string        = "lock"
+	migrationsLockExpiry  time.Duration = 2 * time.Minute
+	migrationsLockTimeout time.Duration = 10 * time.Minute
+	migrationsLockRetry   time.Duration = 2 * time.Second
+)
+
+// migration is a database change applied once, in order, when the service starts
+//
+//	Migrations must be idempotent, as a migration interrupted before being recorded is applied again on the next start.
+//	Applied migrations must never be changed or reordered - add a new migration instead.
+type migration struct {
+	id          string
+	description string
+	apply       func() error
+}
+
+// migrationRecord is stored in the migrations collection for each applied migration
+type migrationRecord struct {
+	ID          string    `bson:"_id"`
+	Description string    `bson:"description"`
+	DateApplied time.Time `bson:"date_applied"`
+}
+
+// migrationStore stores the applied migrations and the lock held by the replica applying them
+type migrationStore interface {
+	// acquireMigrationsLock acquires the lock for the owner if it is free or expired, returning false while another owner holds it
+	acquireMigrationsLock(owner string, expiry time.Duration) (bool, error)
+	// renewMigrationsLock extends the lock of the owner, failing if the owner does not hold it anymore
+	renewMigrationsLock(owner string, expiry time.Duration) error
+	releaseMigrationsLock(owner string) error
+
+	findMigrationRecords() ([]migrationRecord, error)
+	insertMigrationRecord(record migrationRecord) error
+}
+
+// migrationRunner applies the migrations which have not been applied yet, holding the migrations lock so that replicas do not apply them concurrently
+//
+//	The lock is renewed in the background while the migrations run, and renewed again before each migration is applied and
+//	recorded, so a replica which lost the lock stops instead of applying or recording migrations alongside the new owner.
+type migrationRunner struct {
+	store  migrationStore
+	steps  []migration
+	logger *logs.Logger
+
+	lockExpiry  time.Duration
+	lockTimeout time.Duration
+	lockRetry   time.Duration
+}
+
+func (r *migrationRunner) run() error {
+	owner := uuid.NewString()
+	err := r.lock(owner)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCreate, typeMigrationLock, nil, err)
+	}
+	stopRenewing := r.goRenewLock(owner)
+	defer func() {
+		stopRenewing()
+		err := r.store.releaseMigrationsLock(owner)
+		if err != nil {
+			r.logger.Errorf("error releasing the migrations lock: %s", err)
+		}
+	}()
+
+	records, err := r.store.findMigrationRecords()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, typeMigration, nil, err)
+	}
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.ID] = true
+	}
+
+	for _, migration := range r.steps {
+		if applied[migration.id] {
+			continue
+		}
+
+		err = r.store.renewMigrationsLock(owner, r.lockExpiry)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, typeMigrationLock, nil, err)
+		}
+		r.logger.Infof("applying migration %s - %s", migration.id, migration.description)
+		err = migration.apply()
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionApply, typeMigration, &logutils.FieldArgs{"id": migration.id}, err)
+		}
+
+		err = r.store.renewMigrationsLock(owner, r.lockExpiry)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUpdate, typeMigrationLock, nil, err)
+		}
+		record := migrationRecord{ID: migration.id, Description: migration.description, DateApplied: time.Now().UTC()}
+		err = r.store.insertMigrationRecord(record)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionInsert, typeMigration, &logutils.FieldArgs{"id": migration.id}, err)
+		}
+	}
+	return nil
+}
+
+// lock waits until the migrations lock is free or expired and acquires it
+func (r *migrationRunner) lock(owner string) error {
+	deadline := time.Now().Add(r.lockTimeout)
+	for {
+		locked, err := r.store.acquireMigrationsLock(owner, r.lockExpiry)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.ErrorData(logutils.StatusFound, typeMigrationLock, nil)
+		}
+
+		r.logger.Info("waiting for the migrations lock...")
+		time.Sleep(r.lockRetry)
+	}
+}
+
+// goRenewLock renews the migrations lock in the background until the returned function is called
+func (r *migrationRunner) goRenewLock(owner string) func() {
+	stop := make(chan struct{})
+	done := &sync.WaitGroup{}
+	done.Add(1)
+	go func() {
+		defer done.Done()
+		ticker := time.NewTicker(r.lockExpiry / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := r.store.renewMigrationsLock(owner, r.lockExpiry)
+				if err != nil {
+					r.logger.Errorf("error renewing the migrations lock: %s", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		done.Wait()
+	}
+}
+
+// migrationSteps lists all migrations in the order they are applied
+func (d *database) migrationSteps() []migration {
+	return []migration{
+		{id: "0001_configs_index", description: "add unique index on configs type, app_id and org_id", apply: func() error {
+			return d.configs.AddIndex(nil, bson.D{primitive.E{Key: "type", Value: 1}, primitive.E{Key: "app_id", Value: 1}, primitive.E{Key: "org_id", Value: 1}}, true)
+		}},
+		{id: "0002_occupation_data_code_index", description: "add unique index on occupation_data code", apply: func() error {
+			return d.occupationData.AddIndex(nil, bson.D{primitive.E{Key: "code", Value: 1}}, true)
+		}},
+		{id: "0003_survey_responses_account_id", description: "add index on survey_responses account_id", apply: func() error {
+			// the owner of surveys created before account IDs were stored is unknown, so their account_id is left unset
+			return d.surveyResponses.AddIndex(nil, bson.D{primitive.E{Key: "account_id", Value: 1}}, false)
+		}},
+		{id: "0004_retention_indexes", description: "add date indexes used by the retention policies", apply: func() error {
+			for _, coll := range []*collectionWrapper{d.surveyResponses, d.matchResults} {
+				err := coll.AddIndex(nil, bson.D{primitive.E{Key: "date_updated", Value: 1}}, false)
+				if err != nil {
+					return err
+				}
+				err = coll.AddIndex(nil, bson.D{primitive.E{Key: "date_created", Value: 1}}, false)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{id: "0005_revisions", description: "add revision to configs, survey_responses and match_results", apply: func() error {
+			filter := bson.M{"revision": bson.M{"$exists": false}}
+			update := bson.M{"$set": bson.M{"revision": 1}}
+			for _, coll := range []*collectionWrapper{d.configs, d.surveyResponses, d.matchResults} {
+				_, err := coll.UpdateMany(nil, filter, update, nil)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{id: "0006_occupation_data_code_id", description: "use the code of occupation_data as their _id", apply: func() error {
+			var docs []bson.M
+			err := d.occupationData.Find(nil, bson.M{"_id": bson.M{"$type": "objectId"}}, &docs, nil)
+			if err != nil {
+				return err
+			}
+			for _, doc := range docs {
+				code, ok := doc["code"].(string)
+				if !ok || code == "" {
+					continue
+				}
+				err = d.replaceDocumentID(d.occupationData, doc, code)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{id: "0007_idempotency_keys_indexes", description: "add unique index on idempotency_keys account_id and key, and TTL index on date_expires", apply: func() error {
+			err := d.idempotencyKeys.AddIndex(nil, bson.D{primitive.E{Key: "account_id", Value: 1}, primitive.E{Key: "key", Value: 1}}, true)
+			if err != nil {
+				return err
+			}
+			return d.idempotencyKeys.AddIndexWithOptions(nil, bson.D{primitive.E{Key: "date_expires", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+		}},
+		{id: "0008_matching_events_indexes", description: "add TTL index on matching_events date_expires", apply: func() error {
+			return d.matchingEvents.AddIndexWithOptions(nil, bson.D{primitive.E{Key: "date_expires", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+		}},
+		{id: "0009_user_data_exports_indexes", description: "add index on user_data_exports account_id, and TTL index on date_expires", apply: func() error {
+			err := d.userDataExports.AddIndex(nil, bson.D{primitive.E{Key: "account_id", Value: 1}}, false)
+			if err != nil {
+				return err
+			}
+			return d.userDataExports.AddIndexWithOptions(nil, bson.D{primitive.E{Key: "date_expires", Value: 1}}, options.Index().SetExpireAfterSeconds(0))
+		}},
+		{id: "0010_match_results_history", description: "add match_results_history with the saved match results, and its account_id and date_created indexes", apply: func() error {
+			// the history of a result is keyed by its account ID, which the matches are encrypted with
+			pipeline := []bson.M{
+				{"$project": bson.M{"account_id": "$_id", "version": 1, "matches": 1, "encrypted_matches": 1,
+					"date_created": bson.M{"$ifNull": []string{"$date_updated", "$date_created"}}}},
+				{"$merge": bson.M{"into": model.CollectionMatchResultsHistory, "whenMatched": "keepExisting"}},
+			}
+			var result []bson.M
+			err := d.matchResults.Aggregate(nil, pipeline, &result, nil)
+			if err != nil {
+				return err
+			}
+			err = d.matchResultsHistory.AddIndex(nil, bson.D{primitive.E{Key: "account_id", Value: 1}, primitive.E{Key: "date_created", Value: 1}}, false)
+			if err != nil {
+				return err
+			}
+			return d.matchResultsHistory.AddIndex(nil, bson.D{primitive.E{Key: "date_created", Value: 1}}, false)
+		}},
+	}
+}
+
+// replaceDocumentID replaces the _id of a document, deleting and inserting it again in a transaction as an _id cannot be updated
+func (d *database) replaceDocumentID(coll *collectionWrapper, doc bson.M, id string) error {
+	session, err := d.dbClient.StartSession()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	defer session.EndSession(ctx)
+
+	oldID := doc["_id"]
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		_, err := coll.DeleteOne(sessionContext, bson.M{"_id": oldID}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		replacement := bson.M{}
+		for key, value := range doc {
+			replacement[key] = value
+		}
+		replacement["_id"] = id
+		_, err = coll.InsertOne(sessionContext, replacement)
+		return nil, err
+	})
+	return err
+}
+
+// applyMigrations applies the migrations which have not been applied yet
+func (d *database) applyMigrations() error {
+	d.logger.Info("apply migrations.....")
+
+	runner := migrationRunner{store: d, steps: d.migrationSteps(), logger: d.logger,
+		lockExpiry: migrationsLockExpiry, lockTimeout: migrationsLockTimeout, lockRetry: migrationsLockRetry}
+	err := runner.run()
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("apply migrations passed")
+	return nil
+}
+
+func (d *database) acquireMigrationsLock(owner string, expiry time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.M{"_id": migrationsLockID, "expires_at": bson.M{"$lt": now}}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(expiry)}}
+
+	// the upsert fails with a duplicate key error while another replica holds the lock
+	_, err := d.migrations.UpdateOne(nil, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (d *database) renewMigrationsLock(owner string, expiry time.Duration) error {
+	filter := bson.M{"_id": migrationsLockID, "owner": owner}
+	update := bson.M{"$set": bson.M{"expires_at": time.Now().UTC().Add(expiry)}}
+
+	res, err := d.migrations.UpdateOne(nil, filter, update, nil)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return errors.ErrorData(logutils.StatusMissing, typeMigrationLock, &logutils.FieldArgs{"owner": owner})
+	}
+	return nil
+}
+
+func (d *database) releaseMigrationsLock(owner string) error {
+	_, err := d.migrations.DeleteOne(nil, bson.M{"_id": migrationsLockID, "owner": owner}, nil)
+	return err
+}
+
+func (d *database) findMigrationRecords() ([]migrationRecord, error) {
+	var records []migrationRecord
+	err := d.migrations.Find(nil, bson.M{"_id": bson.M{"$ne": migrationsLockID}}, &records, nil)
+	return records, err
+}
+
+func (d *database) insertMigrationRecord(record migrationRecord) error {
+	_, err := d.migrations.InsertOne(nil, record)
+	return err
+}
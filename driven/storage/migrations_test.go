@@ -0,0 +1,215 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+// testMigrationStore stores the migrations lock and records in memory, expiring the lock as MongoDB does
+type testMigrationStore struct {
+	lock        *sync.Mutex
+	owner       string
+	expiresAt   time.Time
+	records     []migrationRecord
+	renewals    int
+	lockHistory []string
+}
+
+func newTestMigrationStore() *testMigrationStore {
+	return &testMigrationStore{lock: &sync.Mutex{}}
+}
+
+func (s *testMigrationStore) acquireMigrationsLock(owner string, expiry time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.owner != "" && time.Now().Before(s.expiresAt) {
+		return false, nil
+	}
+	s.owner = owner
+	s.expiresAt = time.Now().Add(expiry)
+	s.lockHistory = append(s.lockHistory, owner)
+	return true, nil
+}
+
+func (s *testMigrationStore) renewMigrationsLock(owner string, expiry time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.owner != owner {
+		return errors.New("lock lost")
+	}
+	s.expiresAt = time.Now().Add(expiry)
+	s.renewals++
+	return nil
+}
+
+func (s *testMigrationStore) releaseMigrationsLock(owner string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.owner == owner {
+		s.owner = ""
+	}
+	return nil
+}
+
+func (s *testMigrationStore) findMigrationRecords() ([]migrationRecord, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]migrationRecord{}, s.records...), nil
+}
+
+func (s *testMigrationStore) insertMigrationRecord(record migrationRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *testMigrationStore) recordIDs() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := []string{}
+	for _, record := range s.records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
+
+func newTestMigrationRunner(store migrationStore, steps []migration) *migrationRunner {
+	return &migrationRunner{store: store, steps: steps, logger: logs.NewLogger("skills-to-jobs", nil),
+		lockExpiry: 300 * time.Millisecond, lockTimeout: 200 * time.Millisecond, lockRetry: 10 * time.Millisecond}
+}
+
+func TestMigrationRunner_Run(t *testing.T) {
+	var appliedSteps []string
+	step := func(id string, err error) migration {
+		return migration{id: id, description: id, apply: func() error {
+			appliedSteps = append(appliedSteps, id)
+			return err
+		}}
+	}
+
+	store := newTestMigrationStore()
+	failed := errors.New("failed")
+	err := newTestMigrationRunner(store, []migration{step("0001", nil), step("0002", failed), step("0003", nil)}).run()
+	if err == nil {
+		t.Fatalf("migrationRunner.run() error = nil, want the failed migration error")
+	}
+	if !reflect.DeepEqual(appliedSteps, []string{"0001", "0002"}) || !reflect.DeepEqual(store.recordIDs(), []string{"0001"}) {
+		t.Errorf("migrationRunner.run() applied %v and recorded %v, want the migrations up to the failed one recorded before it", appliedSteps, store.recordIDs())
+	}
+	if store.owner != "" {
+		t.Errorf("migrationRunner.run() did not release the lock after failing")
+	}
+
+	//the recorded migrations are not applied again
+	appliedSteps = nil
+	err = newTestMigrationRunner(store, []migration{step("0001", nil), step("0002", nil), step("0003", nil)}).run()
+	if err != nil {
+		t.Fatalf("migrationRunner.run() error = %v", err)
+	}
+	if !reflect.DeepEqual(appliedSteps, []string{"0002", "0003"}) || !reflect.DeepEqual(store.recordIDs(), []string{"0001", "0002", "0003"}) {
+		t.Errorf("migrationRunner.run() applied %v and recorded %v, want 0002 and 0003 applied and all recorded", appliedSteps, store.recordIDs())
+	}
+	if store.owner != "" {
+		t.Errorf("migrationRunner.run() did not release the lock")
+	}
+}
+
+func TestMigrationRunner_Lock(t *testing.T) {
+	store := newTestMigrationStore()
+	runner := newTestMigrationRunner(store, nil)
+
+	//a lock held by another replica times out
+	store.acquireMigrationsLock("other", time.Minute)
+	err := runner.run()
+	if err == nil {
+		t.Errorf("migrationRunner.run() error = nil, want a lock timeout while another replica holds the lock")
+	}
+
+	//an expired lock is taken over
+	store.acquireMigrationsLock("other", 0)
+	store.expiresAt = time.Now().Add(-time.Second)
+	err = runner.run()
+	if err != nil {
+		t.Errorf("migrationRunner.run() error = %v, want the expired lock taken over", err)
+	}
+	if len(store.lockHistory) != 2 {
+		t.Errorf("migrationRunner.run() lock owners = %v, want the other replica then the runner", store.lockHistory)
+	}
+}
+
+func TestMigrationRunner_RenewLock(t *testing.T) {
+	store := newTestMigrationStore()
+	runner := newTestMigrationRunner(store, nil)
+
+	//a migration running longer than the lock expiry keeps the lock
+	runner.steps = []migration{{id: "0001", apply: func() error {
+		time.Sleep(2 * runner.lockExpiry)
+		if locked, _ := store.acquireMigrationsLock("other", time.Minute); locked {
+			t.Errorf("migrationRunner.run() lock acquired by another replica during a long migration")
+		}
+		return nil
+	}}}
+	err := runner.run()
+	if err != nil {
+		t.Fatalf("migrationRunner.run() error = %v", err)
+	}
+	if store.renewals < 3 {
+		t.Errorf("migrationRunner.run() renewed the lock %d times, want it renewed in the background", store.renewals)
+	}
+}
+
+func TestMigrationRunner_LostLock(t *testing.T) {
+	store := newTestMigrationStore()
+	var appliedSteps []string
+	steps := []migration{
+		{id: "0001", apply: func() error {
+			appliedSteps = append(appliedSteps, "0001")
+			//another replica takes the lock over while the migration runs
+			store.lock.Lock()
+			store.owner = "other"
+			store.lock.Unlock()
+			return nil
+		}},
+		{id: "0002", apply: func() error {
+			appliedSteps = append(appliedSteps, "0002")
+			return nil
+		}},
+	}
+
+	err := newTestMigrationRunner(store, steps).run()
+	if err == nil {
+		t.Fatalf("migrationRunner.run() error = nil, want the lost lock error")
+	}
+	if !reflect.DeepEqual(appliedSteps, []string{"0001"}) || len(store.recordIDs()) != 0 {
+		t.Errorf("migrationRunner.run() applied %v and recorded %v after losing the lock, want nothing more applied or recorded", appliedSteps, store.recordIDs())
+	}
+	if store.owner != "other" {
+		t.Errorf("migrationRunner.run() released the lock of another replica")
+	}
+}
@@ -16,33 +16,192 @@ package storage
 
 import (
 	"application/core/model"
+	"application/utils"
+	"sort"
 
 	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/syncmap"
 )
 
-// GetOccupationData finds OccupationData by code
-func (a Adapter) GetOccupationData(code string) (*model.OccupationData, error) {
-	filter := bson.M{"code": code}
+// cacheOccupationDatas caches the occupation data from the DB
+func (a *Adapter) cacheOccupationDatas() error {
+	a.db.logger.Info("cacheOccupationDatas...")
 
-	var data *model.OccupationData
-	err := a.db.occupationData.FindOne(a.context, filter, &data, nil)
+	occupationDatas, err := a.loadOccupationDatas()
 	if err != nil {
-		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, filterArgs(filter), err)
+		return errors.WrapErrorAction(logutils.ActionLoad, model.TypeOccupationData, nil, err)
 	}
 
-	return data, nil
+	a.setCachedOccupationDatas(occupationDatas)
+
+	return nil
+}
+
+func (a *Adapter) setCachedOccupationDatas(occupationDatas []model.OccupationData) {
+	a.occupationDatasLock.Lock()
+	defer a.occupationDatasLock.Unlock()
+
+	a.cachedOccupationDatas = &syncmap.Map{}
+
+	for _, occupationData := range occupationDatas {
+		a.cachedOccupationDatas.Store(occupationData.Code, occupationData)
+	}
 }
 
-// GetAllOccupationDatas finds all OccupationDatas
-func (a Adapter) GetAllOccupationDatas() ([]model.OccupationData, error) {
+func (a *Adapter) getCachedOccupationData(code string) (*model.OccupationData, error) {
+	a.occupationDatasLock.RLock()
+	defer a.occupationDatasLock.RUnlock()
+
+	item, _ := a.cachedOccupationDatas.Load(code)
+	if item == nil {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, &logutils.FieldArgs{"code": code}).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	occupationData, ok := item.(model.OccupationData)
+	if !ok {
+		return nil, errors.ErrorAction(logutils.ActionCast, model.TypeOccupationData, &logutils.FieldArgs{"code": code})
+	}
+	return &occupationData, nil
+}
+
+func (a *Adapter) getCachedOccupationDatas() ([]model.OccupationData, error) {
+	a.occupationDatasLock.RLock()
+	defer a.occupationDatasLock.RUnlock()
+
+	var err error
+	occupationDatas := make([]model.OccupationData, 0)
+	a.cachedOccupationDatas.Range(func(key, item interface{}) bool {
+		occupationData, ok := item.(model.OccupationData)
+		if !ok {
+			err = errors.ErrorAction(logutils.ActionCast, model.TypeOccupationData, &logutils.FieldArgs{"code": key})
+			return false
+		}
+
+		occupationDatas = append(occupationDatas, occupationData)
+		return true
+	})
+
+	sort.Slice(occupationDatas, func(i, j int) bool {
+		return occupationDatas[i].Code < occupationDatas[j].Code
+	})
+	return occupationDatas, err
+}
+
+// updateCachedOccupationData applies a change made outside of a transaction to the cache, ahead of the change stream refreshing it
+func (a *Adapter) updateCachedOccupationData(code string, occupationData *model.OccupationData) {
+	if a.context != nil {
+		return
+	}
+
+	a.occupationDatasLock.RLock()
+	defer a.occupationDatasLock.RUnlock()
+
+	if occupationData != nil {
+		a.cachedOccupationDatas.Store(code, *occupationData)
+	} else {
+		a.cachedOccupationDatas.Delete(code)
+	}
+}
+
+// loadOccupationDatas loads all occupation data
+func (a *Adapter) loadOccupationDatas() ([]model.OccupationData, error) {
 	filter := bson.M{}
+
 	var data []model.OccupationData
-	err := a.db.occupationData.Find(a.context, filter, &data, nil)
+	err := a.db.occupationData.Find(a.operationContext(), filter, &data, nil)
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
 	}
 
 	return data, nil
 }
+
+// GetOccupationData finds the cached occupation data for the given code
+func (a *Adapter) GetOccupationData(code string) (*model.OccupationData, error) {
+	if a.context != nil {
+		return a.findOccupationData(code)
+	}
+	return a.getCachedOccupationData(code)
+}
+
+// GetAllOccupationDatas finds all cached occupation data
+func (a *Adapter) GetAllOccupationDatas() ([]model.OccupationData, error) {
+	if a.context != nil {
+		return a.loadOccupationDatas()
+	}
+	return a.getCachedOccupationDatas()
+}
+
+// findOccupationData finds the occupation data for the given code in the DB
+func (a *Adapter) findOccupationData(code string) (*model.OccupationData, error) {
+	filter := bson.M{"code": code}
+
+	var data *model.OccupationData
+	err := a.db.occupationData.FindOne(a.operationContext(), filter, &data, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, filterArgs(filter), err)
+	}
+
+	return data, nil
+}
+
+// occupationDataDocument is the stored occupationData, identified by its code so that its changes are reported with the code as in the other storage adapters
+type occupationDataDocument struct {
+	ID                   string `bson:"_id"`
+	model.OccupationData `bson:",inline"`
+}
+
+// InsertOccupationData inserts a new OccupationData
+func (a *Adapter) InsertOccupationData(occupationData model.OccupationData) error {
+	doc := occupationDataDocument{ID: occupationData.Code, OccupationData: occupationData}
+	_, err := a.db.occupationData.InsertOne(a.operationContext(), doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.ErrorData(logutils.StatusFound, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}).SetStatus(utils.ErrorStatusExists)
+	}
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeOccupationData, &logutils.FieldArgs{"code": occupationData.Code}, err)
+	}
+
+	a.updateCachedOccupationData(occupationData.Code, &occupationData)
+	return nil
+}
+
+// UpdateOccupationData replaces an existing OccupationData with the same code
+func (a *Adapter) UpdateOccupationData(occupationData model.OccupationData) error {
+	filter := bson.M{"code": occupationData.Code}
+
+	update := bson.M{"$set": occupationData}
+
+	res, err := a.db.occupationData.UpdateOne(a.operationContext(), filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeOccupationData, filterArgs(filter), err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	a.updateCachedOccupationData(occupationData.Code, &occupationData)
+	return nil
+}
+
+// DeleteOccupationData deletes an OccupationData by code
+func (a *Adapter) DeleteOccupationData(code string) error {
+	filter := bson.M{"code": code}
+
+	res, err := a.db.occupationData.DeleteOne(a.operationContext(), filter, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeOccupationData, filterArgs(filter), err)
+	}
+	if res.DeletedCount != 1 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeOccupationData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+
+	a.updateCachedOccupationData(code, nil)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	typeRetentionData logutils.MessageDataType = "retention data"
+)
+
+// retentionCollection returns the collection for the given retention collection name
+func (a *Adapter) retentionCollection(collection string) (*collectionWrapper, error) {
+	switch collection {
+	case model.RetentionCollectionSurveyResponses:
+		return a.db.surveyResponses, nil
+	case model.RetentionCollectionMatchResults:
+		return a.db.matchResults, nil
+	case model.RetentionCollectionMatchResultsHistory:
+		return a.db.matchResultsHistory, nil
+	}
+	return nil, errors.ErrorData(logutils.StatusInvalid, typeRetentionData, &logutils.FieldArgs{"collection": collection})
+}
+
+// updatedBeforeFilter matches the documents last updated, or created if never updated, before the cutoff
+func updatedBeforeFilter(cutoff time.Time) bson.M {
+	return bson.M{"$or": []bson.M{
+		{"date_updated": bson.M{"$lt": cutoff}},
+		{"date_updated": nil, "date_created": bson.M{"$lt": cutoff}},
+	}}
+}
+
+// GetDataRetentionStatus counts the documents in the collection last updated before the cutoff, and finds the earliest last update at or after the cutoff
+func (a *Adapter) GetDataRetentionStatus(collection string, cutoff time.Time) (int64, *time.Time, error) {
+	coll, err := a.retentionCollection(collection)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	count, err := coll.CountDocuments(a.operationContext(), updatedBeforeFilter(cutoff))
+	if err != nil {
+		return 0, nil, errors.WrapErrorAction(logutils.ActionCount, typeRetentionData, &logutils.FieldArgs{"collection": collection}, err)
+	}
+
+	pipeline := []bson.M{
+		{"$project": bson.M{"last_updated": bson.M{"$ifNull": []string{"$date_updated", "$date_created"}}}},
+		{"$match": bson.M{"last_updated": bson.M{"$gte": cutoff}}},
+		{"$group": bson.M{"_id": nil, "next": bson.M{"$min": "$last_updated"}}},
+	}
+	var result []struct {
+		Next time.Time `bson:"next"`
+	}
+	err = coll.Aggregate(a.operationContext(), pipeline, &result, nil)
+	if err != nil {
+		return 0, nil, errors.WrapErrorAction(logutils.ActionFind, typeRetentionData, &logutils.FieldArgs{"collection": collection}, err)
+	}
+	if len(result) == 0 {
+		return count, nil, nil
+	}
+
+	return count, &result[0].Next, nil
+}
+
+// DeleteDataUpdatedBefore deletes the documents in the collection last updated before the cutoff
+func (a *Adapter) DeleteDataUpdatedBefore(collection string, cutoff time.Time) (int64, error) {
+	coll, err := a.retentionCollection(collection)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := coll.DeleteMany(a.operationContext(), updatedBeforeFilter(cutoff), nil)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, typeRetentionData, &logutils.FieldArgs{"collection": collection, "cutoff": cutoff}, err)
+	}
+
+	return res.DeletedCount, nil
+}
@@ -16,29 +16,118 @@ package storage
 
 import (
 	"application/core/model"
+	"application/utils"
 	"time"
 
 	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// surveyDataDocument is the stored surveyData, with the scores encrypted when encryption keys are set
+type surveyDataDocument struct {
+	model.SurveyData `bson:",inline"`
+	EncryptedScores  *encryptedValue `bson:"encrypted_scores,omitempty"`
+}
+
+// encryptScores encrypts the scores of a surveyData when encryption keys are set
+func (a Adapter) encryptScores(surveyData model.SurveyData) (surveyDataDocument, error) {
+	doc := surveyDataDocument{SurveyData: surveyData}
+	if a.db.encryption == nil {
+		return doc, nil
+	}
+
+	encryptedScores, err := a.db.encryption.encrypt(surveyData.ID, surveyData.Scores)
+	if err != nil {
+		return doc, errors.WrapErrorAction(logutils.ActionEncrypt, model.TypeSurveyData, &logutils.FieldArgs{"id": surveyData.ID}, err)
+	}
+	doc.Scores = nil
+	doc.EncryptedScores = encryptedScores
+	return doc, nil
+}
+
+// decryptScores decrypts the scores of a stored surveyData when they are encrypted
+func (a Adapter) decryptScores(doc surveyDataDocument) (*model.SurveyData, error) {
+	if doc.EncryptedScores != nil {
+		var err error
+		doc.Scores, err = decryptValue[[]model.WorkstyleScore](a.db.encryption, doc.ID, doc.EncryptedScores)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDecrypt, model.TypeSurveyData, &logutils.FieldArgs{"id": doc.ID}, err)
+		}
+	}
+	return &doc.SurveyData, nil
+}
+
 // GetSurveyData finds surveyData by id
 func (a Adapter) GetSurveyData(id string) (*model.SurveyData, error) {
 	filter := bson.M{"_id": id}
 
-	var data *model.SurveyData
-	err := a.db.surveyResponses.FindOne(a.context, filter, &data, nil)
+	var doc *surveyDataDocument
+	err := a.db.surveyResponses.FindOne(a.operationContext(), filter, &doc, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, filterArgs(filter), err)
 	}
 
+	return a.decryptScores(*doc)
+}
+
+// FindSurveyDatas finds up to limit surveyDatas with IDs after afterID, ordered by ID
+func (a Adapter) FindSurveyDatas(afterID string, limit int) ([]model.SurveyData, error) {
+	filter := bson.M{"_id": bson.M{"$gt": afterID}}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	var docs []surveyDataDocument
+	err := a.db.surveyResponses.Find(a.operationContext(), filter, &docs, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"after_id": afterID}, err)
+	}
+
+	data := make([]model.SurveyData, len(docs))
+	for i, doc := range docs {
+		surveyData, err := a.decryptScores(doc)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *surveyData
+	}
+	return data, nil
+}
+
+// SearchSurveyDatas finds the surveyDatas matching the search, from the most recently created
+func (a Adapter) SearchSurveyDatas(search model.UserDataSearch) ([]model.SurveyData, error) {
+	filter := userDataSearchFilter(search, "account_id", "date_created")
+
+	var docs []surveyDataDocument
+	err := a.db.surveyResponses.Find(a.operationContext(), filter, &docs, userDataSearchOptions(search, "date_created"))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, filterArgs(filter), err)
+	}
+
+	data := make([]model.SurveyData, len(docs))
+	for i, doc := range docs {
+		surveyData, err := a.decryptScores(doc)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *surveyData
+	}
 	return data, nil
 }
 
 // CreateSurveyData inserts a new surveyData
 func (a Adapter) CreateSurveyData(surveyData model.SurveyData) error {
-	_, err := a.db.surveyResponses.InsertOne(a.context, surveyData)
+	doc, err := a.encryptScores(surveyData)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.surveyResponses.InsertOne(a.operationContext(), doc)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeSurveyData, nil, err)
 	}
@@ -46,15 +135,28 @@ func (a Adapter) CreateSurveyData(surveyData model.SurveyData) error {
 	return nil
 }
 
-// UpdateSurveyData updates a surveyData
+// UpdateSurveyData updates a surveyData, only if its revision matches when the surveyData revision is set
 func (a Adapter) UpdateSurveyData(surveyData model.SurveyData) error {
 	filter := bson.M{"_id": surveyData.ID}
-	update := bson.M{"$set": bson.M{"scores": surveyData.Scores, "date_updated": time.Now()}}
+	if surveyData.Revision > 0 {
+		filter["revision"] = surveyData.Revision
+	}
+	doc, err := a.encryptScores(surveyData)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$set": bson.M{"scores": doc.Scores, "encrypted_scores": doc.EncryptedScores, "date_updated": time.Now()}, "$inc": bson.M{"revision": 1}}
 
-	_, err := a.db.surveyResponses.UpdateOne(a.context, filter, update, nil)
+	res, err := a.db.surveyResponses.UpdateOne(a.operationContext(), filter, update, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeSurveyData, filterArgs(filter), err)
 	}
+	if surveyData.Revision > 0 && res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeSurveyData, filterArgs(filter)).SetStatus(utils.ErrorStatusConflict)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
 	return nil
 }
 
@@ -62,13 +164,24 @@ func (a Adapter) UpdateSurveyData(surveyData model.SurveyData) error {
 func (a Adapter) DeleteSurveyData(id string) error {
 	filter := bson.M{"_id": id}
 
-	res, err := a.db.surveyResponses.DeleteOne(a.context, filter, nil)
+	res, err := a.db.surveyResponses.DeleteOne(a.operationContext(), filter, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeSurveyData, filterArgs(filter), err)
 	}
 	if res.DeletedCount != 1 {
-		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, filterArgs(filter))
+		return errors.ErrorData(logutils.StatusMissing, model.TypeSurveyData, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
 	}
 
 	return nil
 }
+
+// DeleteSurveyDatasByAccount deletes the surveyDatas of an account, returning the number deleted
+func (a Adapter) DeleteSurveyDatasByAccount(accountID string) (int64, error) {
+	filter := bson.M{"account_id": accountID}
+
+	res, err := a.db.surveyResponses.DeleteMany(a.operationContext(), filter, nil)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeSurveyData, filterArgs(filter), err)
+	}
+	return res.DeletedCount, nil
+}
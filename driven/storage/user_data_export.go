@@ -0,0 +1,119 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+	"application/utils"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// userDataExportDocument is the stored userDataExport, with the data encrypted when encryption keys are set
+type userDataExportDocument struct {
+	model.UserDataExport `bson:",inline"`
+	EncryptedData        *encryptedValue `bson:"encrypted_data,omitempty"`
+}
+
+// encryptData encrypts the data of a userDataExport when encryption keys are set
+func (a Adapter) encryptData(userDataExport model.UserDataExport) (userDataExportDocument, error) {
+	doc := userDataExportDocument{UserDataExport: userDataExport}
+	if a.db.encryption == nil || userDataExport.Data == nil {
+		return doc, nil
+	}
+
+	encryptedData, err := a.db.encryption.encrypt(userDataExport.ID, userDataExport.Data)
+	if err != nil {
+		return doc, errors.WrapErrorAction(logutils.ActionEncrypt, model.TypeUserDataExport, &logutils.FieldArgs{"id": userDataExport.ID}, err)
+	}
+	doc.Data = nil
+	doc.EncryptedData = encryptedData
+	return doc, nil
+}
+
+// decryptData decrypts the data of a stored userDataExport when it is encrypted
+func (a Adapter) decryptData(doc userDataExportDocument) (*model.UserDataExport, error) {
+	if doc.EncryptedData != nil {
+		var err error
+		doc.Data, err = decryptValue[[]byte](a.db.encryption, doc.ID, doc.EncryptedData)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDecrypt, model.TypeUserDataExport, &logutils.FieldArgs{"id": doc.ID}, err)
+		}
+	}
+	return &doc.UserDataExport, nil
+}
+
+// InsertUserDataExport inserts a userDataExport
+func (a Adapter) InsertUserDataExport(userDataExport model.UserDataExport) error {
+	doc, err := a.encryptData(userDataExport)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.userDataExports.InsertOne(a.operationContext(), doc)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserDataExport, &logutils.FieldArgs{"id": userDataExport.ID}, err)
+	}
+	return nil
+}
+
+// GetUserDataExport finds a userDataExport by id
+func (a Adapter) GetUserDataExport(id string) (*model.UserDataExport, error) {
+	filter := bson.M{"_id": id}
+
+	var doc *userDataExportDocument
+	err := a.db.userDataExports.FindOne(a.operationContext(), filter, &doc, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserDataExport, filterArgs(filter), err)
+	}
+
+	return a.decryptData(*doc)
+}
+
+// UpdateUserDataExport sets the status, data and expiry date of a userDataExport
+func (a Adapter) UpdateUserDataExport(userDataExport model.UserDataExport) error {
+	doc, err := a.encryptData(userDataExport)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": userDataExport.ID}
+	update := bson.M{"$set": bson.M{"status": doc.Status, "data": doc.Data, "encrypted_data": doc.EncryptedData, "date_expires": doc.DateExpires}}
+	res, err := a.db.userDataExports.UpdateOne(a.operationContext(), filter, update, nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserDataExport, filterArgs(filter), err)
+	}
+	if res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserDataExport, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+	return nil
+}
+
+// DeleteUserDataExportsByAccount deletes the userDataExports of an account, returning the number deleted
+func (a Adapter) DeleteUserDataExportsByAccount(accountID string) (int64, error) {
+	filter := bson.M{"account_id": accountID}
+
+	res, err := a.db.userDataExports.DeleteMany(a.operationContext(), filter, nil)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserDataExport, &logutils.FieldArgs{"account_id": accountID}, err)
+	}
+	return res.DeletedCount, nil
+}
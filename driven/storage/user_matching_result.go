@@ -16,45 +16,158 @@ package storage
 
 import (
 	"application/core/model"
+	"application/utils"
 	"time"
 
 	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// userMatchingResultDocument is the stored userMatchingResult, with the matches encrypted when encryption keys are set
+type userMatchingResultDocument struct {
+	model.UserMatchingResult `bson:",inline"`
+	EncryptedMatches         *encryptedValue `bson:"encrypted_matches,omitempty"`
+}
+
+// encryptMatches encrypts the matches of a userMatchingResult when encryption keys are set
+func (a Adapter) encryptMatches(userMatchingResult model.UserMatchingResult) (userMatchingResultDocument, error) {
+	doc := userMatchingResultDocument{UserMatchingResult: userMatchingResult}
+	if a.db.encryption == nil {
+		return doc, nil
+	}
+
+	encryptedMatches, err := a.db.encryption.encrypt(userMatchingResult.ID, userMatchingResult.Matches)
+	if err != nil {
+		return doc, errors.WrapErrorAction(logutils.ActionEncrypt, model.TypeUserMatchingResult, &logutils.FieldArgs{"id": userMatchingResult.ID}, err)
+	}
+	doc.Matches = nil
+	doc.EncryptedMatches = encryptedMatches
+	return doc, nil
+}
+
+// decryptMatches decrypts the matches of a stored userMatchingResult when they are encrypted
+func (a Adapter) decryptMatches(doc userMatchingResultDocument) (*model.UserMatchingResult, error) {
+	if doc.EncryptedMatches != nil {
+		var err error
+		doc.Matches, err = decryptValue[[]model.Match](a.db.encryption, doc.ID, doc.EncryptedMatches)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionDecrypt, model.TypeUserMatchingResult, &logutils.FieldArgs{"id": doc.ID}, err)
+		}
+	}
+	return &doc.UserMatchingResult, nil
+}
+
 // GetUserMatchingResult finds userMatchingResult by id
 func (a Adapter) GetUserMatchingResult(id string) (*model.UserMatchingResult, error) {
 	filter := bson.M{"_id": id}
 
-	var data *model.UserMatchingResult
-	err := a.db.matchResults.FindOne(a.context, filter, &data, nil)
+	var doc *userMatchingResultDocument
+	err := a.db.matchResults.FindOne(a.operationContext(), filter, &doc, nil)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrorData(logutils.StatusMissing, model.TypeUserMatchingResult, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
+	}
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, filterArgs(filter), err)
+	}
+
+	return a.decryptMatches(*doc)
+}
+
+// FindUserMatchingResults finds up to limit userMatchingResults with IDs after afterID, ordered by ID
+func (a Adapter) FindUserMatchingResults(afterID string, limit int) ([]model.UserMatchingResult, error) {
+	filter := bson.M{"_id": bson.M{"$gt": afterID}}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+
+	var docs []userMatchingResultDocument
+	err := a.db.matchResults.Find(a.operationContext(), filter, &docs, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"after_id": afterID}, err)
+	}
+
+	data := make([]model.UserMatchingResult, len(docs))
+	for i, doc := range docs {
+		userMatchingResult, err := a.decryptMatches(doc)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *userMatchingResult
+	}
+	return data, nil
+}
+
+// SearchUserMatchingResults finds the userMatchingResults matching the search, from the most recently updated
+func (a Adapter) SearchUserMatchingResults(search model.UserDataSearch) ([]model.UserMatchingResult, error) {
+	filter := userDataSearchFilter(search, "_id", "date_updated")
+
+	var docs []userMatchingResultDocument
+	err := a.db.matchResults.Find(a.operationContext(), filter, &docs, userDataSearchOptions(search, "date_updated"))
 	if err != nil {
 		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, filterArgs(filter), err)
 	}
 
+	data := make([]model.UserMatchingResult, len(docs))
+	for i, doc := range docs {
+		userMatchingResult, err := a.decryptMatches(doc)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *userMatchingResult
+	}
 	return data, nil
 }
 
-// SaveUserMatchingResult saves a userMatchingResult
+// InsertUserMatchingResult inserts a new userMatchingResult
+func (a Adapter) InsertUserMatchingResult(userMatchingResult model.UserMatchingResult) error {
+	doc, err := a.encryptMatches(userMatchingResult)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.matchResults.InsertOne(a.operationContext(), doc)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserMatchingResult, nil, err)
+	}
+
+	return nil
+}
+
+// SaveUserMatchingResult inserts or updates a userMatchingResult, only updating it if its revision matches when the userMatchingResult revision is set
 func (a Adapter) SaveUserMatchingResult(userMatchingResult model.UserMatchingResult) error {
 	filter := bson.M{"_id": userMatchingResult.ID}
+	if userMatchingResult.Revision > 0 {
+		filter["revision"] = userMatchingResult.Revision
+	}
+	doc, err := a.encryptMatches(userMatchingResult)
+	if err != nil {
+		return err
+	}
 	update := bson.M{
 		"$set": bson.M{
-			"matches":      userMatchingResult.Matches,
-			"date_updated": time.Now().UTC(),
+			"version":           doc.Version,
+			"matches":           doc.Matches,
+			"encrypted_matches": doc.EncryptedMatches,
+			"date_updated":      time.Now().UTC(),
 		},
 		"$setOnInsert": bson.M{
 			"date_created": time.Now().UTC(),
 		},
+		"$inc": bson.M{
+			"revision": 1,
+		},
 	}
 
-	opts := options.Update().SetUpsert(true)
-	_, err := a.db.matchResults.UpdateOne(a.context, filter, update, opts)
+	opts := options.Update().SetUpsert(userMatchingResult.Revision == 0)
+	res, err := a.db.matchResults.UpdateOne(a.operationContext(), filter, update, opts)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionUpdate, model.TypeUserMatchingResult, filterArgs(filter), err)
 	}
+	if userMatchingResult.Revision > 0 && res.MatchedCount == 0 {
+		return errors.ErrorData(logutils.StatusInvalid, model.TypeUserMatchingResult, filterArgs(filter)).SetStatus(utils.ErrorStatusConflict)
+	}
 	return nil
 }
 
@@ -62,12 +175,12 @@ func (a Adapter) SaveUserMatchingResult(userMatchingResult model.UserMatchingRes
 func (a Adapter) DeleteUserMatchingResult(id string) error {
 	filter := bson.M{"_id": id}
 
-	res, err := a.db.matchResults.DeleteOne(a.context, filter, nil)
+	res, err := a.db.matchResults.DeleteOne(a.operationContext(), filter, nil)
 	if err != nil {
 		return errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserMatchingResult, filterArgs(filter), err)
 	}
 	if res.DeletedCount != 1 {
-		return errors.ErrorData(logutils.StatusMissing, model.TypeConfig, filterArgs(filter))
+		return errors.ErrorData(logutils.StatusMissing, model.TypeUserMatchingResult, filterArgs(filter)).SetStatus(utils.ErrorStatusNotFound)
 	}
 
 	return nil
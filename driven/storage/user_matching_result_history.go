@@ -0,0 +1,85 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"application/core/model"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// userMatchingResultHistoryDocument is the stored userMatchingResultHistory, with the matches encrypted when encryption keys are set
+type userMatchingResultHistoryDocument struct {
+	model.UserMatchingResultHistory `bson:",inline"`
+	EncryptedMatches                *encryptedValue `bson:"encrypted_matches,omitempty"`
+}
+
+// InsertUserMatchingResultHistory inserts a userMatchingResultHistory
+func (a Adapter) InsertUserMatchingResultHistory(history model.UserMatchingResultHistory) error {
+	doc := userMatchingResultHistoryDocument{UserMatchingResultHistory: history}
+	if a.db.encryption != nil {
+		encryptedMatches, err := a.db.encryption.encrypt(history.ID, history.Matches)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionEncrypt, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"id": history.ID}, err)
+		}
+		doc.Matches = nil
+		doc.EncryptedMatches = encryptedMatches
+	}
+
+	_, err := a.db.matchResultsHistory.InsertOne(a.operationContext(), doc)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionInsert, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"id": history.ID}, err)
+	}
+	return nil
+}
+
+// FindUserMatchingResultHistories finds the userMatchingResultHistories of an account, from the oldest
+func (a Adapter) FindUserMatchingResultHistories(accountID string) ([]model.UserMatchingResultHistory, error) {
+	filter := bson.M{"account_id": accountID}
+	findOptions := options.Find().SetSort(bson.D{primitive.E{Key: "date_created", Value: 1}})
+
+	var docs []userMatchingResultHistoryDocument
+	err := a.db.matchResultsHistory.Find(a.operationContext(), filter, &docs, findOptions)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResultHistory, filterArgs(filter), err)
+	}
+
+	histories := make([]model.UserMatchingResultHistory, len(docs))
+	for i, doc := range docs {
+		if doc.EncryptedMatches != nil {
+			doc.Matches, err = decryptValue[[]model.Match](a.db.encryption, doc.ID, doc.EncryptedMatches)
+			if err != nil {
+				return nil, errors.WrapErrorAction(logutils.ActionDecrypt, model.TypeUserMatchingResultHistory, &logutils.FieldArgs{"id": doc.ID}, err)
+			}
+		}
+		histories[i] = doc.UserMatchingResultHistory
+	}
+	return histories, nil
+}
+
+// DeleteUserMatchingResultHistoriesByAccount deletes the userMatchingResultHistories of an account, returning the number deleted
+func (a Adapter) DeleteUserMatchingResultHistoriesByAccount(accountID string) (int64, error) {
+	filter := bson.M{"account_id": accountID}
+
+	res, err := a.db.matchResultsHistory.DeleteMany(a.operationContext(), filter, nil)
+	if err != nil {
+		return 0, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserMatchingResultHistory, filterArgs(filter), err)
+	}
+	return res.DeletedCount, nil
+}
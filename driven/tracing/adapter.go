@@ -0,0 +1,105 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	// ExporterNone disables tracing
+	ExporterNone string = "none"
+	// ExporterOTLP exports the spans to an OTLP collector over HTTP
+	ExporterOTLP string = "otlp"
+	// ExporterStdout writes the spans to the standard output
+	ExporterStdout string = "stdout"
+)
+
+// Adapter sets the global tracer provider used by the service, exporting the spans with the configured exporter
+//
+//	The W3C trace context is propagated from and to the clients whether tracing is enabled or not.
+type Adapter struct {
+	exporter       string
+	serviceName    string
+	serviceVersion string
+
+	provider *sdktrace.TracerProvider
+
+	logger *logs.Logger
+}
+
+// Start creates the exporter and sets the global tracer provider
+func (a *Adapter) Start() error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch a.exporter {
+	case "", ExporterNone:
+		a.logger.Info("tracing disabled")
+		return nil
+	case ExporterOTLP:
+		// the endpoint and headers are set by the standard OTEL_EXPORTER_OTLP_* environment variables
+		exporter, err = otlptracehttp.New(context.Background())
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		err = fmt.Errorf("unknown exporter %s", a.exporter)
+	}
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCreate, "trace exporter", &logutils.FieldArgs{"exporter": a.exporter}, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(a.serviceName), semconv.ServiceVersion(a.serviceVersion)))
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionCreate, "trace resource", nil, err)
+	}
+
+	// the sampler is set by the standard OTEL_TRACES_SAMPLER environment variables, sampling all traces by default
+	a.provider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(a.provider)
+	a.logger.Infof("tracing with the %s exporter", a.exporter)
+	return nil
+}
+
+// Stop exports the remaining spans and stops the exporter until the context is done
+func (a *Adapter) Stop(ctx context.Context) error {
+	if a.provider == nil {
+		return nil
+	}
+	err := a.provider.Shutdown(ctx)
+	if err != nil {
+		return errors.WrapErrorAction("stopping", "tracer provider", nil, err)
+	}
+	return nil
+}
+
+// NewTracingAdapter creates a new tracing adapter exporting the spans of the service with the given exporter
+func NewTracingAdapter(exporter string, serviceName string, serviceVersion string, logger *logs.Logger) *Adapter {
+	return &Adapter{exporter: exporter, serviceName: serviceName, serviceVersion: serviceVersion, logger: logger}
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"application/driven/tracing"
+	"context"
+	"testing"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+func TestAdapter_Start(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{"disabled", "", false},
+		{"none", tracing.ExporterNone, false},
+		{"stdout", tracing.ExporterStdout, false},
+		{"unknown", "zipkin", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := tracing.NewTracingAdapter(tt.exporter, "skills-to-jobs", "test", logs.NewLogger("test", nil))
+			err := adapter.Start()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := adapter.Stop(context.Background()); err != nil {
+				t.Errorf("Stop() error = %v", err)
+			}
+		})
+	}
+}
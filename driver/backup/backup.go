@@ -0,0 +1,328 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"application/core/interfaces"
+	"application/core/model"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	typeArchive       logutils.MessageDataType = "backup archive"
+	typeArchiveRecord logutils.MessageDataType = "backup archive record"
+
+	// ArchiveFormat is the format name in the header of the backup archives
+	ArchiveFormat string = "skills-to-jobs-backup"
+	// ArchiveVersion is the version of the backup archives written by Export
+	ArchiveVersion int = 1
+
+	// CollectionConfigs is the configs collection name
+	CollectionConfigs string = "configs"
+	// CollectionOccupationData is the occupation data collection name
+	CollectionOccupationData string = "occupation_data"
+	// CollectionSurveyResponses is the survey responses collection name
+	CollectionSurveyResponses string = "survey_responses"
+	// CollectionMatchResults is the match results collection name
+	CollectionMatchResults string = "match_results"
+
+	pageSize int = 100
+)
+
+// Collections are the collections that can be backed up, in the order they are exported
+var Collections = []string{CollectionConfigs, CollectionOccupationData, CollectionSurveyResponses, CollectionMatchResults}
+
+// archiveHeader is the first line of a backup archive
+type archiveHeader struct {
+	Format         string    `json:"format"`
+	Version        int       `json:"version"`
+	ServiceVersion string    `json:"service_version"`
+	Collections    []string  `json:"collections"`
+	DateCreated    time.Time `json:"date_created"`
+}
+
+// archiveRecord is a line of a backup archive holding a document of a collection
+type archiveRecord struct {
+	Collection string          `json:"collection"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// RestoreOptions are the options used to restore a backup archive
+type RestoreOptions struct {
+	// Collections are the collections to restore, all of those in the archive if empty
+	Collections []string
+	// RemapIDs assigns new IDs to the restored configs and survey responses
+	RemapIDs bool
+	// Anonymize replaces the user IDs of the restored survey responses and match results with random IDs, keeping the responses and results of a user linked
+	Anonymize bool
+	// Replace deletes any stored document with the ID of a restored document before restoring it
+	Replace bool
+}
+
+// Export writes the documents of the given collections, or all collections if empty, to a JSONL backup archive, returning the number of documents exported per collection
+func Export(storage interfaces.Storage, w io.Writer, collections []string, serviceVersion string) (map[string]int, error) {
+	if len(collections) == 0 {
+		collections = Collections
+	}
+	for _, collection := range collections {
+		if !isCollection(collection) {
+			return nil, errors.ErrorData(logutils.StatusInvalid, typeArchive, &logutils.FieldArgs{"collection": collection})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	header := archiveHeader{Format: ArchiveFormat, Version: ArchiveVersion, ServiceVersion: serviceVersion, Collections: collections, DateCreated: time.Now().UTC()}
+	err := encoder.Encode(header)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionEncode, typeArchive, nil, err)
+	}
+
+	counts := map[string]int{}
+	write := func(collection string, data interface{}) error {
+		bytes, err := json.Marshal(data)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionMarshal, typeArchiveRecord, &logutils.FieldArgs{"collection": collection}, err)
+		}
+		err = encoder.Encode(archiveRecord{Collection: collection, Data: bytes})
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionEncode, typeArchiveRecord, &logutils.FieldArgs{"collection": collection}, err)
+		}
+		counts[collection]++
+		return nil
+	}
+
+	for _, collection := range collections {
+		switch collection {
+		case CollectionConfigs:
+			err = exportConfigs(storage, write)
+		case CollectionOccupationData:
+			err = exportOccupationData(storage, write)
+		case CollectionSurveyResponses:
+			err = exportSurveyResponses(storage, write)
+		case CollectionMatchResults:
+			err = exportMatchResults(storage, write)
+		}
+		if err != nil {
+			return counts, err
+		}
+	}
+	return counts, nil
+}
+
+func exportConfigs(storage interfaces.Storage, write func(collection string, data interface{}) error) error {
+	configs, err := storage.FindConfigs(nil)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, model.TypeConfig, nil, err)
+	}
+	for _, config := range configs {
+		err = write(CollectionConfigs, config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportOccupationData(storage interfaces.Storage, write func(collection string, data interface{}) error) error {
+	occupationDatas, err := storage.GetAllOccupationDatas()
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionFind, model.TypeOccupationData, nil, err)
+	}
+	for _, occupationData := range occupationDatas {
+		err = write(CollectionOccupationData, occupationData)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportSurveyResponses(storage interfaces.Storage, write func(collection string, data interface{}) error) error {
+	afterID := ""
+	for {
+		surveyDatas, err := storage.FindSurveyDatas(afterID, pageSize)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeSurveyData, &logutils.FieldArgs{"after_id": afterID}, err)
+		}
+		for _, surveyData := range surveyDatas {
+			err = write(CollectionSurveyResponses, surveyData)
+			if err != nil {
+				return err
+			}
+			afterID = surveyData.ID
+		}
+		if len(surveyDatas) < pageSize {
+			return nil
+		}
+	}
+}
+
+func exportMatchResults(storage interfaces.Storage, write func(collection string, data interface{}) error) error {
+	afterID := ""
+	for {
+		userMatchingResults, err := storage.FindUserMatchingResults(afterID, pageSize)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionFind, model.TypeUserMatchingResult, &logutils.FieldArgs{"after_id": afterID}, err)
+		}
+		for _, userMatchingResult := range userMatchingResults {
+			err = write(CollectionMatchResults, userMatchingResult)
+			if err != nil {
+				return err
+			}
+			afterID = userMatchingResult.ID
+		}
+		if len(userMatchingResults) < pageSize {
+			return nil
+		}
+	}
+}
+
+// Restore inserts the documents of a JSONL backup archive, returning the number of documents restored per collection
+//
+//	Restoring stops at the first document that cannot be restored. Documents are restored as they were exported, so
+//	documents with IDs already in the storage are not restored unless the options remap their IDs or replace them.
+func Restore(storage interfaces.Storage, r io.Reader, options RestoreOptions) (map[string]int, error) {
+	decoder := json.NewDecoder(r)
+	var header archiveHeader
+	err := decoder.Decode(&header)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionDecode, typeArchive, nil, err)
+	}
+	if header.Format != ArchiveFormat || header.Version < 1 || header.Version > ArchiveVersion {
+		return nil, errors.ErrorData(logutils.StatusInvalid, typeArchive, &logutils.FieldArgs{"format": header.Format, "version": header.Version})
+	}
+
+	restore := map[string]bool{}
+	for _, collection := range options.Collections {
+		if !isCollection(collection) {
+			return nil, errors.ErrorData(logutils.StatusInvalid, typeArchive, &logutils.FieldArgs{"collection": collection})
+		}
+		restore[collection] = true
+	}
+
+	restorer := restorer{storage: storage, options: options, userIDs: map[string]string{}}
+	counts := map[string]int{}
+	for line := 2; ; line++ {
+		var record archiveRecord
+		err = decoder.Decode(&record)
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return counts, errors.WrapErrorAction(logutils.ActionDecode, typeArchiveRecord, &logutils.FieldArgs{"line": line}, err)
+		}
+		if len(restore) > 0 && !restore[record.Collection] {
+			continue
+		}
+
+		err = restorer.restore(record)
+		if err != nil {
+			return counts, errors.WrapErrorAction(logutils.ActionSave, typeArchiveRecord, &logutils.FieldArgs{"line": line, "collection": record.Collection}, err)
+		}
+		counts[record.Collection]++
+	}
+}
+
+// restorer restores the records of a backup archive
+type restorer struct {
+	storage interfaces.Storage
+	options RestoreOptions
+
+	// userIDs maps the user IDs in the archive to their anonymized IDs
+	userIDs map[string]string
+}
+
+// restore inserts the document of a record
+//
+//	When replacing, errors deleting the stored document are ignored as it may not exist, and any other error is reported by the insert.
+func (r *restorer) restore(record archiveRecord) error {
+	switch record.Collection {
+	case CollectionConfigs:
+		var config model.Config
+		err := json.Unmarshal(record.Data, &config)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeConfig, nil, err)
+		}
+		if r.options.RemapIDs {
+			config.ID = uuid.NewString()
+		} else if r.options.Replace {
+			r.storage.DeleteConfig(config.ID)
+		}
+		return r.storage.InsertConfig(config)
+	case CollectionOccupationData:
+		var occupationData model.OccupationData
+		err := json.Unmarshal(record.Data, &occupationData)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeOccupationData, nil, err)
+		}
+		if r.options.Replace {
+			r.storage.DeleteOccupationData(occupationData.Code)
+		}
+		return r.storage.InsertOccupationData(occupationData)
+	case CollectionSurveyResponses:
+		var surveyData model.SurveyData
+		err := json.Unmarshal(record.Data, &surveyData)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeSurveyData, nil, err)
+		}
+		if r.options.RemapIDs {
+			surveyData.ID = uuid.NewString()
+		} else if r.options.Replace {
+			r.storage.DeleteSurveyData(surveyData.ID)
+		}
+		if r.options.Anonymize && surveyData.AccountID != "" {
+			surveyData.AccountID = r.anonymizedUserID(surveyData.AccountID)
+		}
+		return r.storage.CreateSurveyData(surveyData)
+	case CollectionMatchResults:
+		var userMatchingResult model.UserMatchingResult
+		err := json.Unmarshal(record.Data, &userMatchingResult)
+		if err != nil {
+			return errors.WrapErrorAction(logutils.ActionUnmarshal, model.TypeUserMatchingResult, nil, err)
+		}
+		if r.options.Anonymize {
+			userMatchingResult.ID = r.anonymizedUserID(userMatchingResult.ID)
+		} else if r.options.Replace {
+			r.storage.DeleteUserMatchingResult(userMatchingResult.ID)
+		}
+		return r.storage.InsertUserMatchingResult(userMatchingResult)
+	}
+	return errors.ErrorData(logutils.StatusInvalid, typeArchiveRecord, &logutils.FieldArgs{"collection": record.Collection})
+}
+
+// anonymizedUserID returns the random ID replacing a user ID, which is the same for all documents of the user
+func (r *restorer) anonymizedUserID(userID string) string {
+	anonymizedID, ok := r.userIDs[userID]
+	if !ok {
+		anonymizedID = uuid.NewString()
+		r.userIDs[userID] = anonymizedID
+	}
+	return anonymizedID
+}
+
+func isCollection(collection string) bool {
+	for _, item := range Collections {
+		if item == collection {
+			return true
+		}
+	}
+	return false
+}
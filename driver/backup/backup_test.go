@@ -0,0 +1,107 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup_test
+
+import (
+	"application/core/model"
+	"application/driven/memory"
+	"application/driver/backup"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rokwire/logging-library-go/v2/logs"
+)
+
+func buildTestStorage(t *testing.T) *memory.Adapter {
+	storage := memory.NewStorageAdapter("", logs.NewLogger("skills-to-jobs", nil))
+	err := storage.Start()
+	if err != nil {
+		t.Fatalf("memory.Adapter.Start() error = %v", err)
+	}
+	return storage
+}
+
+func TestExportRestore(t *testing.T) {
+	source := buildTestStorage(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	source.InsertConfig(model.Config{ID: "config", Type: "test", AppID: "all", OrgID: "all", Data: map[string]interface{}{"key": "value"}, DateCreated: now})
+	source.InsertOccupationData(model.OccupationData{Code: "15-1252.00", Name: "Software Developers"})
+	for _, id := range []string{"survey-1", "survey-2"} {
+		source.CreateSurveyData(model.SurveyData{ID: id, AccountID: "user", Scores: []model.WorkstyleScore{{Workstyle: "leadership", Score: 4}}, DateCreated: now, Revision: 1})
+	}
+	source.InsertUserMatchingResult(model.UserMatchingResult{ID: "user", Version: "v3.0", Matches: []model.Match{{MatchPercent: 50}}, DateCreated: now, Revision: 1})
+
+	var archive bytes.Buffer
+	counts, err := backup.Export(source, &archive, nil, "test")
+	if err != nil {
+		t.Fatalf("backup.Export() error = %v", err)
+	}
+	want := map[string]int{backup.CollectionConfigs: 1, backup.CollectionOccupationData: 1, backup.CollectionSurveyResponses: 2, backup.CollectionMatchResults: 1}
+	for collection, count := range want {
+		if counts[collection] != count {
+			t.Errorf("backup.Export() %s count = %d, want %d", collection, counts[collection], count)
+		}
+	}
+	if lines := strings.Count(archive.String(), "\n"); lines != 6 {
+		t.Errorf("backup.Export() archive lines = %d, want 6", lines)
+	}
+
+	// restoring into the same storage fails on the existing IDs unless they are replaced
+	if _, err = backup.Restore(source, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{}); err == nil {
+		t.Errorf("backup.Restore() with existing IDs error = nil, want error")
+	}
+	if _, err = backup.Restore(source, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{Replace: true}); err != nil {
+		t.Errorf("backup.Restore() replacing error = %v", err)
+	}
+
+	target := buildTestStorage(t)
+	options := backup.RestoreOptions{Collections: []string{backup.CollectionSurveyResponses, backup.CollectionMatchResults}, Anonymize: true}
+	counts, err = backup.Restore(target, bytes.NewReader(archive.Bytes()), options)
+	if err != nil {
+		t.Fatalf("backup.Restore() error = %v", err)
+	}
+	if counts[backup.CollectionConfigs] != 0 || counts[backup.CollectionSurveyResponses] != 2 || counts[backup.CollectionMatchResults] != 1 {
+		t.Errorf("backup.Restore() counts = %v", counts)
+	}
+
+	surveyData, err := target.GetSurveyData("survey-1")
+	if err != nil {
+		t.Fatalf("memory.Adapter.GetSurveyData() error = %v", err)
+	}
+	if surveyData.AccountID == "user" || !surveyData.DateCreated.Equal(now) || len(surveyData.Scores) != 1 {
+		t.Errorf("backup.Restore() survey data = %+v", surveyData)
+	}
+	results, _ := target.FindUserMatchingResults("", 10)
+	if len(results) != 1 || results[0].ID != surveyData.AccountID || results[0].Version != "v3.0" {
+		t.Errorf("backup.Restore() match results = %+v, want anonymized ID %s", results, surveyData.AccountID)
+	}
+}
+
+func TestRestore_InvalidArchive(t *testing.T) {
+	storage := buildTestStorage(t)
+	archives := []string{
+		``,
+		`{"format": "other", "version": 1}`,
+		`{"format": "skills-to-jobs-backup", "version": 2}`,
+		"{\"format\": \"skills-to-jobs-backup\", \"version\": 1}\n{\"collection\": \"unknown\", \"data\": {}}",
+	}
+	for _, archive := range archives {
+		if _, err := backup.Restore(storage, strings.NewReader(archive), backup.RestoreOptions{}); err == nil {
+			t.Errorf("backup.Restore(%q) error = nil, want error", archive)
+		}
+	}
+}
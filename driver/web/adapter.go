@@ -16,10 +16,18 @@ package web
 
 import (
 	"application/core"
+	"application/core/model"
+	Def "application/driver/web/docs/gen"
+	"application/utils"
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -28,19 +36,33 @@ import (
 	"github.com/rokwire/core-auth-library-go/v3/authservice"
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
 
+	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logs"
 	"github.com/rokwire/logging-library-go/v2/logutils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// tracer creates the spans of the handled requests with the tracer provider set by the tracing adapter
+var tracer = otel.Tracer("application/driver/web")
+
 // Adapter entity
 type Adapter struct {
 	baseURL   string
 	port      string
 	serviceID string
 
-	auth *Auth
+	auth             *Auth
+	validator        *openAPIValidator
+	middlewareConfig MiddlewareConfig
+	metrics          Metrics
+
+	server *http.Server
 
 	cachedYamlDoc []byte
 
@@ -53,10 +75,69 @@ type Adapter struct {
 	logger *logs.Logger
 }
 
-type handlerFunc = func(*logs.Log, *http.Request, *tokenauth.Claims) logs.HTTPResponse
+// apisHandler implements the generated server interface with the default, client and admin API handlers
+type apisHandler struct {
+	DefaultAPIsHandler
+	ClientAPIsHandler
+	AdminAPIsHandler
+}
+
+// Metrics records the metrics of the handled requests and serves the metrics of the service
+type Metrics interface {
+	ObserveRequest(method string, route string, statusCode int, duration time.Duration)
+	Handler() http.Handler
+}
+
+// ServerConfig sets the timeouts of the HTTP server
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
 
-// Start starts the module
-func (a Adapter) Start() {
+// Start starts the module, serving the APIs until it is stopped
+func (a Adapter) Start() error {
+	listener, err := net.Listen("tcp", a.server.Addr)
+	if err != nil {
+		return errors.WrapErrorAction(logutils.ActionStart, "listener", &logutils.FieldArgs{"address": a.server.Addr}, err)
+	}
+	a.logger.Infof("serving on %s", listener.Addr())
+	return a.serve(listener)
+}
+
+// Stop stops accepting requests and waits for the requests in progress to complete until the context is done
+//
+//	The event streams are ended, as they would otherwise last until the context is done.
+func (a Adapter) Stop(ctx context.Context) error {
+	err := a.server.Shutdown(ctx)
+	if err != nil {
+		return errors.WrapErrorAction("stopping", "server", nil, err)
+	}
+	return nil
+}
+
+// serve serves the APIs on the listener until the server is stopped
+func (a Adapter) serve(listener net.Listener) error {
+	err := a.server.Serve(listener)
+	if err != nil && err != http.ErrServerClosed {
+		return errors.WrapErrorAction("serving", "server", nil, err)
+	}
+	return nil
+}
+
+func newServer(port string, config ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{Addr: ":" + port, Handler: handler, ReadHeaderTimeout: config.ReadHeaderTimeout, ReadTimeout: config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout, IdleTimeout: config.IdleTimeout}
+}
+
+// routes registers the routes of the service and wraps them with the middlewares of their routers
+func (a Adapter) routes() http.Handler {
+	middlewares := a.middlewares()
+
+	handler := apisHandler{DefaultAPIsHandler: a.defaultAPIsHandler, ClientAPIsHandler: a.clientAPIsHandler, AdminAPIsHandler: a.adminAPIsHandler}
+	options := Def.StrictHTTPServerOptions{RequestErrorHandlerFunc: handleRequestError, ResponseErrorHandlerFunc: handleError}
+	server := Def.ServerInterfaceWrapper{Handler: Def.NewStrictHandlerWithOptions(handler, nil, options), ErrorHandlerFunc: handleRequestError}
 
 	router := mux.NewRouter().StrictSlash(true)
 
@@ -64,33 +145,69 @@ func (a Adapter) Start() {
 	baseRouter := router.PathPrefix("/" + a.serviceID).Subrouter()
 	baseRouter.PathPrefix("/doc/ui").Handler(a.serveDocUI())
 	baseRouter.HandleFunc("/doc", a.serveDoc)
-	baseRouter.HandleFunc("/version", a.wrapFunc(a.defaultAPIsHandler.version, nil)).Methods("GET")
+	baseRouter.HandleFunc("/version", a.wrapFunc(server.GetVersion, nil)).Methods("GET")
+	baseRouter.HandleFunc("/health/live", a.wrapFunc(server.GetHealthLive, nil)).Methods("GET")
+	baseRouter.HandleFunc("/health/ready", a.wrapFunc(server.GetHealthReady, nil)).Methods("GET")
+	if a.metrics != nil {
+		baseRouter.Handle("/metrics", a.metrics.Handler()).Methods("GET")
+	}
 
 	mainRouter := baseRouter.PathPrefix("/api").Subrouter()
+	use(mainRouter, middlewares.main...)
 
 	// Client APIs
 
 	// Occupation API
-	mainRouter.HandleFunc("/occupation/{code}", a.wrapFunc(a.clientAPIsHandler.getOccupationData, a.auth.client.User)).Methods("GET")
-	// mainRouter.HandleFunc("/occupation", a.wrapFunc(a.clientAPIsHandler.getAllOccupationDatas, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/occupation/{code}", a.wrapFunc(server.GetApiOccupationCode, a.auth.client.User)).Methods("GET")
+	// mainRouter.HandleFunc("/occupation", a.wrapFunc(server.GetApiOccupation, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/occupation/{code}/related", a.wrapFunc(server.GetApiOccupationCodeRelated, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/occupation-pathways", a.wrapFunc(server.GetApiOccupationPathways, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/occupation/{code}/crosswalks", a.wrapFunc(server.GetApiOccupationCodeCrosswalks, a.auth.client.User)).Methods("GET")
+
+	// Crosswalk API
+	mainRouter.HandleFunc("/crosswalks", a.wrapFunc(server.GetApiCrosswalks, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/crosswalks/{system}/occupations", a.wrapFunc(server.GetApiCrosswalksSystemOccupations, a.auth.client.User)).Methods("GET")
 
 	// UserMatchingResult API
-	mainRouter.HandleFunc("/user-match-results", a.wrapFunc(a.clientAPIsHandler.getUserMatchingResult, a.auth.client.User)).Methods("GET")
-	// mainRouter.HandleFunc("/user-match-results", a.wrapFunc(a.clientAPIsHandler.deleteUserMatchingResult, a.auth.client.User)).Methods("DELETE")
+	mainRouter.HandleFunc("/user-match-results", a.wrapFunc(server.GetApiUserMatchResults, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/matching-events", a.wrapFunc(server.GetApiMatchingEvents, a.auth.client.User)).Methods("GET")
+	// mainRouter.HandleFunc("/user-match-results", a.wrapFunc(server.DeleteApiUserMatchResults, a.auth.client.User)).Methods("DELETE")
+
+	// User Data Export API, the exports being downloaded with the token of their link
+	mainRouter.HandleFunc("/user-data/export", a.wrapFunc(server.GetApiUserDataExport, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/user-data/exports/{id}", a.wrapFunc(server.GetApiUserDataExportsId, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/user-data/exports/{id}/download", a.wrapFunc(server.GetApiUserDataExportsIdDownload, nil)).Methods("GET")
 
 	// Survey Data API
-	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(a.clientAPIsHandler.getSurveyData, a.auth.client.User)).Methods("GET")
-	mainRouter.HandleFunc("/survey-data", a.wrapFunc(a.clientAPIsHandler.createSurveyData, a.auth.client.User)).Methods("POST")
-	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(a.clientAPIsHandler.updateSurveyData, a.auth.client.User)).Methods("PUT")
-	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(a.clientAPIsHandler.deleteSurveyData, a.auth.client.User)).Methods("DELETE")
+	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(server.GetApiSurveyDataId, a.auth.client.User)).Methods("GET")
+	mainRouter.HandleFunc("/survey-data", a.wrapFunc(server.PostApiSurveyData, a.auth.client.User)).Methods("POST")
+	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(server.PutApiSurveyDataId, a.auth.client.User)).Methods("PUT")
+	// mainRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(server.DeleteApiSurveyDataId, a.auth.client.User)).Methods("DELETE")
 
 	// Admin APIs
 	adminRouter := mainRouter.PathPrefix("/admin").Subrouter()
-	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(a.adminAPIsHandler.getConfig, a.auth.admin.Permissions)).Methods("GET")
-	adminRouter.HandleFunc("/configs", a.wrapFunc(a.adminAPIsHandler.getConfigs, a.auth.admin.Permissions)).Methods("GET")
-	adminRouter.HandleFunc("/configs", a.wrapFunc(a.adminAPIsHandler.createConfig, a.auth.admin.Permissions)).Methods("POST")
-	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(a.adminAPIsHandler.updateConfig, a.auth.admin.Permissions)).Methods("PUT")
-	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(a.adminAPIsHandler.deleteConfig, a.auth.admin.Permissions)).Methods("DELETE")
+	use(adminRouter, middlewares.admin...)
+	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(server.GetApiAdminConfigsId, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/configs", a.wrapFunc(server.GetApiAdminConfigs, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/configs", a.wrapFunc(server.PostApiAdminConfigs, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(server.PutApiAdminConfigsId, a.auth.admin.Permissions)).Methods("PUT")
+	adminRouter.HandleFunc("/configs/{id}", a.wrapFunc(server.DeleteApiAdminConfigsId, a.auth.admin.Permissions)).Methods("DELETE")
+
+	adminRouter.HandleFunc("/occupations/{code}", a.wrapFunc(server.GetApiAdminOccupationsCode, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/occupations", a.wrapFunc(server.GetApiAdminOccupations, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/occupations", a.wrapFunc(server.PostApiAdminOccupations, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/occupations/{code}", a.wrapFunc(server.PutApiAdminOccupationsCode, a.auth.admin.Permissions)).Methods("PUT")
+	adminRouter.HandleFunc("/occupations/{code}", a.wrapFunc(server.DeleteApiAdminOccupationsCode, a.auth.admin.Permissions)).Methods("DELETE")
+
+	adminRouter.HandleFunc("/reports/occupation-data", a.wrapFunc(server.GetApiAdminReportsOccupationData, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/reports/retention", a.wrapFunc(server.GetApiAdminReportsRetention, a.auth.admin.Permissions)).Methods("GET")
+
+	adminRouter.HandleFunc("/survey-data", a.wrapFunc(server.GetApiAdminSurveyData, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/survey-data/{id}", a.wrapFunc(server.GetApiAdminSurveyDataId, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/match-results", a.wrapFunc(server.GetApiAdminMatchResults, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/match-results/{account_id}", a.wrapFunc(server.GetApiAdminMatchResultsAccountId, a.auth.admin.Permissions)).Methods("GET")
+	adminRouter.HandleFunc("/users/{account_id}/matching", a.wrapFunc(server.PostApiAdminUsersAccountIdMatching, a.auth.admin.Permissions)).Methods("POST")
+	adminRouter.HandleFunc("/users/{account_id}/data", a.wrapFunc(server.DeleteApiAdminUsersAccountIdData, a.auth.admin.Permissions)).Methods("DELETE")
 
 	// BB APIs
 	// bbsRouter := mainRouter.PathPrefix("/bbs").Subrouter()
@@ -101,11 +218,11 @@ func (a Adapter) Start() {
 	// System APIs
 	// systemRouter := mainRouter.PathPrefix("/system").Subrouter()
 
-	a.logger.Fatalf("Error serving: %v", http.ListenAndServe(":"+a.port, router))
+	return chain(router, middlewares.base...)
 }
 
 func (a Adapter) serveDoc(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("access-control-allow-origin", "*")
+	w.Header().Set("access-control-allow-origin", "*")
 
 	if a.cachedYamlDoc != nil {
 		http.ServeContent(w, r, "", time.Now(), bytes.NewReader([]byte(a.cachedYamlDoc)))
@@ -148,48 +265,258 @@ func loadDocsYAML(baseServerURL string) ([]byte, error) {
 	return yamlDoc, nil
 }
 
-func (a Adapter) wrapFunc(handler handlerFunc, authorization tokenauth.Handler) http.HandlerFunc {
+// wrapFunc wraps a handler with the token authorization, rate limit, validation, tracing and metrics of its route
+func (a Adapter) wrapFunc(handler http.HandlerFunc, authorization tokenauth.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		logObj := a.logger.NewRequestLog(req)
+		start := time.Now()
+		route := routeTemplate(req)
+		req, span := startRequestSpan(req, route)
+		defer a.completeRequest(w, req, route, span, start)
 
-		logObj.RequestReceived()
+		logObj := requestLog(req.Context())
 
-		var response logs.HTTPResponse
+		var claims *tokenauth.Claims
 		if authorization != nil {
-			responseStatus, claims, err := authorization.Check(req)
+			_, authSpan := tracer.Start(req.Context(), "Authorize")
+			responseStatus, authClaims, err := authorization.Check(req)
+			authSpan.End()
 			if err != nil {
 				logObj.SendHTTPResponse(w, logObj.HTTPResponseErrorAction(logutils.ActionValidate, logutils.TypeRequest, nil, err, responseStatus, true))
 				return
 			}
 
-			if claims != nil {
-				logObj.SetContext("account_id", claims.Subject)
+			if authClaims != nil {
+				logObj.SetContext("account_id", authClaims.Subject)
 			}
-			response = handler(logObj, req, claims)
-		} else {
-			response = handler(logObj, req, nil)
+			claims = authClaims
+		}
+
+		ctx := context.WithValue(req.Context(), requestContextKey{}, requestContext{log: logObj, claims: claims})
+		req = req.WithContext(ctx)
+		if a.rateLimit(w, req) {
+			a.validator.middleware(handler)(w, req)
 		}
+	}
+}
+
+// routeTemplate returns the path template of the route handling a request
+func routeTemplate(req *http.Request) string {
+	if current := mux.CurrentRoute(req); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unknown"
+}
+
+// startRequestSpan starts the span of a request handled by its route, continuing the trace propagated by the client if any
+func startRequestSpan(req *http.Request, route string) (*http.Request, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+	ctx, span := tracer.Start(ctx, req.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.HTTPRoute(route)))
+	return req.WithContext(ctx), span
+}
+
+// completeRequest ends the span and records the metrics of a request handled by its route since the given start
+func (a Adapter) completeRequest(w http.ResponseWriter, req *http.Request, route string, span trace.Span, start time.Time) {
+	// nothing is written when the handler panicked, which is recovered with a 500 response
+	statusCode := responseStatusCode(w)
+	if statusCode == 0 {
+		statusCode = http.StatusInternalServerError
+	}
 
-		logObj.SendHTTPResponse(w, response)
-		logObj.RequestComplete()
+	span.SetAttributes(semconv.HTTPStatusCode(statusCode))
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+
+	if a.metrics != nil {
+		a.metrics.ObserveRequest(req.Method, route, statusCode, time.Since(start))
 	}
 }
 
+// requestContextKey is the context key of the request context
+type requestContextKey struct{}
+
+// requestContext holds the log and the token claims of a request
+type requestContext struct {
+	log    *logs.Log
+	claims *tokenauth.Claims
+}
+
+// requestLog returns the log of the request with the given context
+func requestLog(ctx context.Context) *logs.Log {
+	value, _ := ctx.Value(requestContextKey{}).(requestContext)
+	return value.log
+}
+
+// requestClaims returns the token claims of the request with the given context
+func requestClaims(ctx context.Context) *tokenauth.Claims {
+	value, _ := ctx.Value(requestContextKey{}).(requestContext)
+	return value.claims
+}
+
+// responseWriter records the status code of a response and adds the trace headers of the request log to it
+type responseWriter struct {
+	http.ResponseWriter
+	log        *logs.Log
+	statusCode int
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.statusCode != 0 {
+		return
+	}
+	w.statusCode = statusCode
+
+	// error responses sent through the log already have the trace headers
+	if len(w.Header().Values("trace-id")) == 0 {
+		traceHeaders := logs.HTTPResponse{Headers: map[string][]string{}}
+		w.log.SetResponseHeaders(&traceHeaders)
+		for key, values := range traceHeaders.Headers {
+			w.Header()[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the wrapped response writer
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// responseStatusCode returns the status code recorded by the responseWriter wrapped by a response writer, or 0 if none was sent yet
+//
+//	The writer is unwrapped until the responseWriter is found, as the middlewares such as gzip wrap it in their own writers.
+func responseStatusCode(w http.ResponseWriter) int {
+	for {
+		switch writer := w.(type) {
+		case *responseWriter:
+			return writer.statusCode
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return 0
+		}
+	}
+}
+
+// handleError sends the error response matching the status of an error returned by a handler
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	l := requestLog(r.Context())
+	l.SendHTTPResponse(w, withFieldErrors(l.HTTPResponseError(errors.Trace(err), err, errorStatusCode(err), false), err))
+}
+
+// handleRequestError sends the error response of a request which could not be parsed
+func handleRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	if tooLargeErr := bodyTooLargeError(err); tooLargeErr != nil {
+		handleError(w, r, tooLargeErr)
+		return
+	}
+	handleError(w, r, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeRequest, nil, err).SetStatus(utils.ErrorStatusInvalid))
+}
+
+// errorStatusCode returns the HTTP status code matching the status of an error returned by core
+func errorStatusCode(err error) int {
+	switch errors.Status(err) {
+	case utils.ErrorStatusInvalid:
+		return http.StatusBadRequest
+	case utils.ErrorStatusExists:
+		return http.StatusConflict
+	case utils.ErrorStatusConflict:
+		return http.StatusPreconditionFailed
+	case utils.ErrorStatusNotFound:
+		return http.StatusNotFound
+	case utils.ErrorStatusUnprocessable:
+		return http.StatusUnprocessableEntity
+	case utils.ErrorStatusRateLimited:
+		return http.StatusTooManyRequests
+	case utils.ErrorStatusInProgress:
+		return http.StatusConflict
+	case utils.ErrorStatusTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case utils.ErrorStatusUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// withFieldErrors adds the failed fields of a validation error to the JSON body of an error response
+func withFieldErrors(response logs.HTTPResponse, err error) logs.HTTPResponse {
+	validation, ok := errors.AsError(err).Internal().(*model.ValidationError)
+	if !ok {
+		return response
+	}
+
+	var body map[string]interface{}
+	if json.Unmarshal(response.Body, &body) != nil {
+		return response
+	}
+	body["errors"] = validation.Fields
+	data, jsonErr := json.Marshal(body)
+	if jsonErr != nil {
+		return response
+	}
+	response.Body = data
+	return response
+}
+
+// etag formats a revision as the value of an ETag header
+func etag(revision int64) string {
+	return strconv.Quote(strconv.FormatInt(revision, 10))
+}
+
+// ifMatchRevision parses the revision from an If-Match header, returning 0 if there is no header or it matches any revision
+func ifMatchRevision(header *string) (int64, error) {
+	if header == nil {
+		return 0, nil
+	}
+	ifMatch := strings.TrimSpace(*header)
+	if len(ifMatch) == 0 || ifMatch == "*" {
+		return 0, nil
+	}
+
+	value, err := strconv.Unquote(ifMatch)
+	if err != nil {
+		return 0, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeHeader, logutils.StringArgs("If-Match"), err).SetStatus(utils.ErrorStatusInvalid)
+	}
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, errors.ErrorData(logutils.StatusInvalid, logutils.TypeHeader, &logutils.FieldArgs{"If-Match": ifMatch}).SetStatus(utils.ErrorStatusInvalid)
+	}
+	return revision, nil
+}
+
 // NewWebAdapter creates new WebAdapter instance
-func NewWebAdapter(baseURL string, port string, serviceID string, app *core.Application, serviceRegManager *authservice.ServiceRegManager, logger *logs.Logger) Adapter {
+func NewWebAdapter(baseURL string, port string, serviceID string, validateResponses bool, serverConfig ServerConfig, middlewareConfig MiddlewareConfig, metrics Metrics, app *core.Application, serviceRegManager *authservice.ServiceRegManager, logger *logs.Logger) Adapter {
 	yamlDoc, err := loadDocsYAML(baseURL)
 	if err != nil {
 		logger.Fatalf("error parsing docs yaml - %s", err.Error())
 	}
 
+	validator, err := newOpenAPIValidator("./driver/web/docs/gen/def.yaml", "/"+serviceID, validateResponses)
+	if err != nil {
+		logger.Fatalf("error loading openapi spec - %s", err.Error())
+	}
+
 	auth, err := NewAuth(serviceRegManager)
 	if err != nil {
 		logger.Fatalf("error creating auth - %s", err.Error())
 	}
 
-	defaultAPIsHandler := NewDefaultAPIsHandler(app)
-	clientAPIsHandler := NewClientAPIsHandler(app)
+	defaultAPIsHandler := NewDefaultAPIsHandler(app, serviceRegManager)
+	clientAPIsHandler := NewClientAPIsHandler(app, baseURL)
 	adminAPIsHandler := NewAdminAPIsHandler(app)
-	return Adapter{baseURL: baseURL, port: port, serviceID: serviceID, cachedYamlDoc: yamlDoc, auth: auth, defaultAPIsHandler: defaultAPIsHandler,
+	adapter := Adapter{baseURL: baseURL, port: port, serviceID: serviceID, cachedYamlDoc: yamlDoc, auth: auth, validator: validator, middlewareConfig: middlewareConfig, metrics: metrics, defaultAPIsHandler: defaultAPIsHandler,
 		clientAPIsHandler: clientAPIsHandler, adminAPIsHandler: adminAPIsHandler, app: app, logger: logger}
+	adapter.server = newServer(port, serverConfig, adapter.routes())
+	adapter.server.RegisterOnShutdown(clientAPIsHandler.stopStreams)
+	return adapter
 }
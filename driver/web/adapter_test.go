@@ -0,0 +1,637 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core"
+	"application/core/model"
+	"application/driven/crosswalk"
+	"application/driven/memory"
+	"application/driven/metrics"
+	"application/driven/ratelimit"
+	Def "application/driver/web/docs/gen"
+	"application/utils"
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rokwire/core-auth-library-go/v3/authorization"
+	"github.com/rokwire/core-auth-library-go/v3/authservice"
+	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	testAccountID = "test-account"
+	// testPermissionsHeader sets the permissions of the admin making a test request
+	testPermissionsHeader = "X-Test-Permissions"
+)
+
+// testAuth accepts every request as a user of the test account
+type testAuth struct{}
+
+func (testAuth) Check(req *http.Request) (int, *tokenauth.Claims, error) {
+	claims := tokenauth.Claims{}
+	claims.Subject = testAccountID
+	return http.StatusOK, &claims, nil
+}
+
+func (testAuth) GetTokenAuth() *tokenauth.TokenAuth {
+	return nil
+}
+
+// testAdminAuth accepts every request as an admin of the test account, with the permissions of the test permissions header
+type testAdminAuth struct {
+	tokenAuth *tokenauth.TokenAuth
+}
+
+func (a testAdminAuth) Check(req *http.Request) (int, *tokenauth.Claims, error) {
+	claims := tokenauth.Claims{Permissions: req.Header.Get(testPermissionsHeader), Admin: true}
+	claims.Subject = testAccountID
+	return http.StatusOK, &claims, nil
+}
+
+func (a testAdminAuth) GetTokenAuth() *tokenauth.TokenAuth {
+	return a.tokenAuth
+}
+
+// testServiceRegLoader loads no service registrations
+type testServiceRegLoader struct{}
+
+func (testServiceRegLoader) LoadServices() ([]authservice.ServiceReg, error) { return nil, nil }
+func (testServiceRegLoader) GetSubscribedServices() []string                 { return nil }
+func (testServiceRegLoader) SubscribeService(serviceID string) bool          { return false }
+func (testServiceRegLoader) UnsubscribeService(serviceID string) bool        { return false }
+
+// newTestAdminAuth creates admin auth handlers authorizing the permissions with the admin permission policy
+func newTestAdminAuth(t *testing.T) tokenauth.Handlers {
+	authService := authservice.AuthService{ServiceID: "skills-to-jobs", ServiceHost: "http://localhost"}
+	serviceRegManager, err := authservice.NewTestServiceRegManager(&authService, testServiceRegLoader{}, false)
+	if err != nil {
+		t.Fatalf("error creating service registration manager: %v", err)
+	}
+	tokenAuth, err := tokenauth.NewTokenAuth(true, serviceRegManager, authorization.NewCasbinStringAuthorization("admin_permission_policy.csv"), nil)
+	if err != nil {
+		t.Fatalf("error creating admin token auth: %v", err)
+	}
+	return tokenauth.NewHandlers(testAdminAuth{tokenAuth: tokenAuth})
+}
+
+func newTestAdapter(t *testing.T) (Adapter, *memory.Adapter) {
+	logger := logs.NewLogger("test", nil)
+	storage := memory.NewStorageAdapter("", logger)
+	if err := storage.Start(); err != nil {
+		t.Fatalf("error starting storage: %v", err)
+	}
+	metricsAdapter := metrics.NewMetricsAdapter()
+	app := core.NewApplication("test", "", storage, crosswalk.NewCrosswalkAdapter(t.TempDir(), logger), ratelimit.NewRateLimitAdapter(), metricsAdapter, 24*time.Hour, logger)
+
+	validator, err := newOpenAPIValidator("docs/gen/def.yaml", "/skills-to-jobs", true)
+	if err != nil {
+		t.Fatalf("error loading openapi spec: %v", err)
+	}
+
+	client := tokenauth.NewHandlers(testAuth{})
+	client.User = tokenauth.NewUserHandler(testAuth{})
+	adapter := Adapter{serviceID: "skills-to-jobs", auth: &Auth{client: client, admin: newTestAdminAuth(t)}, validator: validator, metrics: metricsAdapter, app: app, logger: logger,
+		defaultAPIsHandler: DefaultAPIsHandler{app: app}, clientAPIsHandler: NewClientAPIsHandler(app, "http://localhost/skills-to-jobs"), adminAPIsHandler: NewAdminAPIsHandler(app)}
+	adapter.server = newServer("0", ServerConfig{ReadHeaderTimeout: time.Second}, adapter.routes())
+	adapter.server.RegisterOnShutdown(adapter.clientAPIsHandler.stopStreams)
+	return adapter, storage
+}
+
+func serve(adapter Adapter, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/skills-to-jobs"+path, strings.NewReader(body))
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	recorder := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(recorder, req)
+	return recorder
+}
+
+func serveAdmin(adapter Adapter, method string, path string, permissions string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/skills-to-jobs"+path, nil)
+	req.Header.Set(testPermissionsHeader, permissions)
+	recorder := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(recorder, req)
+	return recorder
+}
+
+func surveyBody(scores map[string]int) string {
+	data := model.SurveyData{Version: model.SurveyVersionBESSI3}
+	for _, skill := range model.SurveyInstruments[model.SurveyVersionBESSI3].Skills {
+		data.Scores = append(data.Scores, model.WorkstyleScore{Workstyle: skill, Score: 50})
+	}
+	for i, score := range data.Scores {
+		if value, ok := scores[score.Workstyle]; ok {
+			data.Scores[i].Score = value
+		}
+	}
+
+	body := map[string]interface{}{"version": data.Version, "scores": data.Scores}
+	encoded, _ := json.Marshal(body)
+	return string(encoded)
+}
+
+func TestAdapter_RequestValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantField  string
+	}{
+		{"valid survey", http.MethodPost, "/api/survey-data", surveyBody(nil), http.StatusOK, ""},
+		{"score out of range", http.MethodPost, "/api/survey-data", surveyBody(map[string]int{"adaptability": 150}), http.StatusUnprocessableEntity, "scores[1].score"},
+		{"missing skill", http.MethodPost, "/api/survey-data", `{"version": "v3.0", "scores": [{"workstyle": "adaptability", "score": 50}]}`, http.StatusUnprocessableEntity, "scores"},
+		{"unknown version", http.MethodPost, "/api/survey-data", `{"version": "v1.0", "scores": []}`, http.StatusUnprocessableEntity, "version"},
+		{"malformed body", http.MethodPost, "/api/survey-data", `{"version": `, http.StatusBadRequest, "body"},
+		{"missing query param", http.MethodGet, "/api/occupation-pathways?to=15-1252.00", "", http.StatusBadRequest, "from"},
+		{"invalid query param", http.MethodGet, "/api/occupation/15-1252.00/related?limit=many", "", http.StatusBadRequest, "limit"},
+		{"invalid path param", http.MethodGet, "/api/crosswalks/unknown/occupations?code=1", "", http.StatusBadRequest, "system"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, _ := newTestAdapter(t)
+			response := serve(adapter, tt.method, tt.path, tt.body)
+			if response.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", response.Code, tt.wantStatus, response.Body.String())
+			}
+			if len(tt.wantField) == 0 {
+				return
+			}
+
+			var body struct {
+				Errors []model.FieldError `json:"errors"`
+			}
+			if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil {
+				t.Fatalf("error parsing response body %s: %v", response.Body.String(), err)
+			}
+			for _, fieldErr := range body.Errors {
+				if fieldErr.Field == tt.wantField {
+					return
+				}
+			}
+			t.Errorf("errors = %v, want an error for %s", body.Errors, tt.wantField)
+		})
+	}
+}
+
+func TestAdapter_ResponseValidation(t *testing.T) {
+	adapter, storage := newTestAdapter(t)
+	err := storage.InsertOccupationData(model.OccupationData{Code: "15-1252.00", Name: "Software Developers", Description: "Develop software",
+		Workstyles: []model.Workstyle{{ID: "adaptability", Name: "Adaptability", Scale: "IM", Value: 4.5}}})
+	if err != nil {
+		t.Fatalf("error inserting occupation data: %v", err)
+	}
+
+	response := serve(adapter, http.MethodGet, "/api/occupation/15-1252.00", "")
+	if response.Code != http.StatusOK {
+		t.Errorf("existing occupation status = %d, want %d: %s", response.Code, http.StatusOK, response.Body.String())
+	}
+
+	response = serve(adapter, http.MethodGet, "/api/occupation/00-0000.00", "")
+	if response.Code != http.StatusNotFound {
+		t.Errorf("missing occupation status = %d, want %d: %s", response.Code, http.StatusNotFound, response.Body.String())
+	}
+
+	response = serve(adapter, http.MethodGet, "/version", "")
+	if response.Code != http.StatusOK || response.Body.String() != "test" {
+		t.Errorf("version = %d %q, want %d %q", response.Code, response.Body.String(), http.StatusOK, "test")
+	}
+}
+
+func TestAdapter_RateLimit(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	limit := model.DefaultRateLimits[0]
+
+	for i := 0; i < limit.Requests; i++ {
+		response := serve(adapter, http.MethodPost, "/api/survey-data", surveyBody(nil))
+		if response.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d: %s", i, response.Code, http.StatusOK, response.Body.String())
+		}
+		if remaining := response.Header().Get("RateLimit-Remaining"); remaining != strconv.Itoa(limit.Requests-i-1) {
+			t.Errorf("request %d RateLimit-Remaining = %s, want %d", i, remaining, limit.Requests-i-1)
+		}
+	}
+
+	response := serve(adapter, http.MethodPost, "/api/survey-data", surveyBody(nil))
+	if response.Code != http.StatusTooManyRequests {
+		t.Fatalf("exceeded status = %d, want %d: %s", response.Code, http.StatusTooManyRequests, response.Body.String())
+	}
+	if len(response.Header().Get("Retry-After")) == 0 || response.Header().Get("RateLimit-Limit") != strconv.Itoa(limit.Requests) {
+		t.Errorf("exceeded headers = %v, want Retry-After and RateLimit-Limit", response.Header())
+	}
+	if !strings.Contains(response.Body.String(), utils.ErrorStatusRateLimited) {
+		t.Errorf("exceeded body = %s, want status %s", response.Body.String(), utils.ErrorStatusRateLimited)
+	}
+
+	response = serve(adapter, http.MethodGet, "/api/crosswalks", "")
+	if response.Code != http.StatusOK || len(response.Header().Get("RateLimit-Limit")) > 0 {
+		t.Errorf("unlimited route = %d %v, want %d without RateLimit headers", response.Code, response.Header(), http.StatusOK)
+	}
+}
+
+func TestAdapter_IdempotentSurveyData(t *testing.T) {
+	adapter, storage := newTestAdapter(t)
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/skills-to-jobs/api/survey-data", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "submission-1")
+		recorder := httptest.NewRecorder()
+		adapter.routes().ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	ids := make([]string, 2)
+	for i := range ids {
+		response := post(surveyBody(nil))
+		if response.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d: %s", i, response.Code, http.StatusOK, response.Body.String())
+		}
+		var surveyData model.SurveyData
+		if err := json.Unmarshal(response.Body.Bytes(), &surveyData); err != nil {
+			t.Fatalf("error parsing response body %s: %v", response.Body.String(), err)
+		}
+		ids[i] = surveyData.ID
+	}
+	if ids[0] != ids[1] {
+		t.Errorf("retried survey data ID = %s, want %s", ids[1], ids[0])
+	}
+	surveys, err := storage.FindSurveyDatas("", 10)
+	if err != nil || len(surveys) != 1 {
+		t.Errorf("stored survey data = %d, %v, want 1", len(surveys), err)
+	}
+
+	response := post(surveyBody(map[string]int{"adaptability": 80}))
+	if response.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reused key status = %d, want %d: %s", response.Code, http.StatusUnprocessableEntity, response.Body.String())
+	}
+}
+
+func TestAdapter_Stop(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	routes := adapter.server.Handler
+	adapter.server.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+		routes.ServeHTTP(w, req)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- adapter.serve(listener) }()
+
+	responses := make(chan int, 1)
+	go func() {
+		response, err := http.Get("http://" + listener.Addr().String() + "/skills-to-jobs/version")
+		if err != nil {
+			responses <- 0
+			return
+		}
+		response.Body.Close()
+		responses <- response.StatusCode
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- adapter.Stop(context.Background()) }()
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop() = %v with a request in progress, want to wait for it", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if status := <-responses; status != http.StatusOK {
+		t.Errorf("in progress request status = %d, want %d", status, http.StatusOK)
+	}
+	if err := <-stopped; err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("serve() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := adapter.Stop(ctx); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
+
+func TestAdapter_Metrics(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	serve(adapter, http.MethodGet, "/api/crosswalks", "")
+	serve(adapter, http.MethodGet, "/api/crosswalks/unknown/occupations?code=1", "")
+
+	response := serve(adapter, http.MethodGet, "/metrics", "")
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", response.Code, http.StatusOK, response.Body.String())
+	}
+	for _, want := range []string{
+		`skills_to_jobs_http_requests_total{method="GET",route="/skills-to-jobs/api/crosswalks",status_code="200"} 1`,
+		`skills_to_jobs_http_requests_total{method="GET",route="/skills-to-jobs/api/crosswalks/{system}/occupations",status_code="400"} 1`,
+		`skills_to_jobs_matching_queue_depth 0`,
+	} {
+		if !strings.Contains(response.Body.String(), want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+}
+
+func TestAdapter_MetricsGzip(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	req := httptest.NewRequest(http.MethodGet, "/skills-to-jobs/api/crosswalks", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("status = %d, headers = %v, want a gzip response", recorder.Code, recorder.Header())
+	}
+
+	response := serve(adapter, http.MethodGet, "/metrics", "")
+	want := `skills_to_jobs_http_requests_total{method="GET",route="/skills-to-jobs/api/crosswalks",status_code="200"} 1`
+	if !strings.Contains(response.Body.String(), want) {
+		t.Errorf("metrics do not contain %s", want)
+	}
+}
+
+func TestAdapter_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	adapter, _ := newTestAdapter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/skills-to-jobs/api/survey-data", strings.NewReader(surveyBody(nil)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("Accept-Encoding", "gzip")
+	response := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(response, req)
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", response.Code, http.StatusOK, response.Body.String())
+	}
+	if err := adapter.app.Stop(context.Background()); err != nil {
+		t.Fatalf("error stopping the application: %v", err)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	request, ok := spans["POST /skills-to-jobs/api/survey-data"]
+	if !ok {
+		t.Fatalf("spans = %v, want the request span", spans)
+	}
+	if request.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || request.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("request span trace = %s, parent = %s, want the propagated trace", request.SpanContext().TraceID(), request.Parent().SpanID())
+	}
+	// the response is compressed, which must not hide its status from the span
+	if request.Status().Code == codes.Error {
+		t.Errorf("request span status = %v, want no error for a gzip response", request.Status())
+	}
+	for _, attribute := range request.Attributes() {
+		if attribute.Key == "http.status_code" && attribute.Value.AsInt64() != http.StatusOK {
+			t.Errorf("request span status code = %d, want %d", attribute.Value.AsInt64(), http.StatusOK)
+		}
+	}
+	if authorize := spans["Authorize"]; authorize == nil || authorize.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("authorize span = %v, want a child of the request span", authorize)
+	}
+
+	matching, ok := spans["MatchOccupations"]
+	if !ok {
+		t.Fatalf("spans = %v, want the matching span", spans)
+	}
+	if matching.Parent().IsValid() || len(matching.Links()) != 1 || matching.Links()[0].SpanContext.SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("matching span parent = %v, links = %v, want a root span linked to the request span", matching.Parent(), matching.Links())
+	}
+	if algorithm := spans["RunMatchingAlgorithm"]; algorithm == nil || algorithm.Parent().SpanID() != matching.SpanContext().SpanID() {
+		t.Errorf("algorithm span = %v, want a child of the matching span", algorithm)
+	}
+}
+
+func TestAdapter_MatchingEvents(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	adapter.app.Start()
+	// the stream outlasts the write timeout
+	adapter.server.WriteTimeout = 50 * time.Millisecond
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- adapter.serve(listener) }()
+
+	response, err := http.Get("http://" + listener.Addr().String() + "/skills-to-jobs/api/matching-events")
+	if err != nil {
+		t.Fatalf("error subscribing: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, content type = %s, want an event stream", response.StatusCode, response.Header.Get("Content-Type"))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if created := serve(adapter, http.MethodPost, "/api/survey-data", surveyBody(nil)); created.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", created.Code, http.StatusOK, created.Body.String())
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	for _, want := range []string{model.MatchingEventStarted, model.MatchingEventCompleted} {
+		var event model.MatchingEvent
+		select {
+		case line := <-lines:
+			if line != "event: "+want {
+				t.Fatalf("event line = %q, want %s", line, want)
+			}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(<-lines, "data: ")), &event); err != nil {
+				t.Fatalf("error parsing event data: %v", err)
+			}
+			<-lines
+		case <-time.After(time.Second):
+			t.Fatalf("%s event not received", want)
+		}
+		if event.Type != want || event.AccountID != testAccountID {
+			t.Errorf("event = %+v, want %s event of %s", event, want, testAccountID)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := adapter.Stop(ctx); err != nil {
+		t.Errorf("Stop() with an event stream error = %v", err)
+	}
+	for range lines {
+	}
+	if err := <-served; err != nil {
+		t.Errorf("serve() error = %v", err)
+	}
+	if err := adapter.app.Stop(context.Background()); err != nil {
+		t.Errorf("error stopping the application: %v", err)
+	}
+}
+
+func TestAdapter_AdminUserData(t *testing.T) {
+	adapter, storage := newTestAdapter(t)
+	now := time.Now().UTC()
+	for _, surveyData := range []model.SurveyData{
+		{ID: "first", AccountID: "account", Version: model.SurveyVersionBESSI3, Scores: []model.WorkstyleScore{}, DateCreated: now.Add(-time.Hour)},
+		{ID: "second", AccountID: "account", Version: model.SurveyVersionBESSI3, Scores: []model.WorkstyleScore{}, DateCreated: now},
+		{ID: "other", AccountID: "other", Version: model.SurveyVersionBESSI3, Scores: []model.WorkstyleScore{}, DateCreated: now},
+	} {
+		if err := storage.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("error creating survey data: %v", err)
+		}
+	}
+
+	recorder := serveAdmin(adapter, http.MethodGet, "/api/admin/survey-data?account_id=account&limit=1", "get_user_data_skills-to-jobs")
+	var surveyDatas []model.SurveyData
+	if err := json.Unmarshal(recorder.Body.Bytes(), &surveyDatas); recorder.Code != http.StatusOK || err != nil {
+		t.Fatalf("GET /api/admin/survey-data = %d %s, want 200", recorder.Code, recorder.Body.String())
+	}
+	if len(surveyDatas) != 1 || surveyDatas[0].ID != "second" {
+		t.Errorf("GET /api/admin/survey-data = %v, want the latest survey data of the account", surveyDatas)
+	}
+
+	if recorder = serveAdmin(adapter, http.MethodGet, "/api/admin/survey-data?limit=1000", "get_user_data_skills-to-jobs"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("GET /api/admin/survey-data over the max limit = %d, want 400", recorder.Code)
+	}
+	if recorder = serveAdmin(adapter, http.MethodGet, "/api/admin/match-results/account", "get_user_data_skills-to-jobs"); recorder.Code != http.StatusNotFound {
+		t.Errorf("GET /api/admin/match-results/{account_id} without a match result = %d, want 404", recorder.Code)
+	}
+
+	if recorder = serveAdmin(adapter, http.MethodDelete, "/api/admin/users/account/data", "get_user_data_skills-to-jobs"); recorder.Code != http.StatusForbidden {
+		t.Errorf("DELETE /api/admin/users/{account_id}/data without the delete permission = %d, want 403", recorder.Code)
+	}
+	recorder = serveAdmin(adapter, http.MethodDelete, "/api/admin/users/account/data", "delete_user_data_skills-to-jobs")
+	var deletion model.UserDataDeletion
+	if err := json.Unmarshal(recorder.Body.Bytes(), &deletion); recorder.Code != http.StatusOK || err != nil || deletion.SurveyDatas != 2 {
+		t.Errorf("DELETE /api/admin/users/{account_id}/data = %d %s, want 2 deleted survey data", recorder.Code, recorder.Body.String())
+	}
+	if recorder = serveAdmin(adapter, http.MethodGet, "/api/admin/survey-data/other", "all_user_data_skills-to-jobs"); recorder.Code != http.StatusOK {
+		t.Errorf("GET /api/admin/survey-data/{id} of another account = %d, want 200", recorder.Code)
+	}
+}
+
+func TestAdapter_UserDataExport(t *testing.T) {
+	adapter, storage := newTestAdapter(t)
+	if recorder := serve(adapter, http.MethodPost, "/api/survey-data", surveyBody(nil)); recorder.Code != http.StatusOK {
+		t.Fatalf("POST /api/survey-data = %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder := serve(adapter, http.MethodGet, "/api/user-data/export", "")
+	var archive model.UserDataArchive
+	if err := json.Unmarshal(recorder.Body.Bytes(), &archive); recorder.Code != http.StatusOK || err != nil || len(archive.SurveyDatas) != 1 {
+		t.Fatalf("GET /api/user-data/export = %d %s, want an archive with the survey data", recorder.Code, recorder.Body.String())
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); disposition != `attachment; filename="user-data.json"` {
+		t.Errorf("GET /api/user-data/export Content-Disposition = %s", disposition)
+	}
+	recorder = serve(adapter, http.MethodGet, "/api/user-data/export?format=csv", "")
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/zip" {
+		t.Errorf("GET /api/user-data/export?format=csv = %d %s, want a ZIP archive", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if recorder = serve(adapter, http.MethodGet, "/api/user-data/export?format=xml", ""); recorder.Code != http.StatusBadRequest {
+		t.Errorf("GET /api/user-data/export?format=xml = %d, want 400", recorder.Code)
+	}
+
+	// a large history is exported in the background
+	for i := 0; i < model.UserDataExportSyncLimit; i++ {
+		surveyData := model.SurveyData{ID: strconv.Itoa(i), AccountID: testAccountID, Version: model.SurveyVersionBESSI3, Scores: []model.WorkstyleScore{}, DateCreated: time.Now().UTC()}
+		if err := storage.CreateSurveyData(surveyData); err != nil {
+			t.Fatalf("error creating survey data: %v", err)
+		}
+	}
+	recorder = serve(adapter, http.MethodGet, "/api/user-data/export", "")
+	location := recorder.Header().Get("Location")
+	if recorder.Code != http.StatusAccepted || !strings.HasPrefix(location, "http://localhost/skills-to-jobs/api/user-data/exports/") {
+		t.Fatalf("GET /api/user-data/export of a large history = %d %s, want 202 with the location of the export", recorder.Code, location)
+	}
+	statusPath := strings.TrimPrefix(location, "http://localhost/skills-to-jobs")
+
+	var userDataExport Def.UserDataExport
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		recorder = serve(adapter, http.MethodGet, statusPath, "")
+		if err := json.Unmarshal(recorder.Body.Bytes(), &userDataExport); recorder.Code != http.StatusOK || err != nil {
+			t.Fatalf("GET /api/user-data/exports/{id} = %d %s, want 200", recorder.Code, recorder.Body.String())
+		}
+		if *userDataExport.Status != Def.Pending || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if *userDataExport.Status != Def.Ready || userDataExport.DownloadUrl == nil {
+		t.Fatalf("GET /api/user-data/exports/{id} = %+v, want a ready export with a download link", userDataExport)
+	}
+
+	downloadPath := strings.TrimPrefix(*userDataExport.DownloadUrl, "http://localhost/skills-to-jobs")
+	req := httptest.NewRequest(http.MethodGet, "/skills-to-jobs"+downloadPath, nil)
+	downloadRecorder := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(downloadRecorder, req)
+	if err := json.Unmarshal(downloadRecorder.Body.Bytes(), &archive); downloadRecorder.Code != http.StatusOK || err != nil || len(archive.SurveyDatas) != model.UserDataExportSyncLimit+1 {
+		t.Errorf("GET /api/user-data/exports/{id}/download = %d, want the archive of the large history", downloadRecorder.Code)
+	}
+	wrongPath := strings.Split(downloadPath, "?")[0] + "?token=wrong"
+	if recorder = serve(adapter, http.MethodGet, wrongPath, ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("GET /api/user-data/exports/{id}/download with a wrong token = %d, want 404", recorder.Code)
+	}
+}
+
+func TestAdapter_OccupationPathwaysLimit(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	for _, limit := range []string{"0", "11"} {
+		if recorder := serve(adapter, http.MethodGet, "/api/occupation-pathways?from=11-1011.00&to=11-1021.00&limit="+limit, ""); recorder.Code != http.StatusBadRequest {
+			t.Errorf("GET /api/occupation-pathways?limit=%s = %d, want 400", limit, recorder.Code)
+		}
+	}
+
+	// the handler rejects the limit too when the requests are not validated against the API docs
+	limit := model.MaxOccupationPathways + 1
+	request := Def.GetApiOccupationPathwaysRequestObject{Params: Def.GetApiOccupationPathwaysParams{From: "11-1011.00", To: "11-1021.00", Limit: &limit}}
+	if _, err := adapter.clientAPIsHandler.GetApiOccupationPathways(context.Background(), request); errors.Status(err) != utils.ErrorStatusInvalid {
+		t.Errorf("ClientAPIsHandler.GetApiOccupationPathways() over the max limit error = %v, want invalid", err)
+	}
+}
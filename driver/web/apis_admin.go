@@ -17,13 +17,14 @@ package web
 import (
 	"application/core"
 	"application/core/model"
-	"encoding/json"
-	"net/http"
+	Def "application/driver/web/docs/gen"
+	"application/utils"
+	"context"
+	"time"
 
-	"github.com/gorilla/mux"
 	"github.com/rokwire/core-auth-library-go/v3/authutils"
 	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
-	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
 )
 
@@ -32,128 +33,231 @@ type AdminAPIsHandler struct {
 	app *core.Application
 }
 
-func (h AdminAPIsHandler) getConfig(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// GetApiAdminConfigsId returns the config with the given ID
+func (h AdminAPIsHandler) GetApiAdminConfigsId(ctx context.Context, request Def.GetApiAdminConfigsIdRequestObject) (Def.GetApiAdminConfigsIdResponseObject, error) {
+	config, err := h.app.Admin.GetConfig(request.Id, requestClaims(ctx))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeConfig, nil, err)
 	}
 
-	config, err := h.app.Admin.GetConfig(id, claims)
+	return Def.GetApiAdminConfigsId200JSONResponse{Body: *config, Headers: Def.GetApiAdminConfigsId200ResponseHeaders{ETag: etag(config.Revision)}}, nil
+}
+
+// GetApiAdminConfigs returns the configs matching the search parameters
+func (h AdminAPIsHandler) GetApiAdminConfigs(ctx context.Context, request Def.GetApiAdminConfigsRequestObject) (Def.GetApiAdminConfigsResponseObject, error) {
+	var configType *string
+	if request.Params.Type != nil && len(*request.Params.Type) > 0 {
+		configType = request.Params.Type
+	}
+
+	configs, err := h.app.Admin.GetConfigs(configType, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeConfig, nil, err)
 	}
 
-	data, err := json.Marshal(config)
+	return Def.GetApiAdminConfigs200JSONResponse(configs), nil
+}
+
+// PostApiAdminConfigs creates a config
+func (h AdminAPIsHandler) PostApiAdminConfigs(ctx context.Context, request Def.PostApiAdminConfigsRequestObject) (Def.PostApiAdminConfigsResponseObject, error) {
+	config := requestConfig(*request.Body, requestClaims(ctx))
+
+	newConfig, err := h.app.Admin.CreateConfig(config, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, model.TypeConfig, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err)
 	}
 
-	return l.HTTPResponseSuccessJSON(data)
+	return Def.PostApiAdminConfigs200JSONResponse(*newConfig), nil
 }
 
-func (h AdminAPIsHandler) getConfigs(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	var configType *string
-	typeParam := r.URL.Query().Get("type")
-	if len(typeParam) > 0 {
-		configType = &typeParam
+// PutApiAdminConfigsId updates the config with the given ID
+func (h AdminAPIsHandler) PutApiAdminConfigsId(ctx context.Context, request Def.PutApiAdminConfigsIdRequestObject) (Def.PutApiAdminConfigsIdResponseObject, error) {
+	revision, err := ifMatchRevision(request.Params.IfMatch)
+	if err != nil {
+		return nil, err
 	}
 
-	configs, err := h.app.Admin.GetConfigs(configType, claims)
+	config := requestConfig(*request.Body, requestClaims(ctx))
+	config.ID = request.Id
+	config.Revision = revision
+
+	err = h.app.Admin.UpdateConfig(config, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeConfig, nil, err)
 	}
 
-	data, err := json.Marshal(configs)
+	return Def.PutApiAdminConfigsId200TextResponse("Success"), nil
+}
+
+// DeleteApiAdminConfigsId deletes the config with the given ID
+func (h AdminAPIsHandler) DeleteApiAdminConfigsId(ctx context.Context, request Def.DeleteApiAdminConfigsIdRequestObject) (Def.DeleteApiAdminConfigsIdResponseObject, error) {
+	err := h.app.Admin.DeleteConfig(request.Id, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, model.TypeConfig, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeConfig, nil, err)
 	}
 
-	return l.HTTPResponseSuccessJSON(data)
+	return Def.DeleteApiAdminConfigsId200TextResponse("Success"), nil
 }
 
-type adminUpdateConfigsRequest struct {
-	AllApps *bool       `json:"all_apps,omitempty"`
-	AllOrgs *bool       `json:"all_orgs,omitempty"`
-	Data    interface{} `json:"data"`
-	System  bool        `json:"system"`
-	Type    string      `json:"type"`
+// GetApiAdminOccupationsCode returns the occupation data with the given code
+func (h AdminAPIsHandler) GetApiAdminOccupationsCode(ctx context.Context, request Def.GetApiAdminOccupationsCodeRequestObject) (Def.GetApiAdminOccupationsCodeResponseObject, error) {
+	occupationData, err := h.app.Admin.GetOccupationData(request.Code)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err)
+	}
+
+	return Def.GetApiAdminOccupationsCode200JSONResponse(*occupationData), nil
 }
 
-func (h AdminAPIsHandler) createConfig(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	var requestData adminUpdateConfigsRequest
-	err := json.NewDecoder(r.Body).Decode(&requestData)
+// GetApiAdminOccupations returns all occupation data
+func (h AdminAPIsHandler) GetApiAdminOccupations(ctx context.Context, request Def.GetApiAdminOccupationsRequestObject) (Def.GetApiAdminOccupationsResponseObject, error) {
+	occupationDatas, err := h.app.Admin.GetOccupationDatas()
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err)
 	}
 
-	appID := claims.AppID
-	if requestData.AllApps != nil && *requestData.AllApps {
-		appID = authutils.AllApps
+	return Def.GetApiAdminOccupations200JSONResponse(occupationDatas), nil
+}
+
+// PostApiAdminOccupations creates an occupation data
+func (h AdminAPIsHandler) PostApiAdminOccupations(ctx context.Context, request Def.PostApiAdminOccupationsRequestObject) (Def.PostApiAdminOccupationsResponseObject, error) {
+	occupationData, err := h.app.Admin.CreateOccupationData(*request.Body)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeOccupationData, nil, err)
 	}
-	orgID := claims.OrgID
-	if requestData.AllOrgs != nil && *requestData.AllOrgs {
-		orgID = authutils.AllOrgs
+
+	return Def.PostApiAdminOccupations200JSONResponse(*occupationData), nil
+}
+
+// PutApiAdminOccupationsCode updates the occupation data with the given code
+func (h AdminAPIsHandler) PutApiAdminOccupationsCode(ctx context.Context, request Def.PutApiAdminOccupationsCodeRequestObject) (Def.PutApiAdminOccupationsCodeResponseObject, error) {
+	requestData := *request.Body
+	requestData.Code = request.Code
+	err := h.app.Admin.UpdateOccupationData(requestData)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeOccupationData, nil, err)
 	}
-	config := model.Config{Type: requestData.Type, AppID: appID, OrgID: orgID, System: requestData.System, Data: requestData.Data}
 
-	newConfig, err := h.app.Admin.CreateConfig(config, claims)
+	return Def.PutApiAdminOccupationsCode200TextResponse("Success"), nil
+}
+
+// DeleteApiAdminOccupationsCode deletes the occupation data with the given code
+func (h AdminAPIsHandler) DeleteApiAdminOccupationsCode(ctx context.Context, request Def.DeleteApiAdminOccupationsCodeRequestObject) (Def.DeleteApiAdminOccupationsCodeResponseObject, error) {
+	err := h.app.Admin.DeleteOccupationData(request.Code)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeOccupationData, nil, err)
 	}
 
-	data, err := json.Marshal(newConfig)
+	return Def.DeleteApiAdminOccupationsCode200TextResponse("Success"), nil
+}
+
+// GetApiAdminReportsOccupationData returns the report of the loaded occupation data
+func (h AdminAPIsHandler) GetApiAdminReportsOccupationData(ctx context.Context, request Def.GetApiAdminReportsOccupationDataRequestObject) (Def.GetApiAdminReportsOccupationDataResponseObject, error) {
+	report, err := h.app.Admin.GetOccupationDataReport()
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, model.TypeConfig, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationDataReport, nil, err)
 	}
 
-	return l.HTTPResponseSuccessJSON(data)
+	return Def.GetApiAdminReportsOccupationData200JSONResponse(*report), nil
 }
 
-func (h AdminAPIsHandler) updateConfig(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// GetApiAdminReportsRetention returns the report of the data retention policies
+func (h AdminAPIsHandler) GetApiAdminReportsRetention(ctx context.Context, request Def.GetApiAdminReportsRetentionRequestObject) (Def.GetApiAdminReportsRetentionResponseObject, error) {
+	report, err := h.app.Admin.GetRetentionReport()
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeRetentionReport, nil, err)
 	}
 
-	var requestData adminUpdateConfigsRequest
-	err := json.NewDecoder(r.Body).Decode(&requestData)
+	return Def.GetApiAdminReportsRetention200JSONResponse(*report), nil
+}
+
+// GetApiAdminSurveyData returns the survey data matching the search parameters
+func (h AdminAPIsHandler) GetApiAdminSurveyData(ctx context.Context, request Def.GetApiAdminSurveyDataRequestObject) (Def.GetApiAdminSurveyDataResponseObject, error) {
+	params := request.Params
+	search := userDataSearch(params.AccountId, params.Version, params.From, params.To, params.Limit, params.Offset)
+	surveyDatas, err := h.app.Admin.SearchSurveyDatas(search, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeSurveyData, nil, err)
 	}
 
-	appID := claims.AppID
-	if requestData.AllApps != nil && *requestData.AllApps {
-		appID = authutils.AllApps
+	return Def.GetApiAdminSurveyData200JSONResponse(surveyDatas), nil
+}
+
+// GetApiAdminSurveyDataId returns the survey data with the given ID
+func (h AdminAPIsHandler) GetApiAdminSurveyDataId(ctx context.Context, request Def.GetApiAdminSurveyDataIdRequestObject) (Def.GetApiAdminSurveyDataIdResponseObject, error) {
+	surveyData, err := h.app.Admin.GetSurveyData(request.Id, requestClaims(ctx))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeSurveyData, nil, err)
 	}
-	orgID := claims.OrgID
-	if requestData.AllOrgs != nil && *requestData.AllOrgs {
-		orgID = authutils.AllOrgs
+
+	return Def.GetApiAdminSurveyDataId200JSONResponse(*surveyData), nil
+}
+
+// GetApiAdminMatchResults returns the match results matching the search parameters
+func (h AdminAPIsHandler) GetApiAdminMatchResults(ctx context.Context, request Def.GetApiAdminMatchResultsRequestObject) (Def.GetApiAdminMatchResultsResponseObject, error) {
+	params := request.Params
+	search := userDataSearch(params.AccountId, params.Version, params.From, params.To, params.Limit, params.Offset)
+	userMatchingResults, err := h.app.Admin.SearchUserMatchingResults(search, requestClaims(ctx))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeUserMatchingResult, nil, err)
 	}
-	config := model.Config{ID: id, Type: requestData.Type, AppID: appID, OrgID: orgID, System: requestData.System, Data: requestData.Data}
 
-	err = h.app.Admin.UpdateConfig(config, claims)
+	return Def.GetApiAdminMatchResults200JSONResponse(userMatchingResults), nil
+}
+
+// GetApiAdminMatchResultsAccountId returns the match result of the user with the given account ID
+func (h AdminAPIsHandler) GetApiAdminMatchResultsAccountId(ctx context.Context, request Def.GetApiAdminMatchResultsAccountIdRequestObject) (Def.GetApiAdminMatchResultsAccountIdResponseObject, error) {
+	userMatchingResult, err := h.app.Admin.GetUserMatchingResult(request.AccountId, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUpdate, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeUserMatchingResult, nil, err)
 	}
 
-	return l.HTTPResponseSuccess()
+	return Def.GetApiAdminMatchResultsAccountId200JSONResponse(*userMatchingResult), nil
 }
 
-func (h AdminAPIsHandler) deleteConfig(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// PostApiAdminUsersAccountIdMatching starts matching the latest survey data of the user with the given account ID again
+func (h AdminAPIsHandler) PostApiAdminUsersAccountIdMatching(ctx context.Context, request Def.PostApiAdminUsersAccountIdMatchingRequestObject) (Def.PostApiAdminUsersAccountIdMatchingResponseObject, error) {
+	surveyData, err := h.app.Admin.MatchUserOccupations(request.AccountId, requestClaims(ctx))
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserMatchingResult, nil, err)
 	}
 
-	err := h.app.Admin.DeleteConfig(id, claims)
+	return Def.PostApiAdminUsersAccountIdMatching202JSONResponse(*surveyData), nil
+}
+
+// DeleteApiAdminUsersAccountIdData deletes the data of the user with the given account ID
+func (h AdminAPIsHandler) DeleteApiAdminUsersAccountIdData(ctx context.Context, request Def.DeleteApiAdminUsersAccountIdDataRequestObject) (Def.DeleteApiAdminUsersAccountIdDataResponseObject, error) {
+	deletion, err := h.app.Admin.DeleteUserData(request.AccountId, requestClaims(ctx))
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeConfig, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserDataDeletion, nil, err)
 	}
 
-	return l.HTTPResponseSuccess()
+	return Def.DeleteApiAdminUsersAccountIdData200JSONResponse(*deletion), nil
+}
+
+// userDataSearch builds the user data search described by the search parameters of a request
+func userDataSearch(accountID *string, version *string, from *time.Time, to *time.Time, limit *int, offset *int) model.UserDataSearch {
+	search := model.UserDataSearch{From: from, To: to, Limit: utils.GetInt(limit), Offset: utils.GetInt(offset)}
+	if accountID != nil && len(*accountID) > 0 {
+		search.AccountID = accountID
+	}
+	if version != nil && len(*version) > 0 {
+		search.Version = version
+	}
+	return search
+}
+
+// requestConfig builds the config described by a create or update request
+func requestConfig(requestData Def.AdminReqUpdateConfigs, claims *tokenauth.Claims) model.Config {
+	appID := claims.AppID
+	if requestData.AllApps != nil && *requestData.AllApps {
+		appID = authutils.AllApps
+	}
+	orgID := claims.OrgID
+	if requestData.AllOrgs != nil && *requestData.AllOrgs {
+		orgID = authutils.AllOrgs
+	}
+	return model.Config{Type: requestData.Type, AppID: appID, OrgID: orgID, System: requestData.System, Data: requestData.Data}
 }
 
 // NewAdminAPIsHandler creates new rest Handler instance
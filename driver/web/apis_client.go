@@ -17,153 +17,405 @@ package web
 import (
 	"application/core"
 	"application/core/model"
+	Def "application/driver/web/docs/gen"
+	"application/utils"
+	"context"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/gorilla/mux"
-	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
-	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/logging-library-go/v2/errors"
 	"github.com/rokwire/logging-library-go/v2/logutils"
 )
 
+// matchingEventsKeepAlive is the interval of the comments sent on idle event streams, so that proxies do not close them
+const matchingEventsKeepAlive time.Duration = 15 * time.Second
+
 // ClientAPIsHandler handles the client rest APIs implementation
 type ClientAPIsHandler struct {
 	app *core.Application
+
+	// baseURL is the URL the service is hosted at, to which the links returned to the clients are relative
+	baseURL string
+
+	// streams is done once the server shuts down, ending the event streams
+	streams     context.Context
+	stopStreams context.CancelFunc
 }
 
-func (h ClientAPIsHandler) getOccupationData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	code := params["code"]
-	if len(code) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("code"), nil, http.StatusBadRequest, false)
+// GetApiOccupationCode returns the occupation data with the given code
+func (h ClientAPIsHandler) GetApiOccupationCode(ctx context.Context, request Def.GetApiOccupationCodeRequestObject) (Def.GetApiOccupationCodeResponseObject, error) {
+	occupationData, err := h.app.Client.WithContext(ctx).GetOccupationData(request.Code)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err)
 	}
 
-	occupationData, err := h.app.Client.GetOccupationData(code)
+	return Def.GetApiOccupationCode200JSONResponse(*occupationData), nil
+}
+
+// GetApiOccupation returns all occupation data
+func (h ClientAPIsHandler) GetApiOccupation(ctx context.Context, request Def.GetApiOccupationRequestObject) (Def.GetApiOccupationResponseObject, error) {
+	occupationData, err := h.app.Client.WithContext(ctx).GetAllOccupationDatas()
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err)
+	}
+
+	return Def.GetApiOccupation200JSONResponse(occupationData), nil
+}
+
+// GetApiOccupationCodeRelated returns the occupations related to the occupation with the given code
+func (h ClientAPIsHandler) GetApiOccupationCodeRelated(ctx context.Context, request Def.GetApiOccupationCodeRelatedRequestObject) (Def.GetApiOccupationCodeRelatedResponseObject, error) {
+	limit := 0
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+		if limit < 0 {
+			return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("limit")).SetStatus(utils.ErrorStatusInvalid)
+		}
 	}
 
-	response, err := json.Marshal(occupationData)
+	neighbors, err := h.app.Client.WithContext(ctx).GetRelatedOccupations(request.Code, limit)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationNeighbor, nil, err)
 	}
-	return l.HTTPResponseSuccessJSON(response)
+
+	return Def.GetApiOccupationCodeRelated200JSONResponse(neighbors), nil
 }
 
-func (h ClientAPIsHandler) getAllOccupationDatas(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	occupationData, err := h.app.Client.GetAllOccupationDatas()
-	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeOccupationData, nil, err, http.StatusInternalServerError, true)
+// GetApiOccupationPathways returns the pathways between two occupations
+func (h ClientAPIsHandler) GetApiOccupationPathways(ctx context.Context, request Def.GetApiOccupationPathwaysRequestObject) (Def.GetApiOccupationPathwaysResponseObject, error) {
+	if len(request.Params.From) <= 0 {
+		return nil, errors.ErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("from")).SetStatus(utils.ErrorStatusInvalid)
+	}
+	if len(request.Params.To) <= 0 {
+		return nil, errors.ErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("to")).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	limit := 1
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+		if limit <= 0 || limit > model.MaxOccupationPathways {
+			return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("limit")).SetStatus(utils.ErrorStatusInvalid)
+		}
 	}
 
-	response, err := json.Marshal(occupationData)
+	pathways, err := h.app.Client.WithContext(ctx).GetOccupationPathways(request.Params.From, request.Params.To, limit)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationPathway, nil, err)
 	}
-	return l.HTTPResponseSuccessJSON(response)
+
+	return Def.GetApiOccupationPathways200JSONResponse(pathways), nil
+}
+
+// GetApiCrosswalks returns the loaded crosswalk systems
+func (h ClientAPIsHandler) GetApiCrosswalks(ctx context.Context, request Def.GetApiCrosswalksRequestObject) (Def.GetApiCrosswalksResponseObject, error) {
+	return Def.GetApiCrosswalks200JSONResponse(h.app.Client.WithContext(ctx).GetCrosswalkSystems()), nil
 }
 
-func (h ClientAPIsHandler) getUserMatchingResult(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	id := claims.Subject
-	userMatchingResult, err := h.app.Client.GetUserMatchingResult(id)
+// GetApiOccupationCodeCrosswalks returns the equivalent codes of an occupation in other classification systems
+func (h ClientAPIsHandler) GetApiOccupationCodeCrosswalks(ctx context.Context, request Def.GetApiOccupationCodeCrosswalksRequestObject) (Def.GetApiOccupationCodeCrosswalksResponseObject, error) {
+	systems, err := getCrosswalkSystemsParam(request.Params.Systems)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeUserMatchingResult, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("systems"), err).SetStatus(utils.ErrorStatusInvalid)
 	}
 
-	response, err := json.Marshal(userMatchingResult)
+	crosswalks, err := h.app.Client.WithContext(ctx).GetOccupationCrosswalks(request.Code, systems)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeCrosswalkCode, nil, err)
 	}
-	return l.HTTPResponseSuccessJSON(response)
+
+	return Def.GetApiOccupationCodeCrosswalks200JSONResponse(crosswalks), nil
 }
 
-func (h ClientAPIsHandler) deleteUserMatchingResult(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	id := claims.Subject
-	err := h.app.Client.DeleteUserMatchingResult(id)
+// GetApiCrosswalksSystemOccupations returns the occupations equivalent to a code of a classification system
+func (h ClientAPIsHandler) GetApiCrosswalksSystemOccupations(ctx context.Context, request Def.GetApiCrosswalksSystemOccupationsRequestObject) (Def.GetApiCrosswalksSystemOccupationsResponseObject, error) {
+	system := string(request.System)
+	if !isCrosswalkSystem(system) {
+		return nil, errors.ErrorData(logutils.StatusInvalid, logutils.TypePathParam, logutils.StringArgs("system")).SetStatus(utils.ErrorStatusInvalid)
+	}
+	if len(request.Params.Code) <= 0 {
+		return nil, errors.ErrorData(logutils.StatusMissing, logutils.TypeQueryParam, logutils.StringArgs("code")).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	occupations, err := h.app.Client.WithContext(ctx).GetCrosswalkOccupations(system, request.Params.Code)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeUserMatchingResult, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeOccupationMatch, nil, err)
 	}
 
-	return l.HTTPResponseSuccess()
+	return Def.GetApiCrosswalksSystemOccupations200JSONResponse(occupations), nil
 }
 
-func (h ClientAPIsHandler) getSurveyData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// GetApiUserMatchResults returns the matching result of the current user
+func (h ClientAPIsHandler) GetApiUserMatchResults(ctx context.Context, request Def.GetApiUserMatchResultsRequestObject) (Def.GetApiUserMatchResultsResponseObject, error) {
+	crosswalkSystems, err := getCrosswalkSystemsParam(request.Params.Crosswalks)
+	if err != nil {
+		return nil, errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeQueryParam, logutils.StringArgs("crosswalks"), err).SetStatus(utils.ErrorStatusInvalid)
+	}
+
+	id := requestClaims(ctx).Subject
+	userMatchingResult, err := h.app.Client.WithContext(ctx).GetUserMatchingResult(id, crosswalkSystems)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeUserMatchingResult, nil, err)
 	}
 
-	surveyData, err := h.app.Client.GetSurveyData(id)
+	return Def.GetApiUserMatchResults200JSONResponse{Body: *userMatchingResult, Headers: Def.GetApiUserMatchResults200ResponseHeaders{ETag: etag(userMatchingResult.Revision)}}, nil
+}
+
+// GetApiMatchingEvents streams the occupation matching events of the current user
+func (h ClientAPIsHandler) GetApiMatchingEvents(ctx context.Context, request Def.GetApiMatchingEventsRequestObject) (Def.GetApiMatchingEventsResponseObject, error) {
+	accountID := requestClaims(ctx).Subject
+	events, unsubscribe, err := h.app.Client.WithContext(ctx).SubscribeMatchingEvents(accountID)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionGet, model.TypeSurveyData, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction("subscribing", model.TypeMatchingEvent, nil, err)
+	}
+
+	return matchingEventsResponse{ctx: ctx, streams: h.streams, events: events, unsubscribe: unsubscribe}, nil
+}
+
+// matchingEventsResponse streams the matching events as server-sent events until the request is done, the events channel is closed or the server shuts down
+type matchingEventsResponse struct {
+	ctx         context.Context
+	streams     context.Context
+	events      <-chan model.MatchingEvent
+	unsubscribe func()
+}
+
+func (r matchingEventsResponse) VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error {
+	defer r.unsubscribe()
+
+	// the stream lasts longer than the write timeout of the server
+	controller := http.NewResponseController(w)
+	err := controller.SetWriteDeadline(time.Time{})
+	if err != nil && !stderrors.Is(err, http.ErrNotSupported) {
+		return errors.WrapErrorAction(logutils.ActionUpdate, "write deadline", nil, err)
 	}
 
-	response, err := json.Marshal(surveyData)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	keepAlive := time.NewTicker(matchingEventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		// write errors mean that the client is gone, which ends the request
+		if controller.Flush() != nil {
+			return nil
+		}
+
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return errors.WrapErrorAction(logutils.ActionMarshal, model.TypeMatchingEvent, nil, err)
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			if err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+			if err != nil {
+				return nil
+			}
+		case <-r.ctx.Done():
+			return nil
+		case <-r.streams.Done():
+			return nil
+		}
+	}
+}
+
+// DeleteApiUserMatchResults deletes the matching result of the current user
+func (h ClientAPIsHandler) DeleteApiUserMatchResults(ctx context.Context, request Def.DeleteApiUserMatchResultsRequestObject) (Def.DeleteApiUserMatchResultsResponseObject, error) {
+	id := requestClaims(ctx).Subject
+	err := h.app.Client.WithContext(ctx).DeleteUserMatchingResult(id)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeUserMatchingResult, nil, err)
 	}
-	return l.HTTPResponseSuccessJSON(response)
+
+	return Def.DeleteApiUserMatchResults200Response{}, nil
 }
 
-func (h ClientAPIsHandler) createSurveyData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	var requestData model.SurveyData
-	err := json.NewDecoder(r.Body).Decode(&requestData)
+// GetApiSurveyDataId returns the survey data with the given ID
+func (h ClientAPIsHandler) GetApiSurveyDataId(ctx context.Context, request Def.GetApiSurveyDataIdRequestObject) (Def.GetApiSurveyDataIdResponseObject, error) {
+	surveyData, err := h.app.Client.WithContext(ctx).GetSurveyData(request.Id)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeSurveyData, nil, err)
 	}
 
-	surveyData, err := h.app.Client.CreateSurveyData(requestData)
-	if err != nil || surveyData == nil {
-		return l.HTTPResponseErrorAction(logutils.ActionCreate, model.TypeSurveyData, nil, err, http.StatusInternalServerError, true)
+	return Def.GetApiSurveyDataId200JSONResponse{Body: *surveyData, Headers: Def.GetApiSurveyDataId200ResponseHeaders{ETag: etag(surveyData.Revision)}}, nil
+}
+
+// PostApiSurveyData creates the survey data of the current user and starts matching it to occupations
+func (h ClientAPIsHandler) PostApiSurveyData(ctx context.Context, request Def.PostApiSurveyDataRequestObject) (Def.PostApiSurveyDataResponseObject, error) {
+	accountID := requestClaims(ctx).Subject
+	requestData := *request.Body
+	requestData.AccountID = accountID
+	if request.Params.IdempotencyKey != nil {
+		surveyData, replayed, err := h.app.Client.WithContext(ctx).CreateSurveyDataIdempotent(requestData, *request.Params.IdempotencyKey)
+		if err != nil {
+			return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeSurveyData, nil, err)
+		}
+		if !replayed {
+			h.app.Client.WithContext(ctx).MatchOccupations(*surveyData, accountID)
+		}
+		return Def.PostApiSurveyData200JSONResponse(*surveyData), nil
 	}
-	go h.app.Client.MatchOccupations(*surveyData, claims.Subject)
 
-	response, err := json.Marshal(surveyData)
+	surveyData, err := h.app.Client.WithContext(ctx).CreateSurveyData(requestData)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionMarshal, logutils.TypeResponseBody, nil, err, http.StatusInternalServerError, false)
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeSurveyData, nil, err)
 	}
-	return l.HTTPResponseSuccessJSON(response)
+	h.app.Client.WithContext(ctx).MatchOccupations(*surveyData, accountID)
+
+	return Def.PostApiSurveyData200JSONResponse(*surveyData), nil
 }
 
-func (h ClientAPIsHandler) updateSurveyData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// PutApiSurveyDataId updates the survey data with the given ID
+func (h ClientAPIsHandler) PutApiSurveyDataId(ctx context.Context, request Def.PutApiSurveyDataIdRequestObject) (Def.PutApiSurveyDataIdResponseObject, error) {
+	revision, err := ifMatchRevision(request.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	requestData := *request.Body
+	if revision > 0 {
+		requestData.Revision = revision
 	}
+	requestData.ID = request.Id
+	err = h.app.Client.WithContext(ctx).UpdateSurveyData(requestData)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionUpdate, model.TypeSurveyData, nil, err)
+	}
+
+	return Def.PutApiSurveyDataId200Response{}, nil
+}
 
-	var requestData model.SurveyData
-	err := json.NewDecoder(r.Body).Decode(&requestData)
+// DeleteApiSurveyDataId deletes the survey data with the given ID
+func (h ClientAPIsHandler) DeleteApiSurveyDataId(ctx context.Context, request Def.DeleteApiSurveyDataIdRequestObject) (Def.DeleteApiSurveyDataIdResponseObject, error) {
+	err := h.app.Client.WithContext(ctx).DeleteSurveyData(request.Id)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUnmarshal, logutils.TypeRequestBody, nil, err, http.StatusBadRequest, true)
+		return nil, errors.WrapErrorAction(logutils.ActionDelete, model.TypeSurveyData, nil, err)
+	}
+
+	return Def.DeleteApiSurveyDataId200Response{}, nil
+}
+
+// GetApiUserDataExport exports all the data of the current user, returning the archive or the export generated in the background
+func (h ClientAPIsHandler) GetApiUserDataExport(ctx context.Context, request Def.GetApiUserDataExportRequestObject) (Def.GetApiUserDataExportResponseObject, error) {
+	format := model.UserDataExportFormatJSON
+	if request.Params.Format != nil {
+		format = string(*request.Params.Format)
 	}
 
-	requestData.ID = id
-	err = h.app.Client.UpdateSurveyData(requestData)
+	userDataExport, err := h.app.Client.WithContext(ctx).ExportUserData(requestClaims(ctx).Subject, format)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionUpdate, model.TypeSurveyData, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionCreate, model.TypeUserDataExport, nil, err)
+	}
+	if userDataExport.Status == model.UserDataExportStatusReady {
+		return userDataArchiveResponse{userDataExport: *userDataExport}, nil
 	}
 
-	return l.HTTPResponseSuccess()
+	headers := Def.GetApiUserDataExport202ResponseHeaders{Location: fmt.Sprintf("%s/api/user-data/exports/%s", h.baseURL, userDataExport.ID)}
+	return Def.GetApiUserDataExport202JSONResponse{Body: h.userDataExportBody(*userDataExport), Headers: headers}, nil
 }
 
-func (h ClientAPIsHandler) deleteSurveyData(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	params := mux.Vars(r)
-	id := params["id"]
-	if len(id) <= 0 {
-		return l.HTTPResponseErrorData(logutils.StatusMissing, logutils.TypePathParam, logutils.StringArgs("id"), nil, http.StatusBadRequest, false)
+// GetApiUserDataExportsId returns the status of an export of the data of the current user
+func (h ClientAPIsHandler) GetApiUserDataExportsId(ctx context.Context, request Def.GetApiUserDataExportsIdRequestObject) (Def.GetApiUserDataExportsIdResponseObject, error) {
+	userDataExport, err := h.app.Client.WithContext(ctx).GetUserDataExport(requestClaims(ctx).Subject, request.Id)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeUserDataExport, nil, err)
 	}
 
-	err := h.app.Client.DeleteSurveyData(id)
+	return Def.GetApiUserDataExportsId200JSONResponse(h.userDataExportBody(*userDataExport)), nil
+}
+
+// GetApiUserDataExportsIdDownload returns the archive of an export, given the token of its download link
+func (h ClientAPIsHandler) GetApiUserDataExportsIdDownload(ctx context.Context, request Def.GetApiUserDataExportsIdDownloadRequestObject) (Def.GetApiUserDataExportsIdDownloadResponseObject, error) {
+	userDataExport, err := h.app.Client.WithContext(ctx).DownloadUserDataExport(request.Id, request.Params.Token)
 	if err != nil {
-		return l.HTTPResponseErrorAction(logutils.ActionDelete, model.TypeSurveyData, nil, err, http.StatusInternalServerError, true)
+		return nil, errors.WrapErrorAction(logutils.ActionGet, model.TypeUserDataExport, nil, err)
+	}
+
+	return userDataArchiveResponse{userDataExport: *userDataExport}, nil
+}
+
+// userDataExportBody returns the status of an export, with its download link once it is ready
+func (h ClientAPIsHandler) userDataExportBody(userDataExport model.UserDataExport) Def.UserDataExport {
+	format := Def.UserDataExportFormat(userDataExport.Format)
+	status := Def.UserDataExportStatus(userDataExport.Status)
+	body := Def.UserDataExport{Id: &userDataExport.ID, Format: &format, Status: &status, DateCreated: &userDataExport.DateCreated, DateExpires: &userDataExport.DateExpires}
+	if userDataExport.Status == model.UserDataExportStatusReady {
+		downloadURL := fmt.Sprintf("%s/api/user-data/exports/%s/download?token=%s", h.baseURL, userDataExport.ID, url.QueryEscape(userDataExport.Token))
+		body.DownloadUrl = &downloadURL
+	}
+	return body
+}
+
+// userDataArchiveResponse writes the archive of a ready export as a file attachment
+type userDataArchiveResponse struct {
+	userDataExport model.UserDataExport
+}
+
+func (r userDataArchiveResponse) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r userDataArchiveResponse) VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r userDataArchiveResponse) write(w http.ResponseWriter) error {
+	contentType, fileName := "application/json", "user-data.json"
+	if r.userDataExport.Format == model.UserDataExportFormatCSV {
+		contentType, fileName = "application/zip", "user-data.zip"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Header().Set("Content-Length", strconv.Itoa(len(r.userDataExport.Data)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(r.userDataExport.Data)
+	return err
+}
+
+// getCrosswalkSystemsParam parses a comma separated list of crosswalk systems from the given query param
+func getCrosswalkSystemsParam(param *string) ([]string, error) {
+	if param == nil || len(*param) == 0 {
+		return nil, nil
 	}
 
-	return l.HTTPResponseSuccess()
+	systems := strings.Split(*param, ",")
+	for i, system := range systems {
+		systems[i] = strings.TrimSpace(system)
+		if !isCrosswalkSystem(systems[i]) {
+			return nil, errors.ErrorData(logutils.StatusInvalid, model.TypeCrosswalk, &logutils.FieldArgs{"system": systems[i]})
+		}
+	}
+	return systems, nil
+}
+
+func isCrosswalkSystem(system string) bool {
+	for _, supported := range model.CrosswalkSystems {
+		if system == supported {
+			return true
+		}
+	}
+	return false
 }
 
 // NewClientAPIsHandler creates new client API handler instance
-func NewClientAPIsHandler(app *core.Application) ClientAPIsHandler {
-	return ClientAPIsHandler{app: app}
+func NewClientAPIsHandler(app *core.Application, baseURL string) ClientAPIsHandler {
+	streams, stopStreams := context.WithCancel(context.Background())
+	return ClientAPIsHandler{app: app, baseURL: baseURL, streams: streams, stopStreams: stopStreams}
 }
@@ -16,22 +16,54 @@ package web
 
 import (
 	"application/core"
-	"net/http"
+	"application/core/model"
+	Def "application/driver/web/docs/gen"
+	"context"
+	"time"
 
-	"github.com/rokwire/core-auth-library-go/v3/tokenauth"
-	"github.com/rokwire/logging-library-go/v2/logs"
+	"github.com/rokwire/core-auth-library-go/v3/authservice"
 )
 
+// authServiceID is the ID of the auth service registration used to validate tokens
+const authServiceID string = "auth"
+
 // DefaultAPIsHandler handles the default rest APIs implementation
 type DefaultAPIsHandler struct {
-	app *core.Application
+	app               *core.Application
+	serviceRegManager *authservice.ServiceRegManager
+}
+
+// GetVersion returns the version of the service
+func (h DefaultAPIsHandler) GetVersion(ctx context.Context, request Def.GetVersionRequestObject) (Def.GetVersionResponseObject, error) {
+	return Def.GetVersion200TextResponse(h.app.Default.GetVersion()), nil
 }
 
-func (h DefaultAPIsHandler) version(l *logs.Log, r *http.Request, claims *tokenauth.Claims) logs.HTTPResponse {
-	return l.HTTPResponseSuccessMessage(h.app.Default.GetVersion())
+// GetHealthLive reports that the service is running
+func (h DefaultAPIsHandler) GetHealthLive(ctx context.Context, request Def.GetHealthLiveRequestObject) (Def.GetHealthLiveResponseObject, error) {
+	return Def.GetHealthLive200JSONResponse(h.app.Default.GetHealth(false)), nil
+}
+
+// GetHealthReady reports whether the service and its dependencies are ready, with the 503 status code when they are not
+func (h DefaultAPIsHandler) GetHealthReady(ctx context.Context, request Def.GetHealthReadyRequestObject) (Def.GetHealthReadyResponseObject, error) {
+	health := h.app.Default.GetHealth(true)
+
+	// tokens can only be validated once the auth service registration and its public key are loaded
+	now := time.Now().UTC()
+	registration := model.HealthCheck{ComponentID: authServiceID, Status: model.HealthStatusPass, Time: &now}
+	_, err := h.serviceRegManager.GetServiceRegWithPubKey(authServiceID)
+	if err != nil {
+		registration.Status = model.HealthStatusFail
+		registration.Output = err.Error()
+	}
+	health.AddChecks("auth:registration", registration)
+
+	if health.Status == model.HealthStatusFail {
+		return Def.GetHealthReady503JSONResponse(health), nil
+	}
+	return Def.GetHealthReady200JSONResponse(health), nil
 }
 
 // NewDefaultAPIsHandler creates new default API Handler instance
-func NewDefaultAPIsHandler(app *core.Application) DefaultAPIsHandler {
-	return DefaultAPIsHandler{app: app}
+func NewDefaultAPIsHandler(app *core.Application, serviceRegManager *authservice.ServiceRegManager) DefaultAPIsHandler {
+	return DefaultAPIsHandler{app: app, serviceRegManager: serviceRegManager}
 }
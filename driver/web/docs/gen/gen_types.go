@@ -0,0 +1,4800 @@
+// Package Def provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package Def
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"application/core/model"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/gorilla/mux"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for UserDataExportFormat.
+const (
+	UserDataExportFormatCsv  UserDataExportFormat = "csv"
+	UserDataExportFormatJson UserDataExportFormat = "json"
+)
+
+// Defines values for UserDataExportStatus.
+const (
+	Failed  UserDataExportStatus = "failed"
+	Pending UserDataExportStatus = "pending"
+	Ready   UserDataExportStatus = "ready"
+)
+
+// Defines values for GetApiCrosswalksSystemOccupationsParamsSystem.
+const (
+	Cip     GetApiCrosswalksSystemOccupationsParamsSystem = "cip"
+	Esco    GetApiCrosswalksSystemOccupationsParamsSystem = "esco"
+	Isco08  GetApiCrosswalksSystemOccupationsParamsSystem = "isco08"
+	Soc2018 GetApiCrosswalksSystemOccupationsParamsSystem = "soc2018"
+)
+
+// Defines values for GetApiUserDataExportParamsFormat.
+const (
+	GetApiUserDataExportParamsFormatCsv  GetApiUserDataExportParamsFormat = "csv"
+	GetApiUserDataExportParamsFormatJson GetApiUserDataExportParamsFormat = "json"
+)
+
+// Config defines model for Config.
+type Config = model.Config
+
+// CrosswalkCode defines model for CrosswalkCode.
+type CrosswalkCode = model.CrosswalkCode
+
+// EnvConfigData defines model for EnvConfigData.
+type EnvConfigData = model.EnvConfigData
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+
+	// Status Status of the error, such as `not-found`, `invalid` or `conflict`
+	Status string `json:"status"`
+}
+
+// Health defines model for Health.
+type Health = model.Health
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck = model.HealthCheck
+
+// Match defines model for Match.
+type Match = model.Match
+
+// OccupationData defines model for OccupationData.
+type OccupationData = model.OccupationData
+
+// OccupationDataReport defines model for OccupationDataReport.
+type OccupationDataReport = model.OccupationDataReport
+
+// OccupationMatch defines model for OccupationMatch.
+type OccupationMatch = model.OccupationMatch
+
+// OccupationNeighbor defines model for OccupationNeighbor.
+type OccupationNeighbor = model.OccupationNeighbor
+
+// OccupationPathway defines model for OccupationPathway.
+type OccupationPathway = model.OccupationPathway
+
+// OccupationPathwayStep defines model for OccupationPathwayStep.
+type OccupationPathwayStep = model.OccupationPathwayStep
+
+// OccupationWorkstylesIssue defines model for OccupationWorkstylesIssue.
+type OccupationWorkstylesIssue = model.OccupationWorkstylesIssue
+
+// RateLimit defines model for RateLimit.
+type RateLimit = model.RateLimit
+
+// RateLimitConfigData defines model for RateLimitConfigData.
+type RateLimitConfigData = model.RateLimitConfigData
+
+// RelatedOccupation defines model for RelatedOccupation.
+type RelatedOccupation = model.RelatedOccupation
+
+// RetentionConfigData defines model for RetentionConfigData.
+type RetentionConfigData = model.RetentionConfigData
+
+// RetentionPolicy defines model for RetentionPolicy.
+type RetentionPolicy = model.RetentionPolicy
+
+// RetentionReport defines model for RetentionReport.
+type RetentionReport = model.RetentionReport
+
+// SurveyData defines model for SurveyData.
+type SurveyData = model.SurveyData
+
+// TechnologySkill defines model for TechnologySkill.
+type TechnologySkill = model.TechnologySkill
+
+// UserDataArchive All the data stored about a user. The match results are every match result saved for the user, from the oldest. No preferences of the user are stored.
+type UserDataArchive = model.UserDataArchive
+
+// UserDataDeletion defines model for UserDataDeletion.
+type UserDataDeletion = model.UserDataDeletion
+
+// UserDataExport An export of the data of a user generated in the background, downloaded from its short-lived link once ready
+type UserDataExport struct {
+	DateCreated *time.Time `json:"date_created,omitempty"`
+
+	// DateExpires Date after which the export is deleted and can no longer be downloaded
+	DateExpires *time.Time `json:"date_expires,omitempty"`
+
+	// DownloadUrl Link to download the export without a token until it expires, only set once the export is ready
+	DownloadUrl *string               `json:"download_url,omitempty"`
+	Format      *UserDataExportFormat `json:"format,omitempty"`
+	Id          *string               `json:"id,omitempty"`
+	Status      *UserDataExportStatus `json:"status,omitempty"`
+}
+
+// UserDataExportFormat defines model for UserDataExport.Format.
+type UserDataExportFormat string
+
+// UserDataExportStatus defines model for UserDataExport.Status.
+type UserDataExportStatus string
+
+// UserMatchingResult defines model for UserMatchingResult.
+type UserMatchingResult = model.UserMatchingResult
+
+// UserMatchingResultHistory A match result of a user as it was saved
+type UserMatchingResultHistory = model.UserMatchingResultHistory
+
+// ValidationError defines model for ValidationError.
+type ValidationError struct {
+	// Errors Fields which failed validation
+	Errors []struct {
+		// Field Path of the field, such as `scores[2].score`
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// Workstyle defines model for Workstyle.
+type Workstyle = model.Workstyle
+
+// WorkstyleScore defines model for WorkstyleScore.
+type WorkstyleScore = model.WorkstyleScore
+
+// AdminReqUpdateConfigs defines model for _admin_req_update-configs.
+type AdminReqUpdateConfigs struct {
+	AllApps *bool       `json:"all_apps,omitempty"`
+	AllOrgs *bool       `json:"all_orgs,omitempty"`
+	Data    interface{} `json:"data"`
+	System  bool        `json:"system"`
+	Type    string      `json:"type"`
+}
+
+// GetApiAdminConfigsParams defines parameters for GetApiAdminConfigs.
+type GetApiAdminConfigsParams struct {
+	// Type config type
+	Type *string `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// PutApiAdminConfigsIdParams defines parameters for PutApiAdminConfigsId.
+type PutApiAdminConfigsIdParams struct {
+	// IfMatch Revision of the record being updated, as returned in the `ETag` header. The update is rejected if the record was changed since
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetApiAdminMatchResultsParams defines parameters for GetApiAdminMatchResults.
+type GetApiAdminMatchResultsParams struct {
+	// AccountId Account ID of the user
+	AccountId *string `form:"account_id,omitempty" json:"account_id,omitempty"`
+
+	// Version Survey version
+	Version *string `form:"version,omitempty" json:"version,omitempty"`
+
+	// From Earliest date of last update, inclusive
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Latest date of last update, inclusive
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum number of results, 50 by default
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetApiAdminSurveyDataParams defines parameters for GetApiAdminSurveyData.
+type GetApiAdminSurveyDataParams struct {
+	// AccountId Account ID of the user
+	AccountId *string `form:"account_id,omitempty" json:"account_id,omitempty"`
+
+	// Version Survey version
+	Version *string `form:"version,omitempty" json:"version,omitempty"`
+
+	// From Earliest creation date, inclusive
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Latest creation date, inclusive
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum number of results, 50 by default
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetApiCrosswalksSystemOccupationsParams defines parameters for GetApiCrosswalksSystemOccupations.
+type GetApiCrosswalksSystemOccupationsParams struct {
+	// Code Code in the classification system
+	Code string `form:"code" json:"code"`
+}
+
+// GetApiCrosswalksSystemOccupationsParamsSystem defines parameters for GetApiCrosswalksSystemOccupations.
+type GetApiCrosswalksSystemOccupationsParamsSystem string
+
+// GetApiOccupationPathwaysParams defines parameters for GetApiOccupationPathways.
+type GetApiOccupationPathwaysParams struct {
+	// From Code of the starting occupation
+	From string `form:"from" json:"from"`
+
+	// To Code of the target occupation
+	To string `form:"to" json:"to"`
+
+	// Limit Maximum number of pathways to retrieve, at most 10
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiOccupationCodeCrosswalksParams defines parameters for GetApiOccupationCodeCrosswalks.
+type GetApiOccupationCodeCrosswalksParams struct {
+	// Systems Comma separated list of classification systems to include. All loaded systems are included if not provided
+	Systems *string `form:"systems,omitempty" json:"systems,omitempty"`
+}
+
+// GetApiOccupationCodeRelatedParams defines parameters for GetApiOccupationCodeRelated.
+type GetApiOccupationCodeRelatedParams struct {
+	// Limit Maximum number of related occupations to retrieve
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostApiSurveyDataParams defines parameters for PostApiSurveyData.
+type PostApiSurveyDataParams struct {
+	// IdempotencyKey Unique key of the submission, such as a UUID, used to retry it safely. Keys expire after the idempotency window of the service
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// PutApiSurveyDataIdParams defines parameters for PutApiSurveyDataId.
+type PutApiSurveyDataIdParams struct {
+	// IfMatch Revision of the record being updated, as returned in the `ETag` header. The update is rejected if the record was changed since
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetApiUserDataExportParams defines parameters for GetApiUserDataExport.
+type GetApiUserDataExportParams struct {
+	// Format Format of the archive, `json` for a JSON document or `csv` for a ZIP archive of CSV files
+	Format *GetApiUserDataExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetApiUserDataExportParamsFormat defines parameters for GetApiUserDataExport.
+type GetApiUserDataExportParamsFormat string
+
+// GetApiUserDataExportsIdDownloadParams defines parameters for GetApiUserDataExportsIdDownload.
+type GetApiUserDataExportsIdDownloadParams struct {
+	// Token Token of the download link
+	Token string `form:"token" json:"token"`
+}
+
+// GetApiUserMatchResultsParams defines parameters for GetApiUserMatchResults.
+type GetApiUserMatchResultsParams struct {
+	// Crosswalks Comma separated list of classification systems whose equivalent codes should be included for each occupation match
+	Crosswalks *string `form:"crosswalks,omitempty" json:"crosswalks,omitempty"`
+}
+
+// PostApiAdminConfigsJSONRequestBody defines body for PostApiAdminConfigs for application/json ContentType.
+type PostApiAdminConfigsJSONRequestBody = AdminReqUpdateConfigs
+
+// PutApiAdminConfigsIdJSONRequestBody defines body for PutApiAdminConfigsId for application/json ContentType.
+type PutApiAdminConfigsIdJSONRequestBody = AdminReqUpdateConfigs
+
+// PostApiAdminOccupationsJSONRequestBody defines body for PostApiAdminOccupations for application/json ContentType.
+type PostApiAdminOccupationsJSONRequestBody = OccupationData
+
+// PutApiAdminOccupationsCodeJSONRequestBody defines body for PutApiAdminOccupationsCode for application/json ContentType.
+type PutApiAdminOccupationsCodeJSONRequestBody = OccupationData
+
+// PostApiSurveyDataJSONRequestBody defines body for PostApiSurveyData for application/json ContentType.
+type PostApiSurveyDataJSONRequestBody = SurveyData
+
+// PutApiSurveyDataIdJSONRequestBody defines body for PutApiSurveyDataId for application/json ContentType.
+type PutApiSurveyDataIdJSONRequestBody = SurveyData
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get configs
+	// (GET /api/admin/configs)
+	GetApiAdminConfigs(w http.ResponseWriter, r *http.Request, params GetApiAdminConfigsParams)
+	// Create config
+	// (POST /api/admin/configs)
+	PostApiAdminConfigs(w http.ResponseWriter, r *http.Request)
+	// Delete config
+	// (DELETE /api/admin/configs/{id})
+	DeleteApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string)
+	// Get config
+	// (GET /api/admin/configs/{id})
+	GetApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string)
+	// Update config
+	// (PUT /api/admin/configs/{id})
+	PutApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string, params PutApiAdminConfigsIdParams)
+	// Search match results
+	// (GET /api/admin/match-results)
+	GetApiAdminMatchResults(w http.ResponseWriter, r *http.Request, params GetApiAdminMatchResultsParams)
+	// Get match result
+	// (GET /api/admin/match-results/{account_id})
+	GetApiAdminMatchResultsAccountId(w http.ResponseWriter, r *http.Request, accountId string)
+	// Get occupations
+	// (GET /api/admin/occupations)
+	GetApiAdminOccupations(w http.ResponseWriter, r *http.Request)
+	// Create occupation
+	// (POST /api/admin/occupations)
+	PostApiAdminOccupations(w http.ResponseWriter, r *http.Request)
+	// Delete occupation
+	// (DELETE /api/admin/occupations/{code})
+	DeleteApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string)
+	// Get occupation
+	// (GET /api/admin/occupations/{code})
+	GetApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string)
+	// Update occupation
+	// (PUT /api/admin/occupations/{code})
+	PutApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string)
+	// Get occupation data report
+	// (GET /api/admin/reports/occupation-data)
+	GetApiAdminReportsOccupationData(w http.ResponseWriter, r *http.Request)
+	// Get retention report
+	// (GET /api/admin/reports/retention)
+	GetApiAdminReportsRetention(w http.ResponseWriter, r *http.Request)
+	// Search survey data
+	// (GET /api/admin/survey-data)
+	GetApiAdminSurveyData(w http.ResponseWriter, r *http.Request, params GetApiAdminSurveyDataParams)
+	// Get survey data
+	// (GET /api/admin/survey-data/{id})
+	GetApiAdminSurveyDataId(w http.ResponseWriter, r *http.Request, id string)
+	// Delete user data
+	// (DELETE /api/admin/users/{account_id}/data)
+	DeleteApiAdminUsersAccountIdData(w http.ResponseWriter, r *http.Request, accountId string)
+	// Match user occupations
+	// (POST /api/admin/users/{account_id}/matching)
+	PostApiAdminUsersAccountIdMatching(w http.ResponseWriter, r *http.Request, accountId string)
+	// Gets crosswalk systems
+	// (GET /api/crosswalks)
+	GetApiCrosswalks(w http.ResponseWriter, r *http.Request)
+	// Gets crosswalk occupations
+	// (GET /api/crosswalks/{system}/occupations)
+	GetApiCrosswalksSystemOccupations(w http.ResponseWriter, r *http.Request, system GetApiCrosswalksSystemOccupationsParamsSystem, params GetApiCrosswalksSystemOccupationsParams)
+	// Streams the occupation matching events of the user
+	// (GET /api/matching-events)
+	GetApiMatchingEvents(w http.ResponseWriter, r *http.Request)
+	// Gets all Occupation data
+	// (GET /api/occupation)
+	GetApiOccupation(w http.ResponseWriter, r *http.Request)
+	// Gets occupation pathways
+	// (GET /api/occupation-pathways)
+	GetApiOccupationPathways(w http.ResponseWriter, r *http.Request, params GetApiOccupationPathwaysParams)
+	// Gets Occupation data
+	// (GET /api/occupation/{code})
+	GetApiOccupationCode(w http.ResponseWriter, r *http.Request, code string)
+	// Gets occupation crosswalks
+	// (GET /api/occupation/{code}/crosswalks)
+	GetApiOccupationCodeCrosswalks(w http.ResponseWriter, r *http.Request, code string, params GetApiOccupationCodeCrosswalksParams)
+	// Gets related occupations
+	// (GET /api/occupation/{code}/related)
+	GetApiOccupationCodeRelated(w http.ResponseWriter, r *http.Request, code string, params GetApiOccupationCodeRelatedParams)
+	// Posts the Survey data
+	// (POST /api/survey-data)
+	PostApiSurveyData(w http.ResponseWriter, r *http.Request, params PostApiSurveyDataParams)
+	// Deletes Survey data
+	// (DELETE /api/survey-data/{id})
+	DeleteApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string)
+	// Gets Survey data
+	// (GET /api/survey-data/{id})
+	GetApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string)
+	// Updates Survey data
+	// (PUT /api/survey-data/{id})
+	PutApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string, params PutApiSurveyDataIdParams)
+	// Exports all the data of the user
+	// (GET /api/user-data/export)
+	GetApiUserDataExport(w http.ResponseWriter, r *http.Request, params GetApiUserDataExportParams)
+	// Gets the status of a user data export
+	// (GET /api/user-data/exports/{id})
+	GetApiUserDataExportsId(w http.ResponseWriter, r *http.Request, id string)
+	// Downloads a user data export
+	// (GET /api/user-data/exports/{id}/download)
+	GetApiUserDataExportsIdDownload(w http.ResponseWriter, r *http.Request, id string, params GetApiUserDataExportsIdDownloadParams)
+	// Deletes User data
+	// (DELETE /api/user-match-results)
+	DeleteApiUserMatchResults(w http.ResponseWriter, r *http.Request)
+	// Gets User data
+	// (GET /api/user-match-results)
+	GetApiUserMatchResults(w http.ResponseWriter, r *http.Request, params GetApiUserMatchResultsParams)
+	// Get liveness
+	// (GET /health/live)
+	GetHealthLive(w http.ResponseWriter, r *http.Request)
+	// Get readiness
+	// (GET /health/ready)
+	GetHealthReady(w http.ResponseWriter, r *http.Request)
+	// Get version
+	// (GET /version)
+	GetVersion(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetApiAdminConfigs operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminConfigs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAdminConfigsParams
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminConfigs(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostApiAdminConfigs operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminConfigs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminConfigs(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteApiAdminConfigsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminConfigsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminConfigsId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminConfigsId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminConfigsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminConfigsId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutApiAdminConfigsId operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAdminConfigsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutApiAdminConfigsIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAdminConfigsId(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminMatchResults operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminMatchResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAdminMatchResultsParams
+
+	// ------------- Optional query parameter "account_id" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "account_id", r.URL.Query(), &params.AccountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "version", r.URL.Query(), &params.Version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminMatchResults(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminMatchResultsAccountId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminMatchResultsAccountId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account_id", mux.Vars(r)["account_id"], &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminMatchResultsAccountId(w, r, accountId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminOccupations operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminOccupations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminOccupations(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostApiAdminOccupations operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminOccupations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminOccupations(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteApiAdminOccupationsCode operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminOccupationsCode(w, r, code)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminOccupationsCode operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminOccupationsCode(w, r, code)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutApiAdminOccupationsCode operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAdminOccupationsCode(w, r, code)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminReportsOccupationData operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminReportsOccupationData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminReportsOccupationData(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminReportsRetention operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminReportsRetention(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminReportsRetention(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminSurveyData operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminSurveyData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAdminSurveyDataParams
+
+	// ------------- Optional query parameter "account_id" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "account_id", r.URL.Query(), &params.AccountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "version", r.URL.Query(), &params.Version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminSurveyData(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiAdminSurveyDataId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminSurveyDataId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminSurveyDataId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteApiAdminUsersAccountIdData operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminUsersAccountIdData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account_id", mux.Vars(r)["account_id"], &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminUsersAccountIdData(w, r, accountId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostApiAdminUsersAccountIdMatching operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminUsersAccountIdMatching(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId string
+
+	err = runtime.BindStyledParameter("simple", false, "account_id", mux.Vars(r)["account_id"], &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminUsersAccountIdMatching(w, r, accountId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiCrosswalks operation middleware
+func (siw *ServerInterfaceWrapper) GetApiCrosswalks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiCrosswalks(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiCrosswalksSystemOccupations operation middleware
+func (siw *ServerInterfaceWrapper) GetApiCrosswalksSystemOccupations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "system" -------------
+	var system GetApiCrosswalksSystemOccupationsParamsSystem
+
+	err = runtime.BindStyledParameter("simple", false, "system", mux.Vars(r)["system"], &system)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "system", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiCrosswalksSystemOccupationsParams
+
+	// ------------- Required query parameter "code" -------------
+
+	if paramValue := r.URL.Query().Get("code"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "code"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiCrosswalksSystemOccupations(w, r, system, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiMatchingEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetApiMatchingEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiMatchingEvents(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiOccupation operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOccupation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOccupation(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiOccupationPathways operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOccupationPathways(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiOccupationPathwaysParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOccupationPathways(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiOccupationCode operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOccupationCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOccupationCode(w, r, code)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiOccupationCodeCrosswalks operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOccupationCodeCrosswalks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiOccupationCodeCrosswalksParams
+
+	// ------------- Optional query parameter "systems" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "systems", r.URL.Query(), &params.Systems)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "systems", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOccupationCodeCrosswalks(w, r, code, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiOccupationCodeRelated operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOccupationCodeRelated(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameter("simple", false, "code", mux.Vars(r)["code"], &code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiOccupationCodeRelatedParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOccupationCodeRelated(w, r, code, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostApiSurveyData operation middleware
+func (siw *ServerInterfaceWrapper) PostApiSurveyData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostApiSurveyDataParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiSurveyData(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteApiSurveyDataId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiSurveyDataId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiSurveyDataId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiSurveyDataId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiSurveyDataId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiSurveyDataId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutApiSurveyDataId operation middleware
+func (siw *ServerInterfaceWrapper) PutApiSurveyDataId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutApiSurveyDataIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiSurveyDataId(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiUserDataExport operation middleware
+func (siw *ServerInterfaceWrapper) GetApiUserDataExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiUserDataExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiUserDataExport(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiUserDataExportsId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiUserDataExportsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiUserDataExportsId(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiUserDataExportsIdDownload operation middleware
+func (siw *ServerInterfaceWrapper) GetApiUserDataExportsIdDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", mux.Vars(r)["id"], &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiUserDataExportsIdDownloadParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiUserDataExportsIdDownload(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteApiUserMatchResults operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiUserMatchResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiUserMatchResults(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetApiUserMatchResults operation middleware
+func (siw *ServerInterfaceWrapper) GetApiUserMatchResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiUserMatchResultsParams
+
+	// ------------- Optional query parameter "crosswalks" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "crosswalks", r.URL.Query(), &params.Crosswalks)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "crosswalks", Err: err})
+		return
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiUserMatchResults(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetHealthLive operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthLive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthLive(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetHealthReady operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthReady(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthReady(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVersion(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{})
+}
+
+type GorillaServerOptions struct {
+	BaseURL          string
+	BaseRouter       *mux.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r *mux.Router) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r *mux.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, GorillaServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = mux.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.HandleFunc(options.BaseURL+"/api/admin/configs", wrapper.GetApiAdminConfigs).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/configs", wrapper.PostApiAdminConfigs).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/configs/{id}", wrapper.DeleteApiAdminConfigsId).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/configs/{id}", wrapper.GetApiAdminConfigsId).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/configs/{id}", wrapper.PutApiAdminConfigsId).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/match-results", wrapper.GetApiAdminMatchResults).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/match-results/{account_id}", wrapper.GetApiAdminMatchResultsAccountId).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/occupations", wrapper.GetApiAdminOccupations).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/occupations", wrapper.PostApiAdminOccupations).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/occupations/{code}", wrapper.DeleteApiAdminOccupationsCode).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/occupations/{code}", wrapper.GetApiAdminOccupationsCode).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/occupations/{code}", wrapper.PutApiAdminOccupationsCode).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/reports/occupation-data", wrapper.GetApiAdminReportsOccupationData).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/reports/retention", wrapper.GetApiAdminReportsRetention).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/survey-data", wrapper.GetApiAdminSurveyData).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/survey-data/{id}", wrapper.GetApiAdminSurveyDataId).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/users/{account_id}/data", wrapper.DeleteApiAdminUsersAccountIdData).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/admin/users/{account_id}/matching", wrapper.PostApiAdminUsersAccountIdMatching).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/crosswalks", wrapper.GetApiCrosswalks).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/crosswalks/{system}/occupations", wrapper.GetApiCrosswalksSystemOccupations).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/matching-events", wrapper.GetApiMatchingEvents).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/occupation", wrapper.GetApiOccupation).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/occupation-pathways", wrapper.GetApiOccupationPathways).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/occupation/{code}", wrapper.GetApiOccupationCode).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/occupation/{code}/crosswalks", wrapper.GetApiOccupationCodeCrosswalks).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/occupation/{code}/related", wrapper.GetApiOccupationCodeRelated).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/survey-data", wrapper.PostApiSurveyData).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/survey-data/{id}", wrapper.DeleteApiSurveyDataId).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/survey-data/{id}", wrapper.GetApiSurveyDataId).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/survey-data/{id}", wrapper.PutApiSurveyDataId).Methods("PUT")
+
+	r.HandleFunc(options.BaseURL+"/api/user-data/export", wrapper.GetApiUserDataExport).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/user-data/exports/{id}", wrapper.GetApiUserDataExportsId).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/user-data/exports/{id}/download", wrapper.GetApiUserDataExportsIdDownload).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/user-match-results", wrapper.DeleteApiUserMatchResults).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/user-match-results", wrapper.GetApiUserMatchResults).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/health/live", wrapper.GetHealthLive).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/health/ready", wrapper.GetHealthReady).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/version", wrapper.GetVersion).Methods("GET")
+
+	return r
+}
+
+type GetApiAdminConfigsRequestObject struct {
+	Params GetApiAdminConfigsParams
+}
+
+type GetApiAdminConfigsResponseObject interface {
+	VisitGetApiAdminConfigsResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminConfigs200JSONResponse []Config
+
+func (response GetApiAdminConfigs200JSONResponse) VisitGetApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminConfigs400Response struct {
+}
+
+func (response GetApiAdminConfigs400Response) VisitGetApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminConfigs401Response struct {
+}
+
+func (response GetApiAdminConfigs401Response) VisitGetApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminConfigs500Response struct {
+}
+
+func (response GetApiAdminConfigs500Response) VisitGetApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PostApiAdminConfigsRequestObject struct {
+	Body *PostApiAdminConfigsJSONRequestBody
+}
+
+type PostApiAdminConfigsResponseObject interface {
+	VisitPostApiAdminConfigsResponse(w http.ResponseWriter) error
+}
+
+type PostApiAdminConfigs200JSONResponse Config
+
+func (response PostApiAdminConfigs200JSONResponse) VisitPostApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiAdminConfigs400Response struct {
+}
+
+func (response PostApiAdminConfigs400Response) VisitPostApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiAdminConfigs401Response struct {
+}
+
+func (response PostApiAdminConfigs401Response) VisitPostApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiAdminConfigs500Response struct {
+}
+
+func (response PostApiAdminConfigs500Response) VisitPostApiAdminConfigsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type DeleteApiAdminConfigsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteApiAdminConfigsIdResponseObject interface {
+	VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiAdminConfigsId200TextResponse string
+
+func (response DeleteApiAdminConfigsId200TextResponse) VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type DeleteApiAdminConfigsId400Response struct {
+}
+
+func (response DeleteApiAdminConfigsId400Response) VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteApiAdminConfigsId401Response struct {
+}
+
+func (response DeleteApiAdminConfigsId401Response) VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiAdminConfigsId404JSONResponse Error
+
+func (response DeleteApiAdminConfigsId404JSONResponse) VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiAdminConfigsId500Response struct {
+}
+
+func (response DeleteApiAdminConfigsId500Response) VisitDeleteApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminConfigsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetApiAdminConfigsIdResponseObject interface {
+	VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminConfigsId200ResponseHeaders struct {
+	ETag string
+}
+
+type GetApiAdminConfigsId200JSONResponse struct {
+	Body    Config
+	Headers GetApiAdminConfigsId200ResponseHeaders
+}
+
+func (response GetApiAdminConfigsId200JSONResponse) VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiAdminConfigsId400Response struct {
+}
+
+func (response GetApiAdminConfigsId400Response) VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminConfigsId401Response struct {
+}
+
+func (response GetApiAdminConfigsId401Response) VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminConfigsId404JSONResponse Error
+
+func (response GetApiAdminConfigsId404JSONResponse) VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminConfigsId500Response struct {
+}
+
+func (response GetApiAdminConfigsId500Response) VisitGetApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PutApiAdminConfigsIdRequestObject struct {
+	Id     string `json:"id"`
+	Params PutApiAdminConfigsIdParams
+	Body   *PutApiAdminConfigsIdJSONRequestBody
+}
+
+type PutApiAdminConfigsIdResponseObject interface {
+	VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error
+}
+
+type PutApiAdminConfigsId200TextResponse string
+
+func (response PutApiAdminConfigsId200TextResponse) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type PutApiAdminConfigsId400Response struct {
+}
+
+func (response PutApiAdminConfigsId400Response) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutApiAdminConfigsId401Response struct {
+}
+
+func (response PutApiAdminConfigsId401Response) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutApiAdminConfigsId404JSONResponse Error
+
+func (response PutApiAdminConfigsId404JSONResponse) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiAdminConfigsId412Response struct {
+}
+
+func (response PutApiAdminConfigsId412Response) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type PutApiAdminConfigsId500Response struct {
+}
+
+func (response PutApiAdminConfigsId500Response) VisitPutApiAdminConfigsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminMatchResultsRequestObject struct {
+	Params GetApiAdminMatchResultsParams
+}
+
+type GetApiAdminMatchResultsResponseObject interface {
+	VisitGetApiAdminMatchResultsResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminMatchResults200JSONResponse []UserMatchingResult
+
+func (response GetApiAdminMatchResults200JSONResponse) VisitGetApiAdminMatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminMatchResults400Response struct {
+}
+
+func (response GetApiAdminMatchResults400Response) VisitGetApiAdminMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminMatchResults401Response struct {
+}
+
+func (response GetApiAdminMatchResults401Response) VisitGetApiAdminMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminMatchResults500Response struct {
+}
+
+func (response GetApiAdminMatchResults500Response) VisitGetApiAdminMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminMatchResultsAccountIdRequestObject struct {
+	AccountId string `json:"account_id"`
+}
+
+type GetApiAdminMatchResultsAccountIdResponseObject interface {
+	VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminMatchResultsAccountId200JSONResponse UserMatchingResult
+
+func (response GetApiAdminMatchResultsAccountId200JSONResponse) VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminMatchResultsAccountId400Response struct {
+}
+
+func (response GetApiAdminMatchResultsAccountId400Response) VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminMatchResultsAccountId401Response struct {
+}
+
+func (response GetApiAdminMatchResultsAccountId401Response) VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminMatchResultsAccountId404JSONResponse Error
+
+func (response GetApiAdminMatchResultsAccountId404JSONResponse) VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminMatchResultsAccountId500Response struct {
+}
+
+func (response GetApiAdminMatchResultsAccountId500Response) VisitGetApiAdminMatchResultsAccountIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminOccupationsRequestObject struct {
+}
+
+type GetApiAdminOccupationsResponseObject interface {
+	VisitGetApiAdminOccupationsResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminOccupations200JSONResponse []OccupationData
+
+func (response GetApiAdminOccupations200JSONResponse) VisitGetApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminOccupations400Response struct {
+}
+
+func (response GetApiAdminOccupations400Response) VisitGetApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminOccupations401Response struct {
+}
+
+func (response GetApiAdminOccupations401Response) VisitGetApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminOccupations500Response struct {
+}
+
+func (response GetApiAdminOccupations500Response) VisitGetApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PostApiAdminOccupationsRequestObject struct {
+	Body *PostApiAdminOccupationsJSONRequestBody
+}
+
+type PostApiAdminOccupationsResponseObject interface {
+	VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error
+}
+
+type PostApiAdminOccupations200JSONResponse OccupationData
+
+func (response PostApiAdminOccupations200JSONResponse) VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiAdminOccupations400Response struct {
+}
+
+func (response PostApiAdminOccupations400Response) VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiAdminOccupations401Response struct {
+}
+
+func (response PostApiAdminOccupations401Response) VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiAdminOccupations409Response struct {
+}
+
+func (response PostApiAdminOccupations409Response) VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostApiAdminOccupations500Response struct {
+}
+
+func (response PostApiAdminOccupations500Response) VisitPostApiAdminOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type DeleteApiAdminOccupationsCodeRequestObject struct {
+	Code string `json:"code"`
+}
+
+type DeleteApiAdminOccupationsCodeResponseObject interface {
+	VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiAdminOccupationsCode200TextResponse string
+
+func (response DeleteApiAdminOccupationsCode200TextResponse) VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type DeleteApiAdminOccupationsCode400Response struct {
+}
+
+func (response DeleteApiAdminOccupationsCode400Response) VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteApiAdminOccupationsCode401Response struct {
+}
+
+func (response DeleteApiAdminOccupationsCode401Response) VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiAdminOccupationsCode404JSONResponse Error
+
+func (response DeleteApiAdminOccupationsCode404JSONResponse) VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiAdminOccupationsCode500Response struct {
+}
+
+func (response DeleteApiAdminOccupationsCode500Response) VisitDeleteApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminOccupationsCodeRequestObject struct {
+	Code string `json:"code"`
+}
+
+type GetApiAdminOccupationsCodeResponseObject interface {
+	VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminOccupationsCode200JSONResponse OccupationData
+
+func (response GetApiAdminOccupationsCode200JSONResponse) VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminOccupationsCode400Response struct {
+}
+
+func (response GetApiAdminOccupationsCode400Response) VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminOccupationsCode401Response struct {
+}
+
+func (response GetApiAdminOccupationsCode401Response) VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminOccupationsCode404JSONResponse Error
+
+func (response GetApiAdminOccupationsCode404JSONResponse) VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminOccupationsCode500Response struct {
+}
+
+func (response GetApiAdminOccupationsCode500Response) VisitGetApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PutApiAdminOccupationsCodeRequestObject struct {
+	Code string `json:"code"`
+	Body *PutApiAdminOccupationsCodeJSONRequestBody
+}
+
+type PutApiAdminOccupationsCodeResponseObject interface {
+	VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error
+}
+
+type PutApiAdminOccupationsCode200TextResponse string
+
+func (response PutApiAdminOccupationsCode200TextResponse) VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type PutApiAdminOccupationsCode400Response struct {
+}
+
+func (response PutApiAdminOccupationsCode400Response) VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutApiAdminOccupationsCode401Response struct {
+}
+
+func (response PutApiAdminOccupationsCode401Response) VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutApiAdminOccupationsCode404JSONResponse Error
+
+func (response PutApiAdminOccupationsCode404JSONResponse) VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiAdminOccupationsCode500Response struct {
+}
+
+func (response PutApiAdminOccupationsCode500Response) VisitPutApiAdminOccupationsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminReportsOccupationDataRequestObject struct {
+}
+
+type GetApiAdminReportsOccupationDataResponseObject interface {
+	VisitGetApiAdminReportsOccupationDataResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminReportsOccupationData200JSONResponse OccupationDataReport
+
+func (response GetApiAdminReportsOccupationData200JSONResponse) VisitGetApiAdminReportsOccupationDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminReportsOccupationData401Response struct {
+}
+
+func (response GetApiAdminReportsOccupationData401Response) VisitGetApiAdminReportsOccupationDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminReportsOccupationData500Response struct {
+}
+
+func (response GetApiAdminReportsOccupationData500Response) VisitGetApiAdminReportsOccupationDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminReportsRetentionRequestObject struct {
+}
+
+type GetApiAdminReportsRetentionResponseObject interface {
+	VisitGetApiAdminReportsRetentionResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminReportsRetention200JSONResponse RetentionReport
+
+func (response GetApiAdminReportsRetention200JSONResponse) VisitGetApiAdminReportsRetentionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminReportsRetention401Response struct {
+}
+
+func (response GetApiAdminReportsRetention401Response) VisitGetApiAdminReportsRetentionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminReportsRetention500Response struct {
+}
+
+func (response GetApiAdminReportsRetention500Response) VisitGetApiAdminReportsRetentionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminSurveyDataRequestObject struct {
+	Params GetApiAdminSurveyDataParams
+}
+
+type GetApiAdminSurveyDataResponseObject interface {
+	VisitGetApiAdminSurveyDataResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminSurveyData200JSONResponse []SurveyData
+
+func (response GetApiAdminSurveyData200JSONResponse) VisitGetApiAdminSurveyDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminSurveyData400Response struct {
+}
+
+func (response GetApiAdminSurveyData400Response) VisitGetApiAdminSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminSurveyData401Response struct {
+}
+
+func (response GetApiAdminSurveyData401Response) VisitGetApiAdminSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminSurveyData500Response struct {
+}
+
+func (response GetApiAdminSurveyData500Response) VisitGetApiAdminSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiAdminSurveyDataIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetApiAdminSurveyDataIdResponseObject interface {
+	VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiAdminSurveyDataId200JSONResponse SurveyData
+
+func (response GetApiAdminSurveyDataId200JSONResponse) VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminSurveyDataId400Response struct {
+}
+
+func (response GetApiAdminSurveyDataId400Response) VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiAdminSurveyDataId401Response struct {
+}
+
+func (response GetApiAdminSurveyDataId401Response) VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiAdminSurveyDataId404JSONResponse Error
+
+func (response GetApiAdminSurveyDataId404JSONResponse) VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiAdminSurveyDataId500Response struct {
+}
+
+func (response GetApiAdminSurveyDataId500Response) VisitGetApiAdminSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type DeleteApiAdminUsersAccountIdDataRequestObject struct {
+	AccountId string `json:"account_id"`
+}
+
+type DeleteApiAdminUsersAccountIdDataResponseObject interface {
+	VisitDeleteApiAdminUsersAccountIdDataResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiAdminUsersAccountIdData200JSONResponse UserDataDeletion
+
+func (response DeleteApiAdminUsersAccountIdData200JSONResponse) VisitDeleteApiAdminUsersAccountIdDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiAdminUsersAccountIdData400Response struct {
+}
+
+func (response DeleteApiAdminUsersAccountIdData400Response) VisitDeleteApiAdminUsersAccountIdDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteApiAdminUsersAccountIdData401Response struct {
+}
+
+func (response DeleteApiAdminUsersAccountIdData401Response) VisitDeleteApiAdminUsersAccountIdDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiAdminUsersAccountIdData500Response struct {
+}
+
+func (response DeleteApiAdminUsersAccountIdData500Response) VisitDeleteApiAdminUsersAccountIdDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PostApiAdminUsersAccountIdMatchingRequestObject struct {
+	AccountId string `json:"account_id"`
+}
+
+type PostApiAdminUsersAccountIdMatchingResponseObject interface {
+	VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error
+}
+
+type PostApiAdminUsersAccountIdMatching202JSONResponse SurveyData
+
+func (response PostApiAdminUsersAccountIdMatching202JSONResponse) VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiAdminUsersAccountIdMatching400Response struct {
+}
+
+func (response PostApiAdminUsersAccountIdMatching400Response) VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiAdminUsersAccountIdMatching401Response struct {
+}
+
+func (response PostApiAdminUsersAccountIdMatching401Response) VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiAdminUsersAccountIdMatching404JSONResponse Error
+
+func (response PostApiAdminUsersAccountIdMatching404JSONResponse) VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiAdminUsersAccountIdMatching500Response struct {
+}
+
+func (response PostApiAdminUsersAccountIdMatching500Response) VisitPostApiAdminUsersAccountIdMatchingResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiCrosswalksRequestObject struct {
+}
+
+type GetApiCrosswalksResponseObject interface {
+	VisitGetApiCrosswalksResponse(w http.ResponseWriter) error
+}
+
+type GetApiCrosswalks200JSONResponse []string
+
+func (response GetApiCrosswalks200JSONResponse) VisitGetApiCrosswalksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiCrosswalks401Response struct {
+}
+
+func (response GetApiCrosswalks401Response) VisitGetApiCrosswalksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiCrosswalks500Response struct {
+}
+
+func (response GetApiCrosswalks500Response) VisitGetApiCrosswalksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiCrosswalksSystemOccupationsRequestObject struct {
+	System GetApiCrosswalksSystemOccupationsParamsSystem `json:"system"`
+	Params GetApiCrosswalksSystemOccupationsParams
+}
+
+type GetApiCrosswalksSystemOccupationsResponseObject interface {
+	VisitGetApiCrosswalksSystemOccupationsResponse(w http.ResponseWriter) error
+}
+
+type GetApiCrosswalksSystemOccupations200JSONResponse []OccupationMatch
+
+func (response GetApiCrosswalksSystemOccupations200JSONResponse) VisitGetApiCrosswalksSystemOccupationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiCrosswalksSystemOccupations400Response struct {
+}
+
+func (response GetApiCrosswalksSystemOccupations400Response) VisitGetApiCrosswalksSystemOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiCrosswalksSystemOccupations401Response struct {
+}
+
+func (response GetApiCrosswalksSystemOccupations401Response) VisitGetApiCrosswalksSystemOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiCrosswalksSystemOccupations500Response struct {
+}
+
+func (response GetApiCrosswalksSystemOccupations500Response) VisitGetApiCrosswalksSystemOccupationsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiMatchingEventsRequestObject struct {
+}
+
+type GetApiMatchingEventsResponseObject interface {
+	VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error
+}
+
+type GetApiMatchingEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetApiMatchingEvents200TexteventStreamResponse) VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiMatchingEvents401Response struct {
+}
+
+func (response GetApiMatchingEvents401Response) VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiMatchingEvents500Response struct {
+}
+
+func (response GetApiMatchingEvents500Response) VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiMatchingEvents503Response struct {
+}
+
+func (response GetApiMatchingEvents503Response) VisitGetApiMatchingEventsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(503)
+	return nil
+}
+
+type GetApiOccupationRequestObject struct {
+}
+
+type GetApiOccupationResponseObject interface {
+	VisitGetApiOccupationResponse(w http.ResponseWriter) error
+}
+
+type GetApiOccupation200JSONResponse []OccupationData
+
+func (response GetApiOccupation200JSONResponse) VisitGetApiOccupationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupation400Response struct {
+}
+
+func (response GetApiOccupation400Response) VisitGetApiOccupationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiOccupation401Response struct {
+}
+
+func (response GetApiOccupation401Response) VisitGetApiOccupationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiOccupation500Response struct {
+}
+
+func (response GetApiOccupation500Response) VisitGetApiOccupationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiOccupationPathwaysRequestObject struct {
+	Params GetApiOccupationPathwaysParams
+}
+
+type GetApiOccupationPathwaysResponseObject interface {
+	VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error
+}
+
+type GetApiOccupationPathways200JSONResponse []OccupationPathway
+
+func (response GetApiOccupationPathways200JSONResponse) VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationPathways400Response struct {
+}
+
+func (response GetApiOccupationPathways400Response) VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiOccupationPathways401Response struct {
+}
+
+func (response GetApiOccupationPathways401Response) VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiOccupationPathways404JSONResponse Error
+
+func (response GetApiOccupationPathways404JSONResponse) VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationPathways500Response struct {
+}
+
+func (response GetApiOccupationPathways500Response) VisitGetApiOccupationPathwaysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiOccupationCodeRequestObject struct {
+	Code string `json:"code"`
+}
+
+type GetApiOccupationCodeResponseObject interface {
+	VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error
+}
+
+type GetApiOccupationCode200JSONResponse OccupationData
+
+func (response GetApiOccupationCode200JSONResponse) VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCode400Response struct {
+}
+
+func (response GetApiOccupationCode400Response) VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiOccupationCode401Response struct {
+}
+
+func (response GetApiOccupationCode401Response) VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiOccupationCode404JSONResponse Error
+
+func (response GetApiOccupationCode404JSONResponse) VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCode500Response struct {
+}
+
+func (response GetApiOccupationCode500Response) VisitGetApiOccupationCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiOccupationCodeCrosswalksRequestObject struct {
+	Code   string `json:"code"`
+	Params GetApiOccupationCodeCrosswalksParams
+}
+
+type GetApiOccupationCodeCrosswalksResponseObject interface {
+	VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error
+}
+
+type GetApiOccupationCodeCrosswalks200JSONResponse []CrosswalkCode
+
+func (response GetApiOccupationCodeCrosswalks200JSONResponse) VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCodeCrosswalks400Response struct {
+}
+
+func (response GetApiOccupationCodeCrosswalks400Response) VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiOccupationCodeCrosswalks401Response struct {
+}
+
+func (response GetApiOccupationCodeCrosswalks401Response) VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiOccupationCodeCrosswalks404JSONResponse Error
+
+func (response GetApiOccupationCodeCrosswalks404JSONResponse) VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCodeCrosswalks500Response struct {
+}
+
+func (response GetApiOccupationCodeCrosswalks500Response) VisitGetApiOccupationCodeCrosswalksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiOccupationCodeRelatedRequestObject struct {
+	Code   string `json:"code"`
+	Params GetApiOccupationCodeRelatedParams
+}
+
+type GetApiOccupationCodeRelatedResponseObject interface {
+	VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error
+}
+
+type GetApiOccupationCodeRelated200JSONResponse []OccupationNeighbor
+
+func (response GetApiOccupationCodeRelated200JSONResponse) VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCodeRelated400Response struct {
+}
+
+func (response GetApiOccupationCodeRelated400Response) VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiOccupationCodeRelated401Response struct {
+}
+
+func (response GetApiOccupationCodeRelated401Response) VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiOccupationCodeRelated404JSONResponse Error
+
+func (response GetApiOccupationCodeRelated404JSONResponse) VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiOccupationCodeRelated500Response struct {
+}
+
+func (response GetApiOccupationCodeRelated500Response) VisitGetApiOccupationCodeRelatedResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PostApiSurveyDataRequestObject struct {
+	Params PostApiSurveyDataParams
+	Body   *PostApiSurveyDataJSONRequestBody
+}
+
+type PostApiSurveyDataResponseObject interface {
+	VisitPostApiSurveyDataResponse(w http.ResponseWriter) error
+}
+
+type PostApiSurveyData200JSONResponse SurveyData
+
+func (response PostApiSurveyData200JSONResponse) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiSurveyData400Response struct {
+}
+
+func (response PostApiSurveyData400Response) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiSurveyData401Response struct {
+}
+
+func (response PostApiSurveyData401Response) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiSurveyData409JSONResponse Error
+
+func (response PostApiSurveyData409JSONResponse) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiSurveyData422JSONResponse ValidationError
+
+func (response PostApiSurveyData422JSONResponse) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiSurveyData429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostApiSurveyData429JSONResponse struct {
+	Body    Error
+	Headers PostApiSurveyData429ResponseHeaders
+}
+
+func (response PostApiSurveyData429JSONResponse) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostApiSurveyData500Response struct {
+}
+
+func (response PostApiSurveyData500Response) VisitPostApiSurveyDataResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type DeleteApiSurveyDataIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteApiSurveyDataIdResponseObject interface {
+	VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiSurveyDataId200Response struct {
+}
+
+func (response DeleteApiSurveyDataId200Response) VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeleteApiSurveyDataId400Response struct {
+}
+
+func (response DeleteApiSurveyDataId400Response) VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteApiSurveyDataId401Response struct {
+}
+
+func (response DeleteApiSurveyDataId401Response) VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiSurveyDataId404JSONResponse Error
+
+func (response DeleteApiSurveyDataId404JSONResponse) VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiSurveyDataId500Response struct {
+}
+
+func (response DeleteApiSurveyDataId500Response) VisitDeleteApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiSurveyDataIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetApiSurveyDataIdResponseObject interface {
+	VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiSurveyDataId200ResponseHeaders struct {
+	ETag string
+}
+
+type GetApiSurveyDataId200JSONResponse struct {
+	Body    SurveyData
+	Headers GetApiSurveyDataId200ResponseHeaders
+}
+
+func (response GetApiSurveyDataId200JSONResponse) VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiSurveyDataId400Response struct {
+}
+
+func (response GetApiSurveyDataId400Response) VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiSurveyDataId401Response struct {
+}
+
+func (response GetApiSurveyDataId401Response) VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiSurveyDataId404JSONResponse Error
+
+func (response GetApiSurveyDataId404JSONResponse) VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiSurveyDataId500Response struct {
+}
+
+func (response GetApiSurveyDataId500Response) VisitGetApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type PutApiSurveyDataIdRequestObject struct {
+	Id     string `json:"id"`
+	Params PutApiSurveyDataIdParams
+	Body   *PutApiSurveyDataIdJSONRequestBody
+}
+
+type PutApiSurveyDataIdResponseObject interface {
+	VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error
+}
+
+type PutApiSurveyDataId200Response struct {
+}
+
+func (response PutApiSurveyDataId200Response) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PutApiSurveyDataId400Response struct {
+}
+
+func (response PutApiSurveyDataId400Response) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutApiSurveyDataId401Response struct {
+}
+
+func (response PutApiSurveyDataId401Response) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutApiSurveyDataId404JSONResponse Error
+
+func (response PutApiSurveyDataId404JSONResponse) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiSurveyDataId412Response struct {
+}
+
+func (response PutApiSurveyDataId412Response) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type PutApiSurveyDataId422JSONResponse ValidationError
+
+func (response PutApiSurveyDataId422JSONResponse) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutApiSurveyDataId500Response struct {
+}
+
+func (response PutApiSurveyDataId500Response) VisitPutApiSurveyDataIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiUserDataExportRequestObject struct {
+	Params GetApiUserDataExportParams
+}
+
+type GetApiUserDataExportResponseObject interface {
+	VisitGetApiUserDataExportResponse(w http.ResponseWriter) error
+}
+
+type GetApiUserDataExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetApiUserDataExport200JSONResponse struct {
+	Body    UserDataArchive
+	Headers GetApiUserDataExport200ResponseHeaders
+}
+
+func (response GetApiUserDataExport200JSONResponse) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiUserDataExport200ApplicationzipResponse struct {
+	Body          io.Reader
+	Headers       GetApiUserDataExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetApiUserDataExport200ApplicationzipResponse) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/zip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiUserDataExport202ResponseHeaders struct {
+	Location string
+}
+
+type GetApiUserDataExport202JSONResponse struct {
+	Body    UserDataExport
+	Headers GetApiUserDataExport202ResponseHeaders
+}
+
+func (response GetApiUserDataExport202JSONResponse) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiUserDataExport400Response struct {
+}
+
+func (response GetApiUserDataExport400Response) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiUserDataExport401Response struct {
+}
+
+func (response GetApiUserDataExport401Response) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiUserDataExport500Response struct {
+}
+
+func (response GetApiUserDataExport500Response) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiUserDataExport503Response struct {
+}
+
+func (response GetApiUserDataExport503Response) VisitGetApiUserDataExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(503)
+	return nil
+}
+
+type GetApiUserDataExportsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetApiUserDataExportsIdResponseObject interface {
+	VisitGetApiUserDataExportsIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiUserDataExportsId200JSONResponse UserDataExport
+
+func (response GetApiUserDataExportsId200JSONResponse) VisitGetApiUserDataExportsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiUserDataExportsId401Response struct {
+}
+
+func (response GetApiUserDataExportsId401Response) VisitGetApiUserDataExportsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiUserDataExportsId404JSONResponse Error
+
+func (response GetApiUserDataExportsId404JSONResponse) VisitGetApiUserDataExportsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiUserDataExportsId500Response struct {
+}
+
+func (response GetApiUserDataExportsId500Response) VisitGetApiUserDataExportsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiUserDataExportsIdDownloadRequestObject struct {
+	Id     string `json:"id"`
+	Params GetApiUserDataExportsIdDownloadParams
+}
+
+type GetApiUserDataExportsIdDownloadResponseObject interface {
+	VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error
+}
+
+type GetApiUserDataExportsIdDownload200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetApiUserDataExportsIdDownload200JSONResponse struct {
+	Body    UserDataArchive
+	Headers GetApiUserDataExportsIdDownload200ResponseHeaders
+}
+
+func (response GetApiUserDataExportsIdDownload200JSONResponse) VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiUserDataExportsIdDownload200ApplicationzipResponse struct {
+	Body          io.Reader
+	Headers       GetApiUserDataExportsIdDownload200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetApiUserDataExportsIdDownload200ApplicationzipResponse) VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/zip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetApiUserDataExportsIdDownload404JSONResponse Error
+
+func (response GetApiUserDataExportsIdDownload404JSONResponse) VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiUserDataExportsIdDownload500Response struct {
+}
+
+func (response GetApiUserDataExportsIdDownload500Response) VisitGetApiUserDataExportsIdDownloadResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type DeleteApiUserMatchResultsRequestObject struct {
+}
+
+type DeleteApiUserMatchResultsResponseObject interface {
+	VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiUserMatchResults200Response struct {
+}
+
+func (response DeleteApiUserMatchResults200Response) VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeleteApiUserMatchResults400Response struct {
+}
+
+func (response DeleteApiUserMatchResults400Response) VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteApiUserMatchResults401Response struct {
+}
+
+func (response DeleteApiUserMatchResults401Response) VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiUserMatchResults404JSONResponse Error
+
+func (response DeleteApiUserMatchResults404JSONResponse) VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiUserMatchResults500Response struct {
+}
+
+func (response DeleteApiUserMatchResults500Response) VisitDeleteApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetApiUserMatchResultsRequestObject struct {
+	Params GetApiUserMatchResultsParams
+}
+
+type GetApiUserMatchResultsResponseObject interface {
+	VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error
+}
+
+type GetApiUserMatchResults200ResponseHeaders struct {
+	ETag string
+}
+
+type GetApiUserMatchResults200JSONResponse struct {
+	Body    UserMatchingResult
+	Headers GetApiUserMatchResults200ResponseHeaders
+}
+
+func (response GetApiUserMatchResults200JSONResponse) VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiUserMatchResults400Response struct {
+}
+
+func (response GetApiUserMatchResults400Response) VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetApiUserMatchResults401Response struct {
+}
+
+func (response GetApiUserMatchResults401Response) VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiUserMatchResults404JSONResponse Error
+
+func (response GetApiUserMatchResults404JSONResponse) VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiUserMatchResults500Response struct {
+}
+
+func (response GetApiUserMatchResults500Response) VisitGetApiUserMatchResultsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse Health
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLive500Response struct {
+}
+
+func (response GetHealthLive500Response) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse Health
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady500Response struct {
+}
+
+func (response GetHealthReady500Response) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+type GetHealthReady503JSONResponse Health
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVersionRequestObject struct {
+}
+
+type GetVersionResponseObject interface {
+	VisitGetVersionResponse(w http.ResponseWriter) error
+}
+
+type GetVersion200TextResponse string
+
+func (response GetVersion200TextResponse) VisitGetVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type GetVersion500Response struct {
+}
+
+func (response GetVersion500Response) VisitGetVersionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(500)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get configs
+	// (GET /api/admin/configs)
+	GetApiAdminConfigs(ctx context.Context, request GetApiAdminConfigsRequestObject) (GetApiAdminConfigsResponseObject, error)
+	// Create config
+	// (POST /api/admin/configs)
+	PostApiAdminConfigs(ctx context.Context, request PostApiAdminConfigsRequestObject) (PostApiAdminConfigsResponseObject, error)
+	// Delete config
+	// (DELETE /api/admin/configs/{id})
+	DeleteApiAdminConfigsId(ctx context.Context, request DeleteApiAdminConfigsIdRequestObject) (DeleteApiAdminConfigsIdResponseObject, error)
+	// Get config
+	// (GET /api/admin/configs/{id})
+	GetApiAdminConfigsId(ctx context.Context, request GetApiAdminConfigsIdRequestObject) (GetApiAdminConfigsIdResponseObject, error)
+	// Update config
+	// (PUT /api/admin/configs/{id})
+	PutApiAdminConfigsId(ctx context.Context, request PutApiAdminConfigsIdRequestObject) (PutApiAdminConfigsIdResponseObject, error)
+	// Search match results
+	// (GET /api/admin/match-results)
+	GetApiAdminMatchResults(ctx context.Context, request GetApiAdminMatchResultsRequestObject) (GetApiAdminMatchResultsResponseObject, error)
+	// Get match result
+	// (GET /api/admin/match-results/{account_id})
+	GetApiAdminMatchResultsAccountId(ctx context.Context, request GetApiAdminMatchResultsAccountIdRequestObject) (GetApiAdminMatchResultsAccountIdResponseObject, error)
+	// Get occupations
+	// (GET /api/admin/occupations)
+	GetApiAdminOccupations(ctx context.Context, request GetApiAdminOccupationsRequestObject) (GetApiAdminOccupationsResponseObject, error)
+	// Create occupation
+	// (POST /api/admin/occupations)
+	PostApiAdminOccupations(ctx context.Context, request PostApiAdminOccupationsRequestObject) (PostApiAdminOccupationsResponseObject, error)
+	// Delete occupation
+	// (DELETE /api/admin/occupations/{code})
+	DeleteApiAdminOccupationsCode(ctx context.Context, request DeleteApiAdminOccupationsCodeRequestObject) (DeleteApiAdminOccupationsCodeResponseObject, error)
+	// Get occupation
+	// (GET /api/admin/occupations/{code})
+	GetApiAdminOccupationsCode(ctx context.Context, request GetApiAdminOccupationsCodeRequestObject) (GetApiAdminOccupationsCodeResponseObject, error)
+	// Update occupation
+	// (PUT /api/admin/occupations/{code})
+	PutApiAdminOccupationsCode(ctx context.Context, request PutApiAdminOccupationsCodeRequestObject) (PutApiAdminOccupationsCodeResponseObject, error)
+	// Get occupation data report
+	// (GET /api/admin/reports/occupation-data)
+	GetApiAdminReportsOccupationData(ctx context.Context, request GetApiAdminReportsOccupationDataRequestObject) (GetApiAdminReportsOccupationDataResponseObject, error)
+	// Get retention report
+	// (GET /api/admin/reports/retention)
+	GetApiAdminReportsRetention(ctx context.Context, request GetApiAdminReportsRetentionRequestObject) (GetApiAdminReportsRetentionResponseObject, error)
+	// Search survey data
+	// (GET /api/admin/survey-data)
+	GetApiAdminSurveyData(ctx context.Context, request GetApiAdminSurveyDataRequestObject) (GetApiAdminSurveyDataResponseObject, error)
+	// Get survey data
+	// (GET /api/admin/survey-data/{id})
+	GetApiAdminSurveyDataId(ctx context.Context, request GetApiAdminSurveyDataIdRequestObject) (GetApiAdminSurveyDataIdResponseObject, error)
+	// Delete user data
+	// (DELETE /api/admin/users/{account_id}/data)
+	DeleteApiAdminUsersAccountIdData(ctx context.Context, request DeleteApiAdminUsersAccountIdDataRequestObject) (DeleteApiAdminUsersAccountIdDataResponseObject, error)
+	// Match user occupations
+	// (POST /api/admin/users/{account_id}/matching)
+	PostApiAdminUsersAccountIdMatching(ctx context.Context, request PostApiAdminUsersAccountIdMatchingRequestObject) (PostApiAdminUsersAccountIdMatchingResponseObject, error)
+	// Gets crosswalk systems
+	// (GET /api/crosswalks)
+	GetApiCrosswalks(ctx context.Context, request GetApiCrosswalksRequestObject) (GetApiCrosswalksResponseObject, error)
+	// Gets crosswalk occupations
+	// (GET /api/crosswalks/{system}/occupations)
+	GetApiCrosswalksSystemOccupations(ctx context.Context, request GetApiCrosswalksSystemOccupationsRequestObject) (GetApiCrosswalksSystemOccupationsResponseObject, error)
+	// Streams the occupation matching events of the user
+	// (GET /api/matching-events)
+	GetApiMatchingEvents(ctx context.Context, request GetApiMatchingEventsRequestObject) (GetApiMatchingEventsResponseObject, error)
+	// Gets all Occupation data
+	// (GET /api/occupation)
+	GetApiOccupation(ctx context.Context, request GetApiOccupationRequestObject) (GetApiOccupationResponseObject, error)
+	// Gets occupation pathways
+	// (GET /api/occupation-pathways)
+	GetApiOccupationPathways(ctx context.Context, request GetApiOccupationPathwaysRequestObject) (GetApiOccupationPathwaysResponseObject, error)
+	// Gets Occupation data
+	// (GET /api/occupation/{code})
+	GetApiOccupationCode(ctx context.Context, request GetApiOccupationCodeRequestObject) (GetApiOccupationCodeResponseObject, error)
+	// Gets occupation crosswalks
+	// (GET /api/occupation/{code}/crosswalks)
+	GetApiOccupationCodeCrosswalks(ctx context.Context, request GetApiOccupationCodeCrosswalksRequestObject) (GetApiOccupationCodeCrosswalksResponseObject, error)
+	// Gets related occupations
+	// (GET /api/occupation/{code}/related)
+	GetApiOccupationCodeRelated(ctx context.Context, request GetApiOccupationCodeRelatedRequestObject) (GetApiOccupationCodeRelatedResponseObject, error)
+	// Posts the Survey data
+	// (POST /api/survey-data)
+	PostApiSurveyData(ctx context.Context, request PostApiSurveyDataRequestObject) (PostApiSurveyDataResponseObject, error)
+	// Deletes Survey data
+	// (DELETE /api/survey-data/{id})
+	DeleteApiSurveyDataId(ctx context.Context, request DeleteApiSurveyDataIdRequestObject) (DeleteApiSurveyDataIdResponseObject, error)
+	// Gets Survey data
+	// (GET /api/survey-data/{id})
+	GetApiSurveyDataId(ctx context.Context, request GetApiSurveyDataIdRequestObject) (GetApiSurveyDataIdResponseObject, error)
+	// Updates Survey data
+	// (PUT /api/survey-data/{id})
+	PutApiSurveyDataId(ctx context.Context, request PutApiSurveyDataIdRequestObject) (PutApiSurveyDataIdResponseObject, error)
+	// Exports all the data of the user
+	// (GET /api/user-data/export)
+	GetApiUserDataExport(ctx context.Context, request GetApiUserDataExportRequestObject) (GetApiUserDataExportResponseObject, error)
+	// Gets the status of a user data export
+	// (GET /api/user-data/exports/{id})
+	GetApiUserDataExportsId(ctx context.Context, request GetApiUserDataExportsIdRequestObject) (GetApiUserDataExportsIdResponseObject, error)
+	// Downloads a user data export
+	// (GET /api/user-data/exports/{id}/download)
+	GetApiUserDataExportsIdDownload(ctx context.Context, request GetApiUserDataExportsIdDownloadRequestObject) (GetApiUserDataExportsIdDownloadResponseObject, error)
+	// Deletes User data
+	// (DELETE /api/user-match-results)
+	DeleteApiUserMatchResults(ctx context.Context, request DeleteApiUserMatchResultsRequestObject) (DeleteApiUserMatchResultsResponseObject, error)
+	// Gets User data
+	// (GET /api/user-match-results)
+	GetApiUserMatchResults(ctx context.Context, request GetApiUserMatchResultsRequestObject) (GetApiUserMatchResultsResponseObject, error)
+	// Get liveness
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Get readiness
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+	// Get version
+	// (GET /version)
+	GetVersion(ctx context.Context, request GetVersionRequestObject) (GetVersionResponseObject, error)
+}
+
+type StrictHandlerFunc = runtime.StrictHttpHandlerFunc
+type StrictMiddlewareFunc = runtime.StrictHttpMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetApiAdminConfigs operation middleware
+func (sh *strictHandler) GetApiAdminConfigs(w http.ResponseWriter, r *http.Request, params GetApiAdminConfigsParams) {
+	var request GetApiAdminConfigsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminConfigs(ctx, request.(GetApiAdminConfigsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminConfigs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminConfigsResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminConfigsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PostApiAdminConfigs operation middleware
+func (sh *strictHandler) PostApiAdminConfigs(w http.ResponseWriter, r *http.Request) {
+	var request PostApiAdminConfigsRequestObject
+
+	var body PostApiAdminConfigsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiAdminConfigs(ctx, request.(PostApiAdminConfigsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiAdminConfigs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiAdminConfigsResponseObject); ok {
+		if err := validResponse.VisitPostApiAdminConfigsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiAdminConfigsId operation middleware
+func (sh *strictHandler) DeleteApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string) {
+	var request DeleteApiAdminConfigsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiAdminConfigsId(ctx, request.(DeleteApiAdminConfigsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiAdminConfigsId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiAdminConfigsIdResponseObject); ok {
+		if err := validResponse.VisitDeleteApiAdminConfigsIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminConfigsId operation middleware
+func (sh *strictHandler) GetApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetApiAdminConfigsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminConfigsId(ctx, request.(GetApiAdminConfigsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminConfigsId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminConfigsIdResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminConfigsIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PutApiAdminConfigsId operation middleware
+func (sh *strictHandler) PutApiAdminConfigsId(w http.ResponseWriter, r *http.Request, id string, params PutApiAdminConfigsIdParams) {
+	var request PutApiAdminConfigsIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PutApiAdminConfigsIdJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutApiAdminConfigsId(ctx, request.(PutApiAdminConfigsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutApiAdminConfigsId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutApiAdminConfigsIdResponseObject); ok {
+		if err := validResponse.VisitPutApiAdminConfigsIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminMatchResults operation middleware
+func (sh *strictHandler) GetApiAdminMatchResults(w http.ResponseWriter, r *http.Request, params GetApiAdminMatchResultsParams) {
+	var request GetApiAdminMatchResultsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminMatchResults(ctx, request.(GetApiAdminMatchResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminMatchResults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminMatchResultsResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminMatchResultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminMatchResultsAccountId operation middleware
+func (sh *strictHandler) GetApiAdminMatchResultsAccountId(w http.ResponseWriter, r *http.Request, accountId string) {
+	var request GetApiAdminMatchResultsAccountIdRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminMatchResultsAccountId(ctx, request.(GetApiAdminMatchResultsAccountIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminMatchResultsAccountId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminMatchResultsAccountIdResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminMatchResultsAccountIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminOccupations operation middleware
+func (sh *strictHandler) GetApiAdminOccupations(w http.ResponseWriter, r *http.Request) {
+	var request GetApiAdminOccupationsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminOccupations(ctx, request.(GetApiAdminOccupationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminOccupations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminOccupationsResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminOccupationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PostApiAdminOccupations operation middleware
+func (sh *strictHandler) PostApiAdminOccupations(w http.ResponseWriter, r *http.Request) {
+	var request PostApiAdminOccupationsRequestObject
+
+	var body PostApiAdminOccupationsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiAdminOccupations(ctx, request.(PostApiAdminOccupationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiAdminOccupations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiAdminOccupationsResponseObject); ok {
+		if err := validResponse.VisitPostApiAdminOccupationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiAdminOccupationsCode operation middleware
+func (sh *strictHandler) DeleteApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string) {
+	var request DeleteApiAdminOccupationsCodeRequestObject
+
+	request.Code = code
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiAdminOccupationsCode(ctx, request.(DeleteApiAdminOccupationsCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiAdminOccupationsCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiAdminOccupationsCodeResponseObject); ok {
+		if err := validResponse.VisitDeleteApiAdminOccupationsCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminOccupationsCode operation middleware
+func (sh *strictHandler) GetApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string) {
+	var request GetApiAdminOccupationsCodeRequestObject
+
+	request.Code = code
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminOccupationsCode(ctx, request.(GetApiAdminOccupationsCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminOccupationsCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminOccupationsCodeResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminOccupationsCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PutApiAdminOccupationsCode operation middleware
+func (sh *strictHandler) PutApiAdminOccupationsCode(w http.ResponseWriter, r *http.Request, code string) {
+	var request PutApiAdminOccupationsCodeRequestObject
+
+	request.Code = code
+
+	var body PutApiAdminOccupationsCodeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutApiAdminOccupationsCode(ctx, request.(PutApiAdminOccupationsCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutApiAdminOccupationsCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutApiAdminOccupationsCodeResponseObject); ok {
+		if err := validResponse.VisitPutApiAdminOccupationsCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminReportsOccupationData operation middleware
+func (sh *strictHandler) GetApiAdminReportsOccupationData(w http.ResponseWriter, r *http.Request) {
+	var request GetApiAdminReportsOccupationDataRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminReportsOccupationData(ctx, request.(GetApiAdminReportsOccupationDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminReportsOccupationData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminReportsOccupationDataResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminReportsOccupationDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminReportsRetention operation middleware
+func (sh *strictHandler) GetApiAdminReportsRetention(w http.ResponseWriter, r *http.Request) {
+	var request GetApiAdminReportsRetentionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminReportsRetention(ctx, request.(GetApiAdminReportsRetentionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminReportsRetention")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminReportsRetentionResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminReportsRetentionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminSurveyData operation middleware
+func (sh *strictHandler) GetApiAdminSurveyData(w http.ResponseWriter, r *http.Request, params GetApiAdminSurveyDataParams) {
+	var request GetApiAdminSurveyDataRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminSurveyData(ctx, request.(GetApiAdminSurveyDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminSurveyData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminSurveyDataResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminSurveyDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiAdminSurveyDataId operation middleware
+func (sh *strictHandler) GetApiAdminSurveyDataId(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetApiAdminSurveyDataIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiAdminSurveyDataId(ctx, request.(GetApiAdminSurveyDataIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiAdminSurveyDataId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiAdminSurveyDataIdResponseObject); ok {
+		if err := validResponse.VisitGetApiAdminSurveyDataIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiAdminUsersAccountIdData operation middleware
+func (sh *strictHandler) DeleteApiAdminUsersAccountIdData(w http.ResponseWriter, r *http.Request, accountId string) {
+	var request DeleteApiAdminUsersAccountIdDataRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiAdminUsersAccountIdData(ctx, request.(DeleteApiAdminUsersAccountIdDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiAdminUsersAccountIdData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiAdminUsersAccountIdDataResponseObject); ok {
+		if err := validResponse.VisitDeleteApiAdminUsersAccountIdDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PostApiAdminUsersAccountIdMatching operation middleware
+func (sh *strictHandler) PostApiAdminUsersAccountIdMatching(w http.ResponseWriter, r *http.Request, accountId string) {
+	var request PostApiAdminUsersAccountIdMatchingRequestObject
+
+	request.AccountId = accountId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiAdminUsersAccountIdMatching(ctx, request.(PostApiAdminUsersAccountIdMatchingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiAdminUsersAccountIdMatching")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiAdminUsersAccountIdMatchingResponseObject); ok {
+		if err := validResponse.VisitPostApiAdminUsersAccountIdMatchingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiCrosswalks operation middleware
+func (sh *strictHandler) GetApiCrosswalks(w http.ResponseWriter, r *http.Request) {
+	var request GetApiCrosswalksRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiCrosswalks(ctx, request.(GetApiCrosswalksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiCrosswalks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiCrosswalksResponseObject); ok {
+		if err := validResponse.VisitGetApiCrosswalksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiCrosswalksSystemOccupations operation middleware
+func (sh *strictHandler) GetApiCrosswalksSystemOccupations(w http.ResponseWriter, r *http.Request, system GetApiCrosswalksSystemOccupationsParamsSystem, params GetApiCrosswalksSystemOccupationsParams) {
+	var request GetApiCrosswalksSystemOccupationsRequestObject
+
+	request.System = system
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiCrosswalksSystemOccupations(ctx, request.(GetApiCrosswalksSystemOccupationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiCrosswalksSystemOccupations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiCrosswalksSystemOccupationsResponseObject); ok {
+		if err := validResponse.VisitGetApiCrosswalksSystemOccupationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiMatchingEvents operation middleware
+func (sh *strictHandler) GetApiMatchingEvents(w http.ResponseWriter, r *http.Request) {
+	var request GetApiMatchingEventsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiMatchingEvents(ctx, request.(GetApiMatchingEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiMatchingEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiMatchingEventsResponseObject); ok {
+		if err := validResponse.VisitGetApiMatchingEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiOccupation operation middleware
+func (sh *strictHandler) GetApiOccupation(w http.ResponseWriter, r *http.Request) {
+	var request GetApiOccupationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiOccupation(ctx, request.(GetApiOccupationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiOccupation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiOccupationResponseObject); ok {
+		if err := validResponse.VisitGetApiOccupationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiOccupationPathways operation middleware
+func (sh *strictHandler) GetApiOccupationPathways(w http.ResponseWriter, r *http.Request, params GetApiOccupationPathwaysParams) {
+	var request GetApiOccupationPathwaysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiOccupationPathways(ctx, request.(GetApiOccupationPathwaysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiOccupationPathways")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiOccupationPathwaysResponseObject); ok {
+		if err := validResponse.VisitGetApiOccupationPathwaysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiOccupationCode operation middleware
+func (sh *strictHandler) GetApiOccupationCode(w http.ResponseWriter, r *http.Request, code string) {
+	var request GetApiOccupationCodeRequestObject
+
+	request.Code = code
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiOccupationCode(ctx, request.(GetApiOccupationCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiOccupationCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiOccupationCodeResponseObject); ok {
+		if err := validResponse.VisitGetApiOccupationCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiOccupationCodeCrosswalks operation middleware
+func (sh *strictHandler) GetApiOccupationCodeCrosswalks(w http.ResponseWriter, r *http.Request, code string, params GetApiOccupationCodeCrosswalksParams) {
+	var request GetApiOccupationCodeCrosswalksRequestObject
+
+	request.Code = code
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiOccupationCodeCrosswalks(ctx, request.(GetApiOccupationCodeCrosswalksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiOccupationCodeCrosswalks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiOccupationCodeCrosswalksResponseObject); ok {
+		if err := validResponse.VisitGetApiOccupationCodeCrosswalksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiOccupationCodeRelated operation middleware
+func (sh *strictHandler) GetApiOccupationCodeRelated(w http.ResponseWriter, r *http.Request, code string, params GetApiOccupationCodeRelatedParams) {
+	var request GetApiOccupationCodeRelatedRequestObject
+
+	request.Code = code
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiOccupationCodeRelated(ctx, request.(GetApiOccupationCodeRelatedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiOccupationCodeRelated")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiOccupationCodeRelatedResponseObject); ok {
+		if err := validResponse.VisitGetApiOccupationCodeRelatedResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PostApiSurveyData operation middleware
+func (sh *strictHandler) PostApiSurveyData(w http.ResponseWriter, r *http.Request, params PostApiSurveyDataParams) {
+	var request PostApiSurveyDataRequestObject
+
+	request.Params = params
+
+	var body PostApiSurveyDataJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiSurveyData(ctx, request.(PostApiSurveyDataRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiSurveyData")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiSurveyDataResponseObject); ok {
+		if err := validResponse.VisitPostApiSurveyDataResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiSurveyDataId operation middleware
+func (sh *strictHandler) DeleteApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string) {
+	var request DeleteApiSurveyDataIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiSurveyDataId(ctx, request.(DeleteApiSurveyDataIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiSurveyDataId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiSurveyDataIdResponseObject); ok {
+		if err := validResponse.VisitDeleteApiSurveyDataIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiSurveyDataId operation middleware
+func (sh *strictHandler) GetApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetApiSurveyDataIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiSurveyDataId(ctx, request.(GetApiSurveyDataIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiSurveyDataId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiSurveyDataIdResponseObject); ok {
+		if err := validResponse.VisitGetApiSurveyDataIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// PutApiSurveyDataId operation middleware
+func (sh *strictHandler) PutApiSurveyDataId(w http.ResponseWriter, r *http.Request, id string, params PutApiSurveyDataIdParams) {
+	var request PutApiSurveyDataIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PutApiSurveyDataIdJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutApiSurveyDataId(ctx, request.(PutApiSurveyDataIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutApiSurveyDataId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutApiSurveyDataIdResponseObject); ok {
+		if err := validResponse.VisitPutApiSurveyDataIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiUserDataExport operation middleware
+func (sh *strictHandler) GetApiUserDataExport(w http.ResponseWriter, r *http.Request, params GetApiUserDataExportParams) {
+	var request GetApiUserDataExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiUserDataExport(ctx, request.(GetApiUserDataExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiUserDataExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiUserDataExportResponseObject); ok {
+		if err := validResponse.VisitGetApiUserDataExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiUserDataExportsId operation middleware
+func (sh *strictHandler) GetApiUserDataExportsId(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetApiUserDataExportsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiUserDataExportsId(ctx, request.(GetApiUserDataExportsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiUserDataExportsId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiUserDataExportsIdResponseObject); ok {
+		if err := validResponse.VisitGetApiUserDataExportsIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiUserDataExportsIdDownload operation middleware
+func (sh *strictHandler) GetApiUserDataExportsIdDownload(w http.ResponseWriter, r *http.Request, id string, params GetApiUserDataExportsIdDownloadParams) {
+	var request GetApiUserDataExportsIdDownloadRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiUserDataExportsIdDownload(ctx, request.(GetApiUserDataExportsIdDownloadRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiUserDataExportsIdDownload")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiUserDataExportsIdDownloadResponseObject); ok {
+		if err := validResponse.VisitGetApiUserDataExportsIdDownloadResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiUserMatchResults operation middleware
+func (sh *strictHandler) DeleteApiUserMatchResults(w http.ResponseWriter, r *http.Request) {
+	var request DeleteApiUserMatchResultsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiUserMatchResults(ctx, request.(DeleteApiUserMatchResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiUserMatchResults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiUserMatchResultsResponseObject); ok {
+		if err := validResponse.VisitDeleteApiUserMatchResultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetApiUserMatchResults operation middleware
+func (sh *strictHandler) GetApiUserMatchResults(w http.ResponseWriter, r *http.Request, params GetApiUserMatchResultsParams) {
+	var request GetApiUserMatchResultsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiUserMatchResults(ctx, request.(GetApiUserMatchResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiUserMatchResults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiUserMatchResultsResponseObject); ok {
+		if err := validResponse.VisitGetApiUserMatchResultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx, request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		if err := validResponse.VisitGetHealthLiveResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx, request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		if err := validResponse.VisitGetHealthReadyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetVersion operation middleware
+func (sh *strictHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	var request GetVersionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVersion(ctx, request.(GetVersionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVersion")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVersionResponseObject); ok {
+		if err := validResponse.VisitGetVersionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/utils"
+	"bufio"
+	"compress/gzip"
+	"context"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	// headerRequestID is the header carrying the ID of a request, set by the client or generated
+	headerRequestID string = "X-Request-ID"
+	// maxRequestIDLength is the maximum length of a request ID set by a client
+	maxRequestIDLength int = 128
+)
+
+// middleware wraps a handler with behaviour common to several routes
+type middleware func(next http.Handler) http.Handler
+
+// MiddlewareConfig configures the built-in middlewares of the web adapter
+type MiddlewareConfig struct {
+	// AllowedOrigins lists the origins allowed to call the APIs from browsers, "*" allowing any. CORS is disabled when empty
+	AllowedOrigins []string
+	// MaxBodyBytes is the maximum size of a request body sent to the APIs. Bodies are not limited when 0
+	MaxBodyBytes int64
+}
+
+// routerMiddlewares lists the middlewares of each router, in order from the outermost
+//
+//	The base middlewares wrap every request of the service, including the requests not matching any route, and the middlewares
+//	of the main and admin routers wrap their own routes and the routes of their subrouters.
+type routerMiddlewares struct {
+	base  []middleware
+	main  []middleware
+	admin []middleware
+}
+
+// middlewares returns the built-in middlewares of each router
+func (a Adapter) middlewares() routerMiddlewares {
+	baseHeaders := map[string]string{"X-Content-Type-Options": "nosniff", "X-Frame-Options": "DENY", "Referrer-Policy": "no-referrer"}
+	if strings.HasPrefix(a.baseURL, "https://") {
+		baseHeaders["Strict-Transport-Security"] = "max-age=31536000"
+	}
+	// the API responses are data only, unlike the documentation UI
+	apiHeaders := map[string]string{"Content-Security-Policy": "default-src 'none'; frame-ancestors 'none'", "Cache-Control": "no-store"}
+
+	return routerMiddlewares{
+		base: []middleware{requestIDMiddleware, a.logMiddleware, recoverMiddleware, securityHeadersMiddleware(baseHeaders),
+			corsMiddleware(a.middlewareConfig.AllowedOrigins), gzipMiddleware},
+		main:  []middleware{securityHeadersMiddleware(apiHeaders), bodyLimitMiddleware(a.middlewareConfig.MaxBodyBytes)},
+		admin: []middleware{},
+	}
+}
+
+// chain wraps a handler with the given middlewares, the first being the outermost
+func chain(handler http.Handler, middlewares ...middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// use adds the given middlewares to a router, the first being the outermost
+func use(router *mux.Router, middlewares ...middleware) {
+	for _, m := range middlewares {
+		router.Use(mux.MiddlewareFunc(m))
+	}
+}
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// requestIDMiddleware sets the ID of each request from its X-Request-ID header, or generates one, and returns it in the response
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(headerRequestID)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(headerRequestID, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// validRequestID checks that a request ID set by a client is short and printable, so that it can be logged and returned as is
+func validRequestID(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestID returns the ID of the request with the given context
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logMiddleware creates the log of each request, which is available to the inner handlers from the request context
+func (a Adapter) logMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logObj := a.logger.NewRequestLog(r)
+		if id := requestID(r.Context()); len(id) > 0 {
+			logObj.SetContext("request_id", id)
+		}
+		logObj.RequestReceived()
+
+		writer := &responseWriter{ResponseWriter: w, log: logObj}
+		ctx := context.WithValue(r.Context(), requestContextKey{}, requestContext{log: logObj})
+		next.ServeHTTP(writer, r.WithContext(ctx))
+
+		logObj.SetContext("status_code", writer.statusCode)
+		logObj.RequestComplete()
+	})
+}
+
+// recoverMiddleware logs the panics of the inner handlers and sends an internal error response if none was sent yet
+func recoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// the server aborts the response of this panic without logging it
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logObj := requestLog(r.Context())
+			logObj.SetContext("stack", string(debug.Stack()))
+			err := errors.Newf("panic handling request: %v", recovered)
+			if responseStatusCode(w) != 0 {
+				logObj.LogError("error after sending the response", err)
+				return
+			}
+			handleError(w, r, err)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// securityHeadersMiddleware sets the given headers on every response
+func securityHeadersMiddleware(headers map[string]string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// corsMiddleware allows the given origins to call the APIs from browsers, answering the preflight requests
+func corsMiddleware(allowedOrigins []string) middleware {
+	allowAll := false
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+	allowedMethods := strings.Join([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}, ", ")
+	allowedHeaders := strings.Join([]string{"Authorization", "Content-Type", "If-Match", "Idempotency-Key", headerRequestID, "traceparent", "tracestate"}, ", ")
+	exposedHeaders := strings.Join([]string{"ETag", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", headerRequestID, "trace-id", "span-id"}, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if len(origin) == 0 || (!allowAll && !allowed[origin]) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			if allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if r.Method != http.MethodOptions || len(r.Header.Get("Access-Control-Request-Method")) == 0 {
+				w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// gzipWriters pools the gzip writers of the compressed responses
+var gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+
+// gzipMiddleware compresses the responses of the requests accepting the gzip encoding
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: w}
+		defer writer.close()
+		next.ServeHTTP(writer, r)
+	})
+}
+
+// acceptsGzip checks if an Accept-Encoding header accepts the gzip encoding
+func acceptsGzip(acceptEncoding string) bool {
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) == "gzip" {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the body of a response, unless it has no body, is already encoded or is an event stream
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	header := w.Header()
+	compress := statusCode != http.StatusNoContent && statusCode != http.StatusNotModified && len(header.Get("Content-Encoding")) == 0 &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+	if compress {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		if len(w.Header().Get("Content-Type")) == 0 {
+			w.Header().Set("Content-Type", http.DetectContentType(data))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+// Flush sends the data compressed so far to the client
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	// the wrapped writers do not all implement http.Flusher, so they are unwrapped by the controller
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack lets the handlers take over the connection, as the swagger UI does not need compression
+func (w *gzipResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, stderrors.New("hijacking is not supported")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped response writer
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *gzipResponseWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close()
+	w.gz.Reset(io.Discard)
+	gzipWriters.Put(w.gz)
+	w.gz = nil
+}
+
+// bodyLimitMiddleware rejects the requests with a body larger than maxBytes, when maxBytes is positive
+func bodyLimitMiddleware(maxBytes int64) middleware {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				handleError(w, r, errors.ErrorData(logutils.StatusInvalid, logutils.TypeRequestBody, &logutils.FieldArgs{"content_length": r.ContentLength, "max_bytes": maxBytes}).SetStatus(utils.ErrorStatusTooLarge))
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bodyTooLargeError returns an error with the too large status if a request failed because its body exceeded the size limit, otherwise nil
+func bodyTooLargeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if !stderrors.As(err, &maxBytesErr) {
+		return nil
+	}
+	return errors.WrapErrorData(logutils.StatusInvalid, logutils.TypeRequestBody, &logutils.FieldArgs{"max_bytes": maxBytesErr.Limit}, err).SetStatus(utils.ErrorStatusTooLarge)
+}
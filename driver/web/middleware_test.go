@@ -0,0 +1,162 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_Recover(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	handler := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { panic("handler failed") }),
+		requestIDMiddleware, adapter.logMiddleware, recoverMiddleware)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/skills-to-jobs/api/crosswalks", nil))
+	if recorder.Code != http.StatusInternalServerError || !strings.Contains(recorder.Body.String(), "handler failed") {
+		t.Errorf("panic response = %d %s, want %d", recorder.Code, recorder.Body.String(), http.StatusInternalServerError)
+	}
+	if len(recorder.Header().Get(headerRequestID)) == 0 {
+		t.Errorf("panic response headers = %v, want %s", recorder.Header(), headerRequestID)
+	}
+}
+
+func TestMiddleware_RequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"client ID", "abc-123", "abc-123"},
+		{"generated ID", "", ""},
+		{"invalid ID", "has spaces", ""},
+		{"too long ID", strings.Repeat("a", maxRequestIDLength+1), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contextID string
+			handler := requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { contextID = requestID(r.Context()) }))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(headerRequestID, tt.header)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			got := recorder.Header().Get(headerRequestID)
+			if got != contextID || len(got) == 0 || (len(tt.want) > 0 && got != tt.want) || (len(tt.want) == 0 && got == tt.header) {
+				t.Errorf("request ID = %q, context %q, want %q", got, contextID, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_CORS(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	adapter.middlewareConfig.AllowedOrigins = []string{"https://app.example.com"}
+	routes := adapter.routes()
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/skills-to-jobs/api/survey-data", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		recorder := httptest.NewRecorder()
+		routes.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	response := preflight("https://app.example.com")
+	if response.Code != http.StatusNoContent || response.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		!strings.Contains(response.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key") {
+		t.Errorf("allowed preflight = %d %v, want %d with CORS headers", response.Code, response.Header(), http.StatusNoContent)
+	}
+
+	response = preflight("https://other.example.com")
+	if len(response.Header().Get("Access-Control-Allow-Origin")) > 0 {
+		t.Errorf("denied preflight headers = %v, want no Access-Control-Allow-Origin", response.Header())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/skills-to-jobs/api/crosswalks", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	recorder := httptest.NewRecorder()
+	routes.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Header().Get("Access-Control-Expose-Headers"), "ETag") {
+		t.Errorf("allowed request = %d %v, want %d with exposed headers", recorder.Code, recorder.Header(), http.StatusOK)
+	}
+}
+
+func TestMiddleware_Gzip(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	routes := adapter.routes()
+
+	req := httptest.NewRequest(http.MethodGet, "/skills-to-jobs/version", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	recorder := httptest.NewRecorder()
+	routes.ServeHTTP(recorder, req)
+	if recorder.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("response headers = %v, want gzip encoding", recorder.Header())
+	}
+	reader, err := gzip.NewReader(recorder.Body)
+	if err != nil {
+		t.Fatalf("error reading gzip body: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil || string(body) != "test" {
+		t.Errorf("body = %q, %v, want %q", body, err, "test")
+	}
+
+	response := serve(adapter, http.MethodGet, "/version", "")
+	if len(response.Header().Get("Content-Encoding")) > 0 || response.Body.String() != "test" {
+		t.Errorf("uncompressed response = %v %q, want %q", response.Header(), response.Body.String(), "test")
+	}
+}
+
+func TestMiddleware_SecurityHeaders(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	response := serve(adapter, http.MethodGet, "/api/crosswalks", "")
+	if response.Header().Get("X-Content-Type-Options") != "nosniff" || response.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("API response headers = %v, want security headers", response.Header())
+	}
+
+	response = serve(adapter, http.MethodGet, "/version", "")
+	if response.Header().Get("X-Content-Type-Options") != "nosniff" || len(response.Header().Get("Content-Security-Policy")) > 0 {
+		t.Errorf("base response headers = %v, want base security headers only", response.Header())
+	}
+}
+
+func TestMiddleware_BodyLimit(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	adapter.middlewareConfig.MaxBodyBytes = 64
+
+	response := serve(adapter, http.MethodPost, "/api/survey-data", surveyBody(nil))
+	if response.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body status = %d, want %d: %s", response.Code, http.StatusRequestEntityTooLarge, response.Body.String())
+	}
+
+	// bodies of unknown length are limited while being read
+	req := httptest.NewRequest(http.MethodPost, "/skills-to-jobs/api/survey-data", io.MultiReader(strings.NewReader(surveyBody(nil))))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	adapter.routes().ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("streamed large body status = %d, want %d: %s", recorder.Code, http.StatusRequestEntityTooLarge, recorder.Body.String())
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core/model"
+	"application/utils"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+// rateLimit takes a request of the current account from the rate limit of the route and sets the RateLimit headers,
+// sending the error response and returning false if the limit is exceeded
+//
+//	Requests without claims are not limited. Requests are let through if the limit cannot be checked.
+func (a Adapter) rateLimit(w http.ResponseWriter, r *http.Request) bool {
+	claims := requestClaims(r.Context())
+	current := mux.CurrentRoute(r)
+	if claims == nil || current == nil {
+		return true
+	}
+	template, err := current.GetPathTemplate()
+	if err != nil {
+		return true
+	}
+
+	route := r.Method + " " + strings.TrimPrefix(template, "/"+a.serviceID)
+	status, err := a.app.Default.TakeRateLimit(route, claims.Subject, claims.AppID, claims.OrgID)
+	if err != nil {
+		requestLog(r.Context()).WarnError("error checking the rate limit", err)
+		return true
+	}
+	if status == nil {
+		return true
+	}
+
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	w.Header().Set("RateLimit-Reset", headerSeconds(status.Reset))
+	if status.Allowed {
+		return true
+	}
+
+	w.Header().Set("Retry-After", headerSeconds(status.RetryAfter))
+	handleError(w, r, errors.ErrorData(logutils.StatusInvalid, model.TypeRateLimit, &logutils.FieldArgs{"route": route}).SetStatus(utils.ErrorStatusRateLimited))
+	return false
+}
+
+// headerSeconds formats a duration as the whole number of seconds in a header, rounded up
+func headerSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
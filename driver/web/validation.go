@@ -0,0 +1,262 @@
+// Copyright 2022 Board of Trustees of the University of Illinois.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"application/core/model"
+	"application/utils"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+	"github.com/rokwire/logging-library-go/v2/errors"
+	"github.com/rokwire/logging-library-go/v2/logutils"
+)
+
+const (
+	typeOpenAPISpec logutils.MessageDataType = "openapi spec"
+	typeRoute       logutils.MessageDataType = "route"
+)
+
+// openAPIValidator validates requests, and optionally responses, against the OpenAPI spec of the service
+type openAPIValidator struct {
+	doc        *openapi3.T
+	pathPrefix string
+
+	validateResponses bool
+}
+
+// middleware validates the request before calling the handler, and validates its response if enabled
+func (v *openAPIValidator) middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := v.findRoute(r)
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		// clients have always been allowed to omit the content type of JSON bodies
+		if len(r.Header.Get("Content-Type")) == 0 && r.ContentLength != 0 && route.Operation.RequestBody != nil {
+			r.Header.Set("Content-Type", "application/json")
+		}
+
+		input := &openapi3filter.RequestValidationInput{Request: r, PathParams: pathParams, Route: route,
+			Options: &openapi3filter.Options{MultiError: true, AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}}
+		err = openapi3filter.ValidateRequest(r.Context(), input)
+		if tooLargeErr := bodyTooLargeError(err); tooLargeErr != nil {
+			handleError(w, r, tooLargeErr)
+			return
+		}
+		if err != nil {
+			handleError(w, r, requestValidationError(err))
+			return
+		}
+
+		if !v.validateResponses || streamsEvents(route.Operation) {
+			next(w, r)
+			return
+		}
+
+		recorder := &responseRecorder{header: http.Header{}}
+		next(recorder, r)
+
+		responseInput := &openapi3filter.ResponseValidationInput{RequestValidationInput: input, Status: recorder.statusCode, Header: recorder.header,
+			Body: io.NopCloser(bytes.NewReader(recorder.body.Bytes())), Options: &openapi3filter.Options{MultiError: true, IncludeResponseStatus: true}}
+		err = openapi3filter.ValidateResponse(r.Context(), responseInput)
+		if err != nil {
+			handleError(w, r, errors.WrapErrorAction(logutils.ActionValidate, logutils.TypeResponse, &logutils.FieldArgs{"status_code": recorder.statusCode}, err))
+			return
+		}
+		recorder.copyTo(w)
+	}
+}
+
+// streamsEvents checks if an operation responds with a stream of server-sent events, which cannot be buffered to be validated
+func streamsEvents(operation *openapi3.Operation) bool {
+	response := operation.Responses.Get(http.StatusOK)
+	return response != nil && response.Value != nil && response.Value.Content.Get("text/event-stream") != nil
+}
+
+// findRoute returns the spec operation matching the mux route of a request
+func (v *openAPIValidator) findRoute(r *http.Request) (*routers.Route, map[string]string, error) {
+	current := mux.CurrentRoute(r)
+	if current == nil {
+		return nil, nil, errors.ErrorData(logutils.StatusMissing, typeRoute, &logutils.FieldArgs{"path": r.URL.Path})
+	}
+	template, err := current.GetPathTemplate()
+	if err != nil {
+		return nil, nil, errors.WrapErrorAction(logutils.ActionGet, typeRoute, &logutils.FieldArgs{"path": r.URL.Path}, err)
+	}
+
+	path := strings.TrimPrefix(template, v.pathPrefix)
+	pathItem := v.doc.Paths.Find(path)
+	if pathItem == nil || pathItem.GetOperation(r.Method) == nil {
+		return nil, nil, errors.ErrorData(logutils.StatusMissing, typeOpenAPISpec, &logutils.FieldArgs{"path": path, "method": r.Method})
+	}
+
+	route := &routers.Route{Spec: v.doc, Path: path, PathItem: pathItem, Method: r.Method, Operation: pathItem.GetOperation(r.Method)}
+	return route, mux.Vars(r), nil
+}
+
+// requestValidationError converts the failures of a request validation to the fields of a validation error.
+// Body schema failures are unprocessable, any other failure makes the request invalid
+func requestValidationError(err error) error {
+	validation := &model.ValidationError{DataType: logutils.TypeRequest}
+	status := utils.ErrorStatusUnprocessable
+	for _, failure := range flattenErrors(err) {
+		requestErr, ok := failure.(*openapi3filter.RequestError)
+		if !ok {
+			validation.Add("request", "%s", failure.Error())
+			status = utils.ErrorStatusInvalid
+			continue
+		}
+
+		if requestErr.Parameter != nil {
+			validation.Add(requestErr.Parameter.Name, "%s", requestFailureReason(requestErr))
+			status = utils.ErrorStatusInvalid
+			continue
+		}
+
+		schemaErrors := schemaErrors(requestErr.Err)
+		if len(schemaErrors) == 0 {
+			validation.Add("body", "%s", requestFailureReason(requestErr))
+			status = utils.ErrorStatusInvalid
+			continue
+		}
+		for _, schemaErr := range schemaErrors {
+			validation.Add(fieldName(schemaErr.JSONPointer()), "%s", schemaErr.Reason)
+		}
+	}
+
+	return errors.WrapErrorAction(logutils.ActionValidate, logutils.TypeRequest, nil, validation).SetStatus(status)
+}
+
+// requestFailureReason returns the reason of a request failure, without the details of the failed schema
+func requestFailureReason(err *openapi3filter.RequestError) string {
+	if schemaErrors := schemaErrors(err.Err); len(schemaErrors) > 0 {
+		return schemaErrors[0].Reason
+	}
+	if len(err.Reason) > 0 {
+		return err.Reason
+	}
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+	return "invalid value"
+}
+
+// schemaErrors returns the schema failures contained in an error
+func schemaErrors(err error) []*openapi3.SchemaError {
+	var schemaErrors []*openapi3.SchemaError
+	for _, failure := range flattenErrors(err) {
+		if schemaErr, ok := failure.(*openapi3.SchemaError); ok {
+			schemaErrors = append(schemaErrors, schemaErr)
+		}
+	}
+	return schemaErrors
+}
+
+// flattenErrors lists the errors contained in nested multi errors
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multiErr, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var flattened []error
+	for _, item := range multiErr {
+		flattened = append(flattened, flattenErrors(item)...)
+	}
+	return flattened
+}
+
+// fieldName formats a JSON pointer to a body value as a field name, for example scores[0].score
+func fieldName(pointer []string) string {
+	if len(pointer) == 0 {
+		return "body"
+	}
+
+	var name strings.Builder
+	for _, part := range pointer {
+		if _, err := strconv.Atoi(part); err == nil && name.Len() > 0 {
+			name.WriteString("[" + part + "]")
+			continue
+		}
+		if name.Len() > 0 {
+			name.WriteString(".")
+		}
+		name.WriteString(part)
+	}
+	return name.String()
+}
+
+// responseRecorder buffers a response so it can be validated before being sent
+type responseRecorder struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(data)
+}
+
+// copyTo sends the buffered response to the given writer
+func (r *responseRecorder) copyTo(w http.ResponseWriter) {
+	for key, values := range r.header {
+		w.Header()[key] = values
+	}
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	w.WriteHeader(r.statusCode)
+	w.Write(r.body.Bytes())
+}
+
+// newOpenAPIValidator loads the OpenAPI spec at the given path and creates a validator for the routes under the path prefix
+func newOpenAPIValidator(specPath string, pathPrefix string, validateResponses bool) (*openAPIValidator, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionLoad, typeOpenAPISpec, &logutils.FieldArgs{"path": specPath}, err)
+	}
+	err = doc.Validate(context.Background())
+	if err != nil {
+		return nil, errors.WrapErrorAction(logutils.ActionValidate, typeOpenAPISpec, &logutils.FieldArgs{"path": specPath}, err)
+	}
+
+	return &openAPIValidator{doc: doc, pathPrefix: pathPrefix, validateResponses: validateResponses}, nil
+}
@@ -16,9 +16,22 @@ package main
 
 import (
 	"application/core"
+	"application/core/interfaces"
+	"application/driven/crosswalk"
+	"application/driven/memory"
+	"application/driven/metrics"
+	"application/driven/ratelimit"
 	"application/driven/storage"
+	"application/driven/tracing"
 	"application/driver/web"
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/rokwire/core-auth-library-go/v3/authservice"
 	"github.com/rokwire/core-auth-library-go/v3/envloader"
@@ -39,7 +52,8 @@ func main() {
 
 	serviceID := "skills-to-jobs"
 
-	loggerOpts := logs.LoggerOpts{SuppressRequests: logs.NewStandardHealthCheckHTTPRequestProperties(serviceID + "/version")}
+	suppressRequests := append(logs.NewStandardHealthCheckHTTPRequestProperties(serviceID+"/version"), logs.HTTPRequestProperties{Method: http.MethodGet, Path: "/" + serviceID + "/metrics"})
+	loggerOpts := logs.LoggerOpts{SuppressRequests: suppressRequests}
 	logger := logs.NewLogger(serviceID, &loggerOpts)
 	envLoader := envloader.NewEnvLoader(Version, logger)
 
@@ -49,18 +63,85 @@ func main() {
 		port = "80"
 	}
 
-	// mongoDB adapter
-	mongoDBAuth := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_AUTH", true, true)
-	mongoDBName := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_DATABASE", true, false)
-	mongoTimeout := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_TIMEOUT", false, false)
-	storageAdapter := storage.NewStorageAdapter(mongoDBAuth, mongoDBName, mongoTimeout, logger)
-	err := storageAdapter.Start()
+	// durationEnvVar returns the duration set in seconds by the environment variable, or the default duration when not set
+	durationEnvVar := func(name string, defaultDuration time.Duration) time.Duration {
+		value := envLoader.GetAndLogEnvVar(envPrefix+name, false, false)
+		if len(value) == 0 {
+			return defaultDuration
+		}
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			logger.Fatalf("Invalid %s: %s", name, value)
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	// tracing adapter
+	tracesExporter := envLoader.GetAndLogEnvVar(envPrefix+"TRACES_EXPORTER", false, false)
+	tracingAdapter := tracing.NewTracingAdapter(tracesExporter, serviceID, Version, logger)
+	err := tracingAdapter.Start()
 	if err != nil {
-		logger.Fatalf("Cannot start the mongoDB adapter: %v", err)
+		logger.Fatalf("Cannot start the tracing adapter: %v", err)
+	}
+
+	// metrics adapter
+	metricsAdapter := metrics.NewMetricsAdapter()
+
+	// storage adapter
+	var storageAdapter interfaces.Storage
+	var stopStorage func(ctx context.Context) error
+	storageType := envLoader.GetAndLogEnvVar(envPrefix+"STORAGE", false, false)
+	switch storageType {
+	case "", "mongodb":
+		mongoDBAuth := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_AUTH", true, true)
+		mongoDBName := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_DATABASE", true, false)
+		mongoTimeout := envLoader.GetAndLogEnvVar(envPrefix+"MONGO_TIMEOUT", false, false)
+		encryptionKeys := envLoader.GetAndLogEnvVar(envPrefix+"ENCRYPTION_KEYS", false, true)
+		mongoAdapter := storage.NewStorageAdapter(mongoDBAuth, mongoDBName, mongoTimeout, encryptionKeys, metricsAdapter, logger)
+		err := mongoAdapter.Start()
+		if err != nil {
+			logger.Fatalf("Cannot start the mongoDB adapter: %v", err)
+		}
+		storageAdapter = mongoAdapter
+		stopStorage = mongoAdapter.Stop
+	case "memory":
+		fixturesDir := envLoader.GetAndLogEnvVar(envPrefix+"STORAGE_FIXTURES_DIR", false, false)
+		memoryAdapter := memory.NewStorageAdapter(fixturesDir, logger)
+		err := memoryAdapter.Start()
+		if err != nil {
+			logger.Fatalf("Cannot start the memory storage adapter: %v", err)
+		}
+		storageAdapter = memoryAdapter
+		stopStorage = memoryAdapter.Stop
+	default:
+		logger.Fatalf("Invalid storage type: %s", storageType)
 	}
 
+	// crosswalk adapter
+	crosswalksDir := envLoader.GetAndLogEnvVar(envPrefix+"CROSSWALKS_DIR", false, false)
+	if len(crosswalksDir) == 0 {
+		crosswalksDir = "./crosswalks"
+	}
+	crosswalkAdapter := crosswalk.NewCrosswalkAdapter(crosswalksDir, logger)
+	err = crosswalkAdapter.Start()
+	if err != nil {
+		logger.Fatalf("Cannot start the crosswalk adapter: %v", err)
+	}
+
+	// rate limit adapter
+	rateLimitAdapter := ratelimit.NewRateLimitAdapter()
+
 	// application
-	application := core.NewApplication(Version, Build, storageAdapter, logger)
+	idempotencyWindowHours := 24
+	idempotencyWindow := envLoader.GetAndLogEnvVar(envPrefix+"IDEMPOTENCY_WINDOW_HOURS", false, false)
+	if len(idempotencyWindow) > 0 {
+		idempotencyWindowHours, err = strconv.Atoi(idempotencyWindow)
+		if err != nil || idempotencyWindowHours <= 0 {
+			logger.Fatalf("Invalid idempotency window: %s", idempotencyWindow)
+		}
+	}
+
+	application := core.NewApplication(Version, Build, storageAdapter, crosswalkAdapter, rateLimitAdapter, metricsAdapter, time.Duration(idempotencyWindowHours)*time.Hour, logger)
 	application.Start()
 
 	// web adapter
@@ -84,6 +165,61 @@ func main() {
 		logger.Fatalf("Error initializing service registration manager: %v", err)
 	}
 
-	webAdapter := web.NewWebAdapter(baseURL, port, serviceID, application, serviceRegManager, logger)
-	webAdapter.Start()
+	validateResponses, _ := strconv.ParseBool(envLoader.GetAndLogEnvVar(envPrefix+"VALIDATE_RESPONSES", false, false))
+
+	middlewareConfig := web.MiddlewareConfig{MaxBodyBytes: 1 << 20}
+	allowedOrigins := envLoader.GetAndLogEnvVar(envPrefix+"CORS_ALLOWED_ORIGINS", false, false)
+	if len(allowedOrigins) > 0 {
+		middlewareConfig.AllowedOrigins = strings.Split(allowedOrigins, ",")
+	}
+	maxBodyBytes := envLoader.GetAndLogEnvVar(envPrefix+"MAX_BODY_BYTES", false, false)
+	if len(maxBodyBytes) > 0 {
+		middlewareConfig.MaxBodyBytes, err = strconv.ParseInt(maxBodyBytes, 10, 64)
+		if err != nil || middlewareConfig.MaxBodyBytes < 0 {
+			logger.Fatalf("Invalid max body bytes: %s", maxBodyBytes)
+		}
+	}
+
+	serverConfig := web.ServerConfig{
+		ReadHeaderTimeout: durationEnvVar("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       durationEnvVar("HTTP_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      durationEnvVar("HTTP_WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:       durationEnvVar("HTTP_IDLE_TIMEOUT", 120*time.Second),
+	}
+	shutdownTimeout := durationEnvVar("SHUTDOWN_TIMEOUT", 30*time.Second)
+
+	webAdapter := web.NewWebAdapter(baseURL, port, serviceID, validateResponses, serverConfig, middlewareConfig, metricsAdapter, application, serviceRegManager, logger)
+	go func() {
+		err := webAdapter.Start()
+		if err != nil {
+			logger.Fatalf("Error serving: %v", err)
+		}
+	}()
+
+	// stop on SIGINT or SIGTERM, draining the requests and background work until the shutdown timeout
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	received := <-signals
+	logger.Infof("Received %s, stopping within %s", received, shutdownTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err = webAdapter.Stop(ctx)
+	if err != nil {
+		logger.Errorf("Error stopping the web adapter: %v", err)
+	}
+	err = application.Stop(ctx)
+	if err != nil {
+		logger.Errorf("Error stopping the application: %v", err)
+	}
+	err = stopStorage(ctx)
+	if err != nil {
+		logger.Errorf("Error stopping the storage adapter: %v", err)
+	}
+	err = tracingAdapter.Stop(ctx)
+	if err != nil {
+		logger.Errorf("Error stopping the tracing adapter: %v", err)
+	}
+	logger.Info("Stopped")
 }
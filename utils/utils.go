@@ -18,6 +18,27 @@ import (
 	"time"
 )
 
+const (
+	// ErrorStatusInvalid is the error status used when the provided data fails validation
+	ErrorStatusInvalid string = "invalid"
+	// ErrorStatusExists is the error status used when creating data that already exists
+	ErrorStatusExists string = "exists"
+	// ErrorStatusConflict is the error status used when a conditional update fails because the stored revision changed
+	ErrorStatusConflict string = "conflict"
+	// ErrorStatusNotFound is the error status used when the requested data does not exist
+	ErrorStatusNotFound string = "not-found"
+	// ErrorStatusUnprocessable is the error status used when well-formed data fails field-level validation
+	ErrorStatusUnprocessable string = "unprocessable"
+	// ErrorStatusRateLimited is the error status used when a client exceeds the rate limit of a route
+	ErrorStatusRateLimited string = "rate-limited"
+	// ErrorStatusInProgress is the error status used when a request is retried while the first request with the same idempotency key is in progress
+	ErrorStatusInProgress string = "in-progress"
+	// ErrorStatusTooLarge is the error status used when a request body exceeds the size limit
+	ErrorStatusTooLarge string = "too-large"
+	// ErrorStatusUnavailable is the error status used when the service is stopping and does not accept new work
+	ErrorStatusUnavailable string = "unavailable"
+)
+
 // GetInt gives the value which this pointer points. Gives 0 if the pointer is nil
 func GetInt(v *int) int {
 	if v == nil {